@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// connectAuroraWriter returns the connection if it's to the writer of an
+// Aurora cluster. Otherwise it's closed and a connection to the instance
+// endpoint of the writer is returned, as the cluster endpoint may still
+// resolve to the old writer for a while after a failover.
+func connectAuroraWriter(ctx context.Context, config *mysql.Config, conn driver.Conn) (driver.Conn, error) {
+	readOnly, err := queryDriverConnValue(ctx, conn, "SELECT @@innodb_read_only")
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if readOnly == "0" {
+		return conn, nil
+	}
+
+	writerID, err := queryDriverConnValue(ctx, conn, "SELECT server_id FROM information_schema.replica_host_status WHERE session_id = 'MASTER_SESSION_ID'")
+	conn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed finding the Aurora writer: %v", err)
+	}
+	if writerID == "" {
+		return nil, fmt.Errorf("%s is read-only and there is no Aurora writer", config.Addr)
+	}
+
+	writerAddr, err := auroraInstanceAddress(config.Addr, writerID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[WARN] %s is an Aurora reader, connecting to the writer %s", config.Addr, writerAddr)
+
+	writerConfig := config.Clone()
+	writerConfig.Addr = writerAddr
+	connector, err := mysql.NewConnector(writerConfig)
+	if err != nil {
+		return nil, err
+	}
+	conn, err = connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The writer may still be read-only while the failover is ongoing.
+	readOnly, err = queryDriverConnValue(ctx, conn, "SELECT @@innodb_read_only")
+	if err == nil && readOnly != "0" {
+		err = fmt.Errorf("the Aurora writer %s is still read-only", writerAddr)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// auroraInstanceAddress returns the address of the instance in the same
+// cluster as the endpoint, e.g. db-2.abc.eu-west-1.rds.amazonaws.com:3306 for
+// db-2 and mycluster.cluster-abc.eu-west-1.rds.amazonaws.com:3306.
+func auroraInstanceAddress(endpoint string, instance string) (string, error) {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 3 {
+		return "", fmt.Errorf("%s is not an Aurora endpoint", host)
+	}
+	labels[0] = instance
+	for _, prefix := range []string{"cluster-ro-", "cluster-custom-", "cluster-"} {
+		if strings.HasPrefix(labels[1], prefix) {
+			labels[1] = strings.TrimPrefix(labels[1], prefix)
+			break
+		}
+	}
+	return net.JoinHostPort(strings.Join(labels, "."), port), nil
+}
+
+// queryDriverConnValue returns the first column of the first row of the
+// query, or an empty string if there is none.
+func queryDriverConnValue(ctx context.Context, conn driver.Conn, query string) (string, error) {
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return "", fmt.Errorf("connection doesn't support queries")
+	}
+
+	logStatement(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, nil)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	values := make([]driver.Value, len(rows.Columns()))
+	if err := rows.Next(values); err != nil {
+		if err == io.EOF {
+			return "", nil
+		}
+		return "", err
+	}
+	switch value := values[0].(type) {
+	case nil:
+		return "", nil
+	case []byte:
+		return string(value), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
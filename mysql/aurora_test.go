@@ -0,0 +1,21 @@
+package mysql
+
+import "testing"
+
+func TestAuroraInstanceAddress(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"mycluster.cluster-abc123.eu-west-1.rds.amazonaws.com:3306":        "db-2.abc123.eu-west-1.rds.amazonaws.com:3306",
+		"mycluster.cluster-ro-abc123.eu-west-1.rds.amazonaws.com:3306":     "db-2.abc123.eu-west-1.rds.amazonaws.com:3306",
+		"reporting.cluster-custom-abc123.eu-west-1.rds.amazonaws.com:3307": "db-2.abc123.eu-west-1.rds.amazonaws.com:3307",
+		"db-1.abc123.eu-west-1.rds.amazonaws.com:3306":                     "db-2.abc123.eu-west-1.rds.amazonaws.com:3306",
+	} {
+		addr, err := auroraInstanceAddress(endpoint, "db-2")
+		if err != nil || addr != expected {
+			t.Errorf("auroraInstanceAddress(%q) = %q, %v; expected %q", endpoint, addr, err, expected)
+		}
+	}
+
+	if _, err := auroraInstanceAddress("localhost:3306", "db-2"); err == nil {
+		t.Error("expected an error for a non-Aurora endpoint")
+	}
+}
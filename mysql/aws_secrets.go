@@ -0,0 +1,109 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+type AWSSecret struct {
+	SecretID    string
+	Parameter   string
+	Region      string
+	UsernameKey string
+	PasswordKey string
+
+	// endpoint overrides the regional AWS endpoint, for tests.
+	endpoint string
+}
+
+func awsSecretFromMap(raw map[string]interface{}) AWSSecret {
+	return AWSSecret{
+		SecretID:    raw["secret_id"].(string),
+		Parameter:   raw["parameter"].(string),
+		Region:      raw["region"].(string),
+		UsernameKey: raw["username_key"].(string),
+		PasswordKey: raw["password_key"].(string),
+	}
+}
+
+// fetchAWSCredentials reads the secret from Secrets Manager or the parameter
+// from SSM Parameter Store. JSON values like the secrets of RDS-managed master
+// passwords give the username and the password; other values only the
+// password, so username is returned empty. AWS credentials and the default
+// region come from the usual chain of environment, shared config and roles.
+func fetchAWSCredentials(ctx context.Context, secret AWSSecret) (string, string, error) {
+	var opts []func(*config.LoadOptions) error
+	region := secret.Region
+	if region == "" {
+		// ARNs look like arn:aws:secretsmanager:<region>:<account>:secret:<name>.
+		if parts := strings.Split(secret.SecretID, ":"); len(parts) > 3 && parts[0] == "arn" {
+			region = parts[3]
+		}
+	}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return "", "", fmt.Errorf("failed loading AWS config: %v", err)
+	}
+	if cfg.Region == "" {
+		// The SDK doesn't read AWS_DEFAULT_REGION, unlike the AWS CLI.
+		cfg.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if cfg.Region == "" {
+		return "", "", fmt.Errorf("no AWS region given")
+	}
+
+	var value string
+	if secret.SecretID != "" {
+		client := secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+			if secret.endpoint != "" {
+				o.BaseEndpoint = aws.String(secret.endpoint)
+			}
+		})
+		resp, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(secret.SecretID),
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed reading secret %s: %v", secret.SecretID, err)
+		}
+		value = aws.ToString(resp.SecretString)
+	} else {
+		client := ssm.NewFromConfig(cfg, func(o *ssm.Options) {
+			if secret.endpoint != "" {
+				o.BaseEndpoint = aws.String(secret.endpoint)
+			}
+		})
+		resp, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(secret.Parameter),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed reading parameter %s: %v", secret.Parameter, err)
+		}
+		if resp.Parameter != nil {
+			value = aws.ToString(resp.Parameter.Value)
+		}
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return "", value, nil
+	}
+	password, ok := values[secret.PasswordKey].(string)
+	if !ok {
+		return "", "", fmt.Errorf("secret has no %q", secret.PasswordKey)
+	}
+	username, _ := values[secret.UsernameKey].(string)
+	return username, password, nil
+}
@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchAWSCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	// Keep the credentials of whoever runs the tests out.
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDEXAMPLE/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var input map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&input)
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			if !strings.Contains(r.Header.Get("Authorization"), "/us-east-1/secretsmanager/") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			io.WriteString(w, `{"SecretString": "{\"username\": \"admin\", \"password\": \"rds-managed\"}"}`)
+		case "AmazonSSM.GetParameter":
+			if input["Name"] == "/mysql/password" && input["WithDecryption"] == true {
+				io.WriteString(w, `{"Parameter": {"Value": "plain"}}`)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	username, password, err := fetchAWSCredentials(ctx, AWSSecret{
+		SecretID:    "arn:aws:secretsmanager:us-east-1:123456789012:secret:rds!db-abc",
+		UsernameKey: "username",
+		PasswordKey: "password",
+		endpoint:    server.URL,
+	})
+	if err != nil || username != "admin" || password != "rds-managed" {
+		t.Errorf("unexpected secret credentials %q, %q, %v", username, password, err)
+	}
+
+	username, password, err = fetchAWSCredentials(ctx, AWSSecret{
+		Parameter:   "/mysql/password",
+		UsernameKey: "username",
+		PasswordKey: "password",
+		endpoint:    server.URL,
+	})
+	if err != nil || username != "" || password != "plain" {
+		t.Errorf("unexpected parameter credentials %q, %q, %v", username, password, err)
+	}
+}
@@ -0,0 +1,80 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	azidentity "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+const (
+	azureCredentialDefault         = "default"
+	azureCredentialManagedIdentity = "managed_identity"
+	azureCredentialEnvironment     = "environment"
+	azureCredentialCli             = "cli"
+
+	// Tokens are refreshed this long before they expire, so connections
+	// opened just before the expiry still authenticate.
+	azureTokenRefreshMargin = 5 * time.Minute
+)
+
+func newAzureCredential(credentialType string) (azcore.TokenCredential, error) {
+	switch credentialType {
+	case azureCredentialManagedIdentity:
+		var options *azidentity.ManagedIdentityCredentialOptions
+		if clientID := os.Getenv("AZURE_CLIENT_ID"); clientID != "" {
+			options = &azidentity.ManagedIdentityCredentialOptions{ID: azidentity.ClientID(clientID)}
+		}
+		return azidentity.NewManagedIdentityCredential(options)
+	case azureCredentialEnvironment:
+		return azidentity.NewEnvironmentCredential(nil)
+	case azureCredentialCli:
+		return azidentity.NewAzureCLICredential(nil)
+	default:
+		return azidentity.NewDefaultAzureCredential(nil)
+	}
+}
+
+func azureTokenScope() string {
+	azScope := "https://ossrdbms-aad.database.windows.net"
+	if os.Getenv("ARM_ENVIRONMENT") == "china" {
+		azScope = "https://ossrdbms-aad.database.chinacloudapi.cn"
+	} else if os.Getenv("ARM_ENVIRONMENT") == "german" {
+		azScope = "https://ossrdbms-aad.database.chinacloudapi.de"
+	} else if os.Getenv("ARM_ENVIRONMENT") == "usgovernment" {
+		azScope = "https://ossrdbms-aad.database.usgovcloudapi.net"
+	}
+	return azScope + "/.default"
+}
+
+// azureTokenSource caches the Azure AD access token used as the password and
+// gets a new one when it's about to expire, as tokens only live for about an
+// hour, which long applies easily outlast.
+type azureTokenSource struct {
+	credential azcore.TokenCredential
+	scope      string
+
+	mtx   sync.Mutex
+	token azcore.AccessToken
+}
+
+func (s *azureTokenSource) Password(ctx context.Context) (string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if time.Until(s.token.ExpiresOn) > azureTokenRefreshMargin {
+		return s.token.Token, nil
+	}
+
+	token, err := s.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{s.scope}})
+	if err != nil {
+		return "", fmt.Errorf("failed to get token from Azure AD %v", err)
+	}
+	s.token = token
+	return token.Token, nil
+}
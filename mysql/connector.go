@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// failoverConnector opens connections to the first endpoint that accepts
+// them, starting with the one that worked last, and with the password returned
+// by passwordFunc at the time if it's set. With auroraWriter, connections to
+// Aurora readers are replaced by ones to the writer. The initCommands are run
+// on every new connection. With transientRetry, statements failing with
+// transient errors are retried. With metrics, statements are counted. With
+// recordStatements, statements of resources in dry run are recorded instead,
+// and the ones of resources showing their statements are recorded as well.
+type failoverConnector struct {
+	config           *mysql.Config
+	endpoints        []string
+	passwordFunc     func(ctx context.Context) (string, error)
+	auroraWriter     bool
+	initCommands     []string
+	transientRetry   *RetryPolicy
+	metrics          *queryMetrics
+	recordStatements bool
+
+	mtx     sync.Mutex
+	current int
+}
+
+func newFailoverConnector(conf *MySQLConfiguration) *failoverConnector {
+	return &failoverConnector{
+		config:           conf.Config,
+		endpoints:        append([]string{conf.Config.Addr}, conf.FallbackEndpoints...),
+		passwordFunc:     conf.PasswordFunc,
+		auroraWriter:     conf.AuroraWriter,
+		initCommands:     conf.InitCommands,
+		transientRetry:   conf.TransientRetry,
+		metrics:          conf.Metrics,
+		recordStatements: conf.DryRun || conf.ShowStatements,
+	}
+}
+
+func (c *failoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.transientRetry != nil {
+		conn = &retryConn{conn: conn, connect: c.connect, policy: *c.transientRetry, metrics: c.metrics}
+	}
+	if c.metrics != nil {
+		conn = &metricsConn{Conn: conn, metrics: c.metrics}
+	}
+	if c.recordStatements {
+		conn = &dryRunConn{Conn: conn}
+	}
+	return conn, nil
+}
+
+func (c *failoverConnector) connect(ctx context.Context) (driver.Conn, error) {
+	config := c.config.Clone()
+	if c.passwordFunc != nil {
+		password, err := c.passwordFunc(ctx)
+		if err != nil {
+			return nil, err
+		}
+		config.Passwd = password
+	}
+
+	c.mtx.Lock()
+	start := c.current
+	c.mtx.Unlock()
+
+	var lastErr error
+	for i := range c.endpoints {
+		index := (start + i) % len(c.endpoints)
+		config.Addr = c.endpoints[index]
+
+		connector, err := mysql.NewConnector(config)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := connector.Connect(ctx)
+		if err == nil {
+			c.mtx.Lock()
+			c.current = index
+			c.mtx.Unlock()
+			if c.auroraWriter {
+				conn, err = connectAuroraWriter(ctx, config, conn)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if err := c.runInitCommands(ctx, conn); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return conn, nil
+		}
+		// The server answered, so other endpoints won't do any better.
+		if mysqlErrorNumber(err) != 0 || ctx.Err() != nil {
+			return nil, err
+		}
+
+		log.Printf("[WARN] Connecting to %s failed: %v", config.Addr, err)
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (c *failoverConnector) runInitCommands(ctx context.Context, conn driver.Conn) error {
+	if len(c.initCommands) == 0 {
+		return nil
+	}
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		return fmt.Errorf("connection doesn't support running init_commands")
+	}
+	for _, command := range c.initCommands {
+		logStatement(ctx, command)
+		if _, err := execer.ExecContext(ctx, command, nil); err != nil {
+			return fmt.Errorf("failed running init command %q: %w", command, err)
+		}
+	}
+	return nil
+}
+
+func (c *failoverConnector) Driver() driver.Driver {
+	return &mysql.MySQLDriver{}
+}
@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBinaryLogs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowBinaryLogs,
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"file": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"position": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"executed_gtid_set": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"encrypted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowBinaryLogs(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := showBinaryLogStatus(ctx, db, meta)
+	if err != nil {
+		return diag.Errorf("failed reading binary log status: %v", err)
+	}
+
+	// Servers without binary logging return no status, and SHOW BINARY LOGS
+	// fails on them.
+	logs := []map[string]interface{}{}
+	if status != nil {
+		stmtSQL := "SHOW BINARY LOGS"
+		logStatement(ctx, stmtSQL)
+		rows, err := queryStatusRows(ctx, db, stmtSQL)
+		if err != nil {
+			return diag.Errorf("failed reading binary logs: %v", err)
+		}
+		for _, row := range rows {
+			size, _ := strconv.ParseInt(row["File_size"], 10, 64)
+			logs = append(logs, map[string]interface{}{
+				"name":      row["Log_name"],
+				"size":      size,
+				"encrypted": row["Encrypted"] == "Yes",
+			})
+		}
+	}
+
+	position, _ := strconv.ParseInt(status["Position"], 10, 64)
+	d.Set("enabled", status != nil)
+	d.Set("file", status["File"])
+	d.Set("position", position)
+	d.Set("executed_gtid_set", status["Executed_Gtid_Set"])
+	if err := d.Set("logs", logs); err != nil {
+		return diag.Errorf("failed setting logs field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
+
+// showBinaryLogStatus returns the current binary log coordinates, or nil if
+// binary logging is off. MySQL 8.2 renamed SHOW MASTER STATUS.
+func showBinaryLogStatus(ctx context.Context, db *sql.DB, meta interface{}) (map[string]string, error) {
+	stmtSQL := "SHOW MASTER STATUS"
+	requiredVersion, _ := version.NewVersion("8.2.0")
+	if getFlavorFromMeta(ctx, meta) == flavorMySQL && !getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		stmtSQL = "SHOW BINARY LOG STATUS"
+	}
+	logStatement(ctx, stmtSQL)
+
+	return queryStatusRow(ctx, db, stmtSQL)
+}
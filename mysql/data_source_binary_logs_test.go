@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBinaryLogs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipRds(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBinaryLogsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mysql_binary_logs.test", "enabled"),
+					resource.TestCheckResourceAttrSet("data.mysql_binary_logs.test", "position"),
+					resource.TestCheckResourceAttrSet("data.mysql_binary_logs.test", "logs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccBinaryLogsConfig_basic = `
+data "mysql_binary_logs" "test" {}
+`
@@ -0,0 +1,154 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCharsets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowCharsets,
+		Schema: map[string]*schema.Schema{
+			"pattern": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_character_set": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_collation": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"charsets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_collation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"max_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"collations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowCharsets(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var defaultCharset, defaultCollation string
+	stmtSQL := "SELECT @@character_set_server, @@collation_server"
+	logStatement(ctx, stmtSQL)
+	if err := db.QueryRowContext(ctx, stmtSQL).Scan(&defaultCharset, &defaultCollation); err != nil {
+		return diag.Errorf("failed reading server defaults: %v", err)
+	}
+
+	pattern := d.Get("pattern").(string)
+
+	stmtSQL = "SELECT CHARACTER_SET_NAME, DEFAULT_COLLATE_NAME, DESCRIPTION, MAXLEN FROM INFORMATION_SCHEMA.CHARACTER_SETS"
+	args := []interface{}{}
+	if pattern != "" {
+		stmtSQL += " WHERE CHARACTER_SET_NAME LIKE ?"
+		args = append(args, pattern)
+	}
+	stmtSQL += " ORDER BY CHARACTER_SET_NAME"
+	logStatement(ctx, stmtSQL)
+
+	rows, err := db.QueryContext(ctx, stmtSQL, args...)
+	if err != nil {
+		return diag.Errorf("failed querying for character sets: %v", err)
+	}
+	defer rows.Close()
+
+	var charsets []map[string]interface{}
+	for rows.Next() {
+		var name, collation, description string
+		var maxLength int
+
+		if err := rows.Scan(&name, &collation, &description, &maxLength); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+
+		charsets = append(charsets, map[string]interface{}{
+			"name":              name,
+			"default_collation": collation,
+			"description":       description,
+			"max_length":        maxLength,
+			"collations":        []string{},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading character sets: %v", err)
+	}
+
+	stmtSQL = "SELECT COLLATION_NAME, CHARACTER_SET_NAME FROM INFORMATION_SCHEMA.COLLATIONS ORDER BY COLLATION_NAME"
+	logStatement(ctx, stmtSQL)
+
+	collationRows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		return diag.Errorf("failed querying for collations: %v", err)
+	}
+	defer collationRows.Close()
+
+	collations := map[string][]string{}
+	for collationRows.Next() {
+		var name string
+		// MariaDB lists collations usable with several character sets
+		// without one.
+		var charset sql.NullString
+
+		if err := collationRows.Scan(&name, &charset); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+		if charset.Valid {
+			collations[charset.String] = append(collations[charset.String], name)
+		}
+	}
+	if err := collationRows.Err(); err != nil {
+		return diag.Errorf("failed reading collations: %v", err)
+	}
+
+	for _, charset := range charsets {
+		if names, ok := collations[charset["name"].(string)]; ok {
+			charset["collations"] = names
+		}
+	}
+
+	d.Set("default_character_set", defaultCharset)
+	d.Set("default_collation", defaultCollation)
+	if err := d.Set("charsets", charsets); err != nil {
+		return diag.Errorf("failed setting charsets field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCharsets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "mysql_charsets" "test" {
+  pattern = "utf8mb4"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mysql_charsets.test", "default_character_set"),
+					resource.TestCheckResourceAttrSet("data.mysql_charsets.test", "default_collation"),
+					resource.TestCheckResourceAttr("data.mysql_charsets.test", "charsets.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_charsets.test", "charsets.0.name", "utf8mb4"),
+					resource.TestCheckResourceAttr("data.mysql_charsets.test", "charsets.0.max_length", "4"),
+					resource.TestCheckResourceAttrSet("data.mysql_charsets.test", "charsets.0.default_collation"),
+					resource.TestCheckTypeSetElemAttr("data.mysql_charsets.test", "charsets.0.collations.*", "utf8mb4_bin"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDatabases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowDatabases,
+		Schema: map[string]*schema.Schema{
+			"pattern": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"databases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_character_set": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_collation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowDatabases(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pattern := d.Get("pattern").(string)
+
+	sql := "SELECT SCHEMA_NAME, DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM INFORMATION_SCHEMA.SCHEMATA"
+	args := []interface{}{}
+	if pattern != "" {
+		sql += " WHERE SCHEMA_NAME LIKE ?"
+		args = append(args, pattern)
+	}
+	sql += " ORDER BY SCHEMA_NAME"
+
+	logStatement(ctx, sql)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return diag.Errorf("failed querying for databases: %v", err)
+	}
+	defer rows.Close()
+
+	var databases []map[string]interface{}
+	for rows.Next() {
+		var name, charset, collation string
+
+		if err := rows.Scan(&name, &charset, &collation); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+
+		databases = append(databases, map[string]interface{}{
+			"name":                  name,
+			"default_character_set": charset,
+			"default_collation":     collation,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading databases: %v", err)
+	}
+
+	if err := d.Set("databases", databases); err != nil {
+		return diag.Errorf("failed setting databases field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDatabases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabasesConfig_basic("%"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_databases.test", "pattern", "%"),
+					testAccTablesCount("data.mysql_databases.test", "databases.#", func(rn string, count int) error {
+						if count < 1 {
+							return fmt.Errorf("%s: databases not found", rn)
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccDatabasesConfig_basic("mysql"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_databases.test", "databases.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_databases.test", "databases.0.name", "mysql"),
+					resource.TestCheckResourceAttrSet("data.mysql_databases.test", "databases.0.default_character_set"),
+				),
+			},
+			{
+				Config: testAccDatabasesConfig_basic("__database_does_not_exist__"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_databases.test", "databases.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabasesConfig_basic(pattern string) string {
+	return fmt.Sprintf(`
+data "mysql_databases" "test" {
+		pattern = "%s"
+}`, pattern)
+}
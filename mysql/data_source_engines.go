@@ -0,0 +1,96 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceEngines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowEngines,
+		Schema: map[string]*schema.Schema{
+			"default_engine": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"engines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"transactions": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowEngines(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Engines that are compiled in but disabled have SUPPORT = 'NO'.
+	stmtSQL := "SELECT ENGINE, SUPPORT, TRANSACTIONS, COMMENT FROM INFORMATION_SCHEMA.ENGINES WHERE SUPPORT IN ('YES', 'DEFAULT') ORDER BY ENGINE"
+	logStatement(ctx, stmtSQL)
+
+	rows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		return diag.Errorf("failed querying for engines: %v", err)
+	}
+	defer rows.Close()
+
+	var engines []map[string]interface{}
+	var defaultEngine string
+	for rows.Next() {
+		var name, support, comment string
+		var transactions sql.NullString
+
+		if err := rows.Scan(&name, &support, &transactions, &comment); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+		if support == "DEFAULT" {
+			defaultEngine = name
+		}
+
+		engines = append(engines, map[string]interface{}{
+			"name":         name,
+			"default":      support == "DEFAULT",
+			"transactions": transactions.String == "YES",
+			"comment":      comment,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading engines: %v", err)
+	}
+
+	d.Set("default_engine", defaultEngine)
+	if err := d.Set("engines", engines); err != nil {
+		return diag.Errorf("failed setting engines field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
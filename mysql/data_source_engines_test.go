@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEngines(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "mysql_engines" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_engines.test", "default_engine", "InnoDB"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.mysql_engines.test", "engines.*", map[string]string{
+						"name":         "InnoDB",
+						"default":      "true",
+						"transactions": "true",
+					}),
+				),
+			},
+		},
+	})
+}
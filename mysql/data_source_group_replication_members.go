@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroupReplicationMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowGroupReplicationMembers,
+		Schema: map[string]*schema.Schema{
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"primary": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ShowGroupReplicationMembers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkGroupReplicationSupported(ctx, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	groupMembers, err := showGroupReplicationMembers(ctx, db)
+	if err != nil {
+		return diag.Errorf("failed reading group replication members: %v", err)
+	}
+
+	members := make([]map[string]interface{}, 0, len(groupMembers))
+	primary := ""
+	for _, member := range groupMembers {
+		if member.Role == "PRIMARY" && primary == "" {
+			primary = member.ID
+		}
+		members = append(members, map[string]interface{}{
+			"id":      member.ID,
+			"host":    member.Host,
+			"port":    member.Port,
+			"state":   member.State,
+			"role":    member.Role,
+			"version": member.Version,
+		})
+	}
+
+	if err := d.Set("members", members); err != nil {
+		return diag.Errorf("failed setting members field: %v", err)
+	}
+	d.Set("primary", primary)
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
+
+type groupReplicationMember struct {
+	ID      string
+	Host    string
+	Port    int
+	State   string
+	Role    string
+	Version string
+}
+
+func showGroupReplicationMembers(ctx context.Context, db *sql.DB) ([]groupReplicationMember, error) {
+	// The port is NULL while a member is offline.
+	stmtSQL := "SELECT MEMBER_ID, MEMBER_HOST, IFNULL(MEMBER_PORT, 0), MEMBER_STATE, MEMBER_ROLE, MEMBER_VERSION FROM performance_schema.replication_group_members ORDER BY MEMBER_HOST, MEMBER_PORT"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []groupReplicationMember{}
+	for rows.Next() {
+		var member groupReplicationMember
+		if err := rows.Scan(&member.ID, &member.Host, &member.Port, &member.State, &member.Role, &member.Version); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
@@ -0,0 +1,195 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceInnoDBCluster() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowInnoDBCluster,
+		Schema: map[string]*schema.Schema{
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"primary_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"primary": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"online_members": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"server_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowInnoDBCluster(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkGroupReplicationSupported(ctx, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	clusterID, clusterName, primaryMode, err := showInnoDBClusterMetadata(ctx, db, d.Get("cluster_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT IFNULL(address, ''), mysql_server_uuid, instance_name FROM mysql_innodb_cluster_metadata.instances WHERE cluster_id = ? ORDER BY address"
+	logStatement(ctx, stmtSQL, clusterID)
+	rows, err := db.QueryContext(ctx, stmtSQL, clusterID)
+	if err != nil {
+		return diag.Errorf("failed querying for cluster instances: %v", err)
+	}
+	defer rows.Close()
+
+	var instances []map[string]interface{}
+	for rows.Next() {
+		var address, serverUUID, instanceName string
+		if err := rows.Scan(&address, &serverUUID, &instanceName); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+		instances = append(instances, map[string]interface{}{
+			"address":       address,
+			"server_uuid":   serverUUID,
+			"instance_name": instanceName,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading cluster instances: %v", err)
+	}
+
+	// The metadata and performance_schema differ in collations, so they're
+	// joined here rather than in SQL.
+	groupMembers, err := showGroupReplicationMembers(ctx, db)
+	if err != nil {
+		return diag.Errorf("failed reading group replication members: %v", err)
+	}
+	membersByID := map[string]groupReplicationMember{}
+	for _, member := range groupMembers {
+		membersByID[member.ID] = member
+	}
+
+	primary := ""
+	online := 0
+	for _, instance := range instances {
+		// Instances missing from the group the connected member sees are MISSING.
+		state, role := "MISSING", ""
+		if member, ok := membersByID[instance["server_uuid"].(string)]; ok {
+			state, role = member.State, member.Role
+		}
+		instance["state"] = state
+		instance["role"] = role
+		if state == "ONLINE" {
+			online++
+			if role == "PRIMARY" && primary == "" {
+				primary = instance["address"].(string)
+			}
+		}
+	}
+
+	if err := d.Set("members", instances); err != nil {
+		return diag.Errorf("failed setting members field: %v", err)
+	}
+	d.Set("cluster_name", clusterName)
+	d.Set("cluster_id", clusterID)
+	d.Set("primary_mode", primaryMode)
+	d.Set("primary", primary)
+	d.Set("online_members", online)
+	d.Set("healthy", len(instances) > 0 && online == len(instances))
+
+	d.SetId(clusterID)
+
+	return nil
+}
+
+// showInnoDBClusterMetadata returns the ID, name and primary mode of the
+// cluster with the name. Without a name, the metadata must hold a single
+// cluster, which is the case unless the server is part of a ClusterSet.
+func showInnoDBClusterMetadata(ctx context.Context, db *sql.DB, name string) (string, string, string, error) {
+	stmtSQL := "SELECT cluster_id, cluster_name, primary_mode FROM mysql_innodb_cluster_metadata.clusters"
+	args := []interface{}{}
+	if name != "" {
+		stmtSQL += " WHERE cluster_name = ?"
+		args = append(args, name)
+	}
+	logStatement(ctx, stmtSQL, args...)
+
+	rows, err := db.QueryContext(ctx, stmtSQL, args...)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed querying for InnoDB Cluster metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var clusterID, clusterName, primaryMode string
+	found := 0
+	for rows.Next() {
+		if err := rows.Scan(&clusterID, &clusterName, &primaryMode); err != nil {
+			return "", "", "", fmt.Errorf("failed scanning MySQL rows: %w", err)
+		}
+		found++
+	}
+	if err := rows.Err(); err != nil {
+		return "", "", "", fmt.Errorf("failed reading InnoDB Cluster metadata: %w", err)
+	}
+
+	switch {
+	case found == 0 && name != "":
+		return "", "", "", fmt.Errorf("InnoDB Cluster %q not found", name)
+	case found == 0:
+		return "", "", "", fmt.Errorf("no InnoDB Cluster found in the metadata")
+	case found > 1:
+		return "", "", "", fmt.Errorf("the metadata holds %d InnoDB Clusters; set cluster_name to choose one", found)
+	}
+	return clusterID, clusterName, primaryMode, nil
+}
@@ -0,0 +1,32 @@
+package mysql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceInnoDBCluster_noMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInnoDBClusterConfig_basic,
+				ExpectError: regexp.MustCompile("failed querying for InnoDB Cluster metadata"),
+			},
+		},
+	})
+}
+
+const testAccInnoDBClusterConfig_basic = `
+data "mysql_innodb_cluster" "test" {
+  cluster_name = "tf_test_cluster"
+}
+`
@@ -0,0 +1,229 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	readOnlyQueryRegex = regexp.MustCompile(`(?is)^\s*(SELECT|WITH|SHOW|EXPLAIN|DESCRIBE|DESC|TABLE|VALUES)\b`)
+	// Reading into files writes them even in read-only transactions.
+	queryIntoFileRegex = regexp.MustCompile(`(?is)\bINTO\s+(OUTFILE|DUMPFILE)\b`)
+	// CTEs can be followed by data changes, e.g. WITH ... DELETE.
+	queryDMLRegex = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|REPLACE)\b`)
+)
+
+func dataSourceQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowQuery,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parameters": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func ShowQuery(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	query := d.Get("query").(string)
+	if err := checkReadOnlyQuery(query); err != nil {
+		return diag.Errorf("only read-only statements like SELECT are allowed, got: %s: %v", query, err)
+	}
+	args := d.Get("parameters").([]interface{})
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return diag.Errorf("failed getting connection: %v", err)
+	}
+	defer conn.Close()
+
+	// TiDB only accepts READ ONLY transactions with tidb_enable_noop_functions,
+	// except for stale reads. Bounded staleness reads the newest data available.
+	beginSQL := "START TRANSACTION READ ONLY"
+	if getFlavorFromMeta(ctx, meta) == flavorTiDB {
+		beginSQL = "START TRANSACTION READ ONLY AS OF TIMESTAMP tidb_bounded_staleness(NOW() - INTERVAL 5 SECOND, NOW())"
+	}
+	logStatement(ctx, beginSQL)
+	if _, err := conn.ExecContext(ctx, beginSQL); err != nil {
+		return diag.Errorf("failed starting read-only transaction: %v", err)
+	}
+	defer conn.ExecContext(context.Background(), "ROLLBACK")
+
+	logStatement(ctx, query)
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return diag.Errorf("failed running query: %v", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return diag.Errorf("failed reading columns: %v", err)
+	}
+
+	result := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+
+		// Maps can't hold nulls, so NULL columns are left out.
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if values[i].Valid {
+				row[column] = values[i].String
+			}
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading rows: %v", err)
+	}
+
+	d.Set("columns", columns)
+	if err := d.Set("rows", result); err != nil {
+		return diag.Errorf("failed setting rows field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
+
+// checkReadOnlyQuery accepts single read-only statements. Quoted strings and
+// names and comments are left out of the checks, so they can't hide other
+// statements.
+func checkReadOnlyQuery(query string) error {
+	// Backslashes don't escape with NO_BACKSLASH_ESCAPES, so the query has to
+	// pass either way.
+	for _, backslashEscapes := range []bool{true, false} {
+		if err := checkReadOnlyQueryCode(queryCode(query, backslashEscapes)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkReadOnlyQueryCode(code string) error {
+	if strings.Contains(code, ";") {
+		return fmt.Errorf("only a single statement without ; is allowed")
+	}
+	if !readOnlyQueryRegex.MatchString(code) {
+		return fmt.Errorf("the statement doesn't read")
+	}
+	if queryIntoFileRegex.MatchString(code) {
+		return fmt.Errorf("INTO OUTFILE and INTO DUMPFILE write files")
+	}
+	for _, match := range queryDMLRegex.FindAllStringIndex(code, -1) {
+		// REPLACE() and INSERT() are string functions, and FOR UPDATE locks
+		// the rows read.
+		if strings.HasPrefix(strings.TrimLeft(code[match[1]:], " \t\r\n"), "(") {
+			continue
+		}
+		before := strings.Fields(code[:match[0]])
+		if strings.EqualFold(code[match[0]:match[1]], "UPDATE") && len(before) > 0 && strings.EqualFold(before[len(before)-1], "FOR") {
+			continue
+		}
+		return fmt.Errorf("%s changes data", strings.ToUpper(code[match[0]:match[1]]))
+	}
+	return nil
+}
+
+// queryCode returns the query with quoted strings and names and comments
+// replaced by spaces. Executable comments like /*!80000 ... */ are run by
+// MySQL, so their contents are kept.
+func queryCode(query string, backslashEscapes bool) string {
+	code := []byte(query)
+	blank := func(from int, to int) {
+		for i := from; i < to && i < len(code); i++ {
+			if code[i] != '\n' {
+				code[i] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := i + 1
+			for ; end < len(query); end++ {
+				if query[end] == '\\' && c != '`' && backslashEscapes {
+					end++
+					continue
+				}
+				if query[end] == c {
+					if end+1 < len(query) && query[end+1] == c {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			// Only the contents are blanked, the quotes are kept.
+			blank(i+1, end)
+			i = end
+		case c == '#' || (strings.HasPrefix(query[i:], "--") && (i+2 == len(query) || query[i+2] <= ' ')):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			blank(i, i+end)
+			i += end
+		case c == '/' && strings.HasPrefix(query[i:], "/*!"):
+			// Skip the marker and the version, if any.
+			end := i + 3
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+			blank(i, end)
+			i = end - 1
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query)
+			} else {
+				end += i + 4
+			}
+			blank(i, end)
+			i = end - 1
+		}
+	}
+	return string(code)
+}
@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceQuery(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "mysql_query" "test" {
+  query      = "SELECT ? AS tenant, NULL AS missing, 2 AS shard"
+  parameters = ["acme"]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_query.test", "columns.#", "3"),
+					resource.TestCheckResourceAttr("data.mysql_query.test", "columns.1", "missing"),
+					resource.TestCheckResourceAttr("data.mysql_query.test", "rows.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_query.test", "rows.0.%", "2"),
+					resource.TestCheckResourceAttr("data.mysql_query.test", "rows.0.tenant", "acme"),
+					resource.TestCheckResourceAttr("data.mysql_query.test", "rows.0.shard", "2"),
+				),
+			},
+			{
+				Config: `
+data "mysql_query" "test" {
+  query = "DELETE FROM mysql.user"
+}`,
+				ExpectError: regexp.MustCompile("only read-only statements"),
+			},
+			{
+				Config: `
+data "mysql_query" "test" {
+  query = "SELECT 1; DELETE FROM mysql.user"
+}`,
+				ExpectError: regexp.MustCompile("only a single statement"),
+			},
+		},
+	})
+}
+
+func TestCheckReadOnlyQuery(t *testing.T) {
+	for _, query := range []string{
+		"SELECT 1",
+		"  select * from app.tenants where name = ?",
+		"SELECT 'a;b', \"c;d\", `e;f` FROM t",
+		"SELECT 'it''s; fine'",
+		"SELECT 'back\\slash', 'semi;colon'",
+		"SELECT REPLACE(name, 'a', 'b'), INSERT(name, 1, 2, 'x') FROM t",
+		"SELECT * FROM t FOR UPDATE",
+		"SELECT 'INTO OUTFILE'",
+		"/* leading; comment */ SELECT 1",
+		"SELECT 1 -- trailing; comment",
+		"SELECT 1 # trailing; comment",
+		"WITH cte AS (SELECT 1 AS n) SELECT n FROM cte",
+		"SHOW GRANTS",
+		"EXPLAIN SELECT 1",
+	} {
+		if err := checkReadOnlyQuery(query); err != nil {
+			t.Errorf("checkReadOnlyQuery(%q) failed: %v", query, err)
+		}
+	}
+
+	for _, query := range []string{
+		"DELETE FROM mysql.user",
+		"SELECT 1; DELETE FROM mysql.user",
+		"SELECT 1;",
+		"SELECT 'a'; DROP TABLE t",
+		// Without backslash escapes, the string ends at the backslash.
+		"SELECT '\\'; DELETE FROM t; -- '",
+		"SELECT 1 /*!50000 ; DELETE FROM t */",
+		"SELECT 1 -- comment\n; DELETE FROM t",
+		"WITH cte AS (SELECT 1) DELETE FROM t",
+		"WITH cte AS (SELECT id FROM t) UPDATE t SET a = 1",
+		"WITH cte AS (SELECT 1) INSERT INTO t SELECT * FROM cte",
+		"WITH cte AS (SELECT 1) REPLACE INTO t SELECT * FROM cte",
+		"SELECT * FROM t INTO OUTFILE '/tmp/t'",
+		"SELECT * INTO DUMPFILE '/tmp/t' FROM t",
+	} {
+		if err := checkReadOnlyQuery(query); err == nil {
+			t.Errorf("checkReadOnlyQuery(%q) accepted the query", query)
+		}
+	}
+}
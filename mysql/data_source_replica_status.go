@@ -0,0 +1,107 @@
+package mysql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceReplicaStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowReplicaStatus,
+		Schema: map[string]*schema.Schema{
+			"channel": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"configured": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"source_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"io_running": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sql_running": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"seconds_behind_source": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"retrieved_gtid_set": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"executed_gtid_set": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_io_error": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_sql_error": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fields": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func ShowReplicaStatus(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channel := d.Get("channel").(string)
+	status, err := showReplicaStatus(ctx, db, channel)
+	if err != nil {
+		return diag.Errorf("failed reading replica status: %v", err)
+	}
+
+	d.Set("configured", status != nil)
+	d.Set("source_host", status["Source_Host"])
+	sourcePort, _ := strconv.Atoi(status["Source_Port"])
+	d.Set("source_port", sourcePort)
+	d.Set("io_running", status["Replica_IO_Running"])
+	d.Set("sql_running", status["Replica_SQL_Running"])
+	// The lag is NULL while the threads aren't running.
+	secondsBehind, err := strconv.Atoi(status["Seconds_Behind_Source"])
+	if err != nil {
+		secondsBehind = -1
+	}
+	d.Set("seconds_behind_source", secondsBehind)
+	d.Set("retrieved_gtid_set", status["Retrieved_Gtid_Set"])
+	d.Set("executed_gtid_set", status["Executed_Gtid_Set"])
+	d.Set("last_io_error", status["Last_IO_Error"])
+	d.Set("last_sql_error", status["Last_SQL_Error"])
+	if err := d.Set("fields", status); err != nil {
+		return diag.Errorf("failed setting fields: %v", err)
+	}
+
+	if channel == "" {
+		d.SetId(defaultReplicationChannelId)
+	} else {
+		d.SetId(channel)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceReplicaStatus(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "8.0.23")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccReplicationSourceCheckDestroy("tf_test_status"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccReplicaStatusConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "configured", "true"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "source_host", "source.example.com"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "source_port", "3306"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "io_running", "No"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "sql_running", "No"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "seconds_behind_source", "-1"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.test", "fields.Channel_Name", "tf_test_status"),
+					resource.TestCheckResourceAttr("data.mysql_replica_status.missing", "configured", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccReplicaStatusConfig_basic = `
+resource "mysql_replication_source" "test" {
+  channel  = "tf_test_status"
+  host     = "source.example.com"
+  user     = "replicator"
+  password = "secret"
+  started  = false
+}
+
+data "mysql_replica_status" "test" {
+  channel = mysql_replication_source.test.channel
+}
+
+data "mysql_replica_status" "missing" {
+  channel = "tf_test_missing"
+}
+`
@@ -0,0 +1,163 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowRoles,
+		Schema: map[string]*schema.Schema{
+			"pattern": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"members": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"granted_roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type roleEdge struct {
+	from UserOrRole
+	to   UserOrRole
+}
+
+func ShowRoles(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hasRoles, err := supportsRoles(ctx, meta)
+	if err != nil {
+		return diag.Errorf("failed getting role support: %v", err)
+	}
+	if !hasRoles {
+		return diag.Errorf("roles are not supported by this version of MySQL")
+	}
+
+	// Roles are locked accounts with an expired, empty password - that's how
+	// CREATE ROLE stores them. MariaDB marks them, with an empty host.
+	isMariaDB := getFlavorFromMeta(ctx, meta) == flavorMariaDB
+	sql := "SELECT User, Host FROM mysql.user WHERE account_locked = 'Y' AND password_expired = 'Y' AND authentication_string = ''"
+	if isMariaDB {
+		sql = "SELECT User, Host FROM mysql.user WHERE is_role = 'Y'"
+	}
+	args := []interface{}{}
+	if pattern := d.Get("pattern").(string); pattern != "" {
+		sql += " AND User LIKE ?"
+		args = append(args, pattern)
+	}
+	sql += " ORDER BY User, Host"
+
+	logStatement(ctx, sql)
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return diag.Errorf("failed querying for roles: %v", err)
+	}
+	defer rows.Close()
+
+	var roles []UserOrRole
+	for rows.Next() {
+		var role UserOrRole
+		if err := rows.Scan(&role.Name, &role.Host); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading roles: %v", err)
+	}
+
+	// MariaDB lists the roles granted to each account in roles_mapping
+	// instead of role_edges.
+	sql = "SELECT FROM_USER, FROM_HOST, TO_USER, TO_HOST FROM mysql.role_edges"
+	if isMariaDB {
+		sql = "SELECT Role, '', User, Host FROM mysql.roles_mapping"
+	}
+	logStatement(ctx, sql)
+	edgeRows, err := db.QueryContext(ctx, sql)
+	if err != nil {
+		return diag.Errorf("failed querying for role edges: %v", err)
+	}
+	defer edgeRows.Close()
+
+	var edges []roleEdge
+	for edgeRows.Next() {
+		var edge roleEdge
+		if err := edgeRows.Scan(&edge.from.Name, &edge.from.Host, &edge.to.Name, &edge.to.Host); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+		edges = append(edges, edge)
+	}
+	if err := edgeRows.Err(); err != nil {
+		return diag.Errorf("failed reading role edges: %v", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		members := []string{}
+		grantedRoles := []string{}
+		for _, edge := range edges {
+			if edge.from == role {
+				members = append(members, roleEdgeAccount(edge.to))
+			}
+			if edge.to == role {
+				grantedRoles = append(grantedRoles, roleEdgeAccount(edge.from))
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"name":          role.Name,
+			"host":          role.Host,
+			"members":       members,
+			"granted_roles": grantedRoles,
+		})
+	}
+
+	if err := d.Set("roles", result); err != nil {
+		return diag.Errorf("failed setting roles field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
+
+// roleEdgeAccount formats accounts as name@host, or as name for the roles of
+// MariaDB, which have no host.
+func roleEdgeAccount(account UserOrRole) string {
+	if account.Host == "" {
+		return account.Name
+	}
+	return fmt.Sprintf("%s@%s", account.Name, account.Host)
+}
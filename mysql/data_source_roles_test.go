@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRolesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.name", "tf-test-roles-a"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.members.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.members.0", "tf-test-roles-b@%"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.1.granted_roles.0", "tf-test-roles-a@%"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRoles_mariaDB(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSkipNotMariaDB(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRolesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.name", "tf-test-roles-a"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.host", ""),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.members.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.0.members.0", "tf-test-roles-b"),
+					resource.TestCheckResourceAttr("data.mysql_roles.test", "roles.1.granted_roles.0", "tf-test-roles-a"),
+				),
+			},
+		},
+	})
+}
+
+func TestRoleEdgeAccount(t *testing.T) {
+	if actual := roleEdgeAccount(UserOrRole{Name: "reader", Host: "%"}); actual != "reader@%" {
+		t.Errorf("expected reader@%%, got %s", actual)
+	}
+	if actual := roleEdgeAccount(UserOrRole{Name: "reader"}); actual != "reader" {
+		t.Errorf("expected reader, got %s", actual)
+	}
+}
+
+const testAccRolesConfig_basic = `
+resource "mysql_role" "a" {
+  name = "tf-test-roles-a"
+}
+
+resource "mysql_role" "b" {
+  name = "tf-test-roles-b"
+}
+
+resource "mysql_grant" "b" {
+  role     = mysql_role.b.name
+  database = "*"
+  roles    = [mysql_role.a.name]
+}
+
+data "mysql_roles" "test" {
+  pattern = "tf-test-roles-%"
+
+  depends_on = [mysql_grant.b]
+}
+`
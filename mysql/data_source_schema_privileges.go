@@ -0,0 +1,178 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// INFORMATION_SCHEMA quotes grantees like 'user'@'host'.
+var informationSchemaGranteeRegex = regexp.MustCompile(`^'(.*)'@'(.*)'$`)
+
+func dataSourceSchemaPrivileges() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowSchemaPrivileges,
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"grantees": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"privileges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"level": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"database": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"table": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"column": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"privilege": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"grantable": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowSchemaPrivileges(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	user := d.Get("user").(string)
+	host := d.Get("host").(string)
+
+	sources := []struct {
+		level   string
+		table   string
+		columns string
+	}{
+		{"database", "SCHEMA_PRIVILEGES", "'', ''"},
+		{"table", "TABLE_PRIVILEGES", "TABLE_NAME, ''"},
+		{"column", "COLUMN_PRIVILEGES", "TABLE_NAME, COLUMN_NAME"},
+	}
+
+	grantees := map[UserOrRole][]map[string]interface{}{}
+	for _, source := range sources {
+		stmtSQL := "SELECT GRANTEE, TABLE_SCHEMA, " + source.columns + ", PRIVILEGE_TYPE, IS_GRANTABLE FROM INFORMATION_SCHEMA." + source.table
+		args := []interface{}{}
+		if database != "" {
+			stmtSQL += " WHERE TABLE_SCHEMA = ?"
+			args = append(args, database)
+		}
+		stmtSQL += " ORDER BY GRANTEE, TABLE_SCHEMA, PRIVILEGE_TYPE"
+		logStatement(ctx, stmtSQL)
+
+		rows, err := db.QueryContext(ctx, stmtSQL, args...)
+		if err != nil {
+			return diag.Errorf("failed querying %s: %v", source.table, err)
+		}
+
+		for rows.Next() {
+			var grantee, schemaName, tableName, columnName, privilege, grantable string
+			if err := rows.Scan(&grantee, &schemaName, &tableName, &columnName, &privilege, &grantable); err != nil {
+				rows.Close()
+				return diag.Errorf("failed scanning MySQL rows: %v", err)
+			}
+
+			account := UserOrRole{Name: grantee}
+			if m := informationSchemaGranteeRegex.FindStringSubmatch(grantee); m != nil {
+				account = UserOrRole{Name: m[1], Host: m[2]}
+			}
+			if (user != "" && account.Name != user) || (host != "" && account.Host != host) {
+				continue
+			}
+
+			grantees[account] = append(grantees[account], map[string]interface{}{
+				"level":     source.level,
+				"database":  schemaName,
+				"table":     tableName,
+				"column":    columnName,
+				"privilege": privilege,
+				"grantable": grantable == "YES",
+			})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return diag.Errorf("failed reading %s: %v", source.table, err)
+		}
+	}
+
+	accounts := make([]UserOrRole, 0, len(grantees))
+	for account := range grantees {
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Name != accounts[j].Name {
+			return accounts[i].Name < accounts[j].Name
+		}
+		return accounts[i].Host < accounts[j].Host
+	})
+
+	result := make([]map[string]interface{}, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, map[string]interface{}{
+			"user":       account.Name,
+			"host":       account.Host,
+			"privileges": grantees[account],
+		})
+	}
+
+	if err := d.Set("grantees", result); err != nil {
+		return diag.Errorf("failed setting grantees field: %v", err)
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return nil
+}
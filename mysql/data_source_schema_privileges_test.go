@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSchemaPrivileges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSkipTiDB(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSchemaPrivilegesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.user", "tf-test-schema-privs"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.host", "example.com"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.privileges.0.level", "database"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.privileges.0.database", "tf_test_schema_privs"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.privileges.0.privilege", "SELECT"),
+					resource.TestCheckResourceAttr("data.mysql_schema_privileges.test", "grantees.0.privileges.0.grantable", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSchemaPrivilegesConfig_basic = `
+resource "mysql_database" "test" {
+  name = "tf_test_schema_privs"
+}
+
+resource "mysql_user" "test" {
+  user = "tf-test-schema-privs"
+  host = "example.com"
+}
+
+resource "mysql_grant" "test" {
+  user       = mysql_user.test.user
+  host       = mysql_user.test.host
+  database   = mysql_database.test.name
+  privileges = ["SELECT"]
+}
+
+data "mysql_schema_privileges" "test" {
+  database = mysql_database.test.name
+  user     = mysql_user.test.user
+
+  depends_on = [mysql_grant.test]
+}
+`
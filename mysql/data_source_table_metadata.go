@@ -0,0 +1,236 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTableMetadata() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: ShowTableMetadata,
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"engine": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_collation": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nullable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_expression": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auto_increment": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"primary_key": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"indexes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"columns": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"unique": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"foreign_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"columns": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"referenced_database": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"referenced_table": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"referenced_columns": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"on_update": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"on_delete": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ShowTableMetadata(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	name := d.Get("name").(string)
+
+	var engine, collation sql.NullString
+	var comment string
+	stmtSQL := "SELECT ENGINE, TABLE_COLLATION, TABLE_COMMENT FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?"
+	logStatement(ctx, stmtSQL)
+	err = db.QueryRowContext(ctx, stmtSQL, database, name).Scan(&engine, &collation, &comment)
+	if err == sql.ErrNoRows {
+		return diag.Errorf("table %s.%s not found", database, name)
+	}
+	if err != nil {
+		return diag.Errorf("failed reading table: %v", err)
+	}
+
+	columns, err := readTableColumns(ctx, db, meta, database, name)
+	if err != nil {
+		return diag.Errorf("failed reading table columns: %v", err)
+	}
+
+	primaryKey, indexes, err := readTableIndexes(ctx, db, database, name)
+	if err != nil {
+		return diag.Errorf("failed reading table indexes: %v", err)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i]["name"].(string) < indexes[j]["name"].(string)
+	})
+
+	foreignKeys, err := readTableForeignKeys(ctx, db, database, name)
+	if err != nil {
+		return diag.Errorf("failed reading table foreign keys: %v", err)
+	}
+
+	d.Set("engine", engine.String)
+	d.Set("default_collation", collation.String)
+	d.Set("comment", comment)
+	if err := d.Set("columns", columns); err != nil {
+		return diag.Errorf("failed setting columns field: %v", err)
+	}
+	d.Set("primary_key", primaryKey)
+	if err := d.Set("indexes", indexes); err != nil {
+		return diag.Errorf("failed setting indexes field: %v", err)
+	}
+	if err := d.Set("foreign_keys", foreignKeys); err != nil {
+		return diag.Errorf("failed setting foreign_keys field: %v", err)
+	}
+
+	d.SetId(database + "." + name)
+
+	return nil
+}
+
+func readTableForeignKeys(ctx context.Context, db *sql.DB, database string, table string) ([]map[string]interface{}, error) {
+	stmtSQL := `SELECT k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_SCHEMA, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME, r.UPDATE_RULE, r.DELETE_RULE
+		FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE k
+		JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS r ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND r.TABLE_NAME = k.TABLE_NAME
+		WHERE k.TABLE_SCHEMA = ? AND k.TABLE_NAME = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL, database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foreignKeys := []map[string]interface{}{}
+	var current map[string]interface{}
+	for rows.Next() {
+		var name, column, referencedDatabase, referencedTable, referencedColumn, onUpdate, onDelete string
+		if err := rows.Scan(&name, &column, &referencedDatabase, &referencedTable, &referencedColumn, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+
+		// The rows of a key are next to each other.
+		if current == nil || current["name"] != name {
+			current = map[string]interface{}{
+				"name":                name,
+				"columns":             []interface{}{},
+				"referenced_database": referencedDatabase,
+				"referenced_table":    referencedTable,
+				"referenced_columns":  []interface{}{},
+				"on_update":           onUpdate,
+				"on_delete":           onDelete,
+			}
+			foreignKeys = append(foreignKeys, current)
+		}
+		current["columns"] = append(current["columns"].([]interface{}), column)
+		current["referenced_columns"] = append(current["referenced_columns"].([]interface{}), referencedColumn)
+	}
+	return foreignKeys, rows.Err()
+}
@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTableMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSkipTiDB(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTableMetadataConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "engine", "InnoDB"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "columns.#", "3"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "columns.0.name", "id"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "columns.0.auto_increment", "true"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "columns.2.name", "email"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "columns.2.nullable", "true"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "primary_key.0", "id"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "indexes.#", "2"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "indexes.0.name", "email"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "indexes.0.unique", "true"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "foreign_keys.#", "1"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "foreign_keys.0.name", "fk_tenant"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "foreign_keys.0.referenced_table", "tenants"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "foreign_keys.0.referenced_columns.0", "id"),
+					resource.TestCheckResourceAttr("data.mysql_table_metadata.test", "foreign_keys.0.on_delete", "CASCADE"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTableMetadataConfig_basic = `
+resource "mysql_database" "test" {
+  name = "tf_test_table_metadata"
+}
+
+resource "mysql_sql" "tenants" {
+  name       = "tenants"
+  create_sql = "CREATE TABLE ${mysql_database.test.name}.tenants (id INT PRIMARY KEY)"
+  delete_sql = "DROP TABLE ${mysql_database.test.name}.tenants"
+}
+
+resource "mysql_sql" "users" {
+  name       = "users"
+  create_sql = "CREATE TABLE ${mysql_database.test.name}.users (id INT AUTO_INCREMENT PRIMARY KEY, tenant_id INT NOT NULL, email VARCHAR(255) UNIQUE, CONSTRAINT fk_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON DELETE CASCADE) ENGINE=InnoDB"
+  delete_sql = "DROP TABLE ${mysql_database.test.name}.users"
+
+  depends_on = [mysql_sql.tenants]
+}
+
+data "mysql_table_metadata" "test" {
+  database = mysql_database.test.name
+  name     = "users"
+
+  depends_on = [mysql_sql.users]
+}
+`
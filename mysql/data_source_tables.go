@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,7 +31,7 @@ func dataSourceTables() *schema.Resource {
 }
 
 func ShowTables(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getReadDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -43,10 +42,10 @@ func ShowTables(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	sql := fmt.Sprintf("SHOW TABLES FROM %s", quoteIdentifier(database))
 
 	if pattern != "" {
-		sql += fmt.Sprintf(" LIKE '%s'", pattern)
+		sql += " LIKE " + quoteLiteral(pattern)
 	}
 
-	log.Printf("[DEBUG] SQL: %s", sql)
+	logStatement(ctx, sql)
 
 	rows, err := db.QueryContext(ctx, sql)
 	if err != nil {
@@ -0,0 +1,478 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type dryRunKey struct{}
+
+// dryRunRecorder collects the statements that would have been run, or with
+// execute, the ones that were run.
+type dryRunRecorder struct {
+	mtx        sync.Mutex
+	execute    bool
+	statements []string
+}
+
+func (r *dryRunRecorder) add(stmt string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.statements = append(r.statements, stmt)
+}
+
+// recordStatement records a statement that changes the server without
+// looking like it, e.g. SELECT of a UDF, since only other statements are
+// recorded by the connection. It tells whether the statement has to be skipped
+// because of dry run.
+func recordStatement(ctx context.Context, stmtSQL string, args ...interface{}) bool {
+	recorder, ok := ctx.Value(dryRunKey{}).(*dryRunRecorder)
+	if !ok {
+		return false
+	}
+	namedArgs := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		namedArgs = append(namedArgs, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+	recorder.add(redactSQL(interpolateForDisplay(stmtSQL, namedArgs)))
+	return !recorder.execute
+}
+
+// changingRecorder returns the recorder of the context if the query isn't
+// read-only, so that it's recorded like the statements run through ExecContext.
+func changingRecorder(ctx context.Context, query string) (*dryRunRecorder, bool) {
+	recorder, ok := ctx.Value(dryRunKey{}).(*dryRunRecorder)
+	if !ok || checkReadOnlyQuery(query) == nil {
+		return nil, false
+	}
+	return recorder, true
+}
+
+// inDryRun tells whether the context comes from a resource in dry run, e.g.
+// while planning its statements, so nothing it does may have side effects.
+func inDryRun(ctx context.Context) bool {
+	recorder, ok := ctx.Value(dryRunKey{}).(*dryRunRecorder)
+	return ok && !recorder.execute
+}
+
+// setDryRun makes Create, Update and Delete of the resource only record the
+// statements they would run when the provider has dry_run set. They then fail
+// with the recorded statements, so that the state is left as it was.
+func setDryRun(resource *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, create bool) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if meta.(*MySQLConfiguration).ShowStatements && !meta.(*MySQLConfiguration).DryRun {
+				return showStatements(ctx, d, meta, f)
+			}
+			if !meta.(*MySQLConfiguration).DryRun {
+				return f(ctx, d, meta)
+			}
+
+			recorder := &dryRunRecorder{}
+			diags := f(context.WithValue(ctx, dryRunKey{}, recorder), d, meta)
+			if create {
+				d.SetId("")
+			} else {
+				d.Partial(true)
+			}
+
+			detail := "No statements would have been run."
+			if len(recorder.statements) > 0 {
+				detail = "The following statements would have been run:\n\n" + strings.Join(recorder.statements, ";\n") + ";"
+			}
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "dry_run is set, so no changes were made",
+				Detail:   detail,
+			})
+		}
+	}
+
+	resource.CreateContext = wrap(resource.CreateContext, true)
+	resource.UpdateContext = wrap(resource.UpdateContext, false)
+	resource.DeleteContext = wrap(resource.DeleteContext, false)
+}
+
+// setPlannedStatements makes plans of the resource show the statements that
+// applying them would run in planned_statements, when the provider has
+// show_statements or dry_run set. The statements are recorded by applying the
+// planned change in dry run, so they are built by the same code as the ones
+// run later. Statements of replacements include the ones deleting the
+// resource; plans only deleting resources don't reach the provider.
+func setPlannedStatements(resource *schema.Resource) {
+	plain := *resource
+	resource.Schema["planned_statements"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+		if conf, ok := meta.(*MySQLConfiguration); !ok || !(conf.DryRun || conf.ShowStatements) {
+			// Otherwise, new resources would plan it as known after apply.
+			return d.Clear("planned_statements")
+		}
+
+		statements, changed, err := planStatements(ctx, &plain, d, meta)
+		if err != nil {
+			log.Printf("[WARN] Recording the planned statements of %s failed: %v", d.Id(), err)
+			return d.SetNewComputed("planned_statements")
+		}
+		if !changed {
+			return nil
+		}
+		return d.SetNew("planned_statements", statements)
+	}
+}
+
+// planStatements applies the planned change of the resource in dry run and
+// returns the statements it would have run, and whether there's a change.
+func planStatements(ctx context.Context, r *schema.Resource, d *schema.ResourceDiff, meta interface{}) ([]interface{}, bool, error) {
+	if !d.GetRawConfig().IsWhollyKnown() || d.GetRawPlan().IsNull() {
+		return nil, false, fmt.Errorf("the configuration isn't known yet")
+	}
+
+	state, err := r.ShimInstanceStateFromValue(d.GetRawState())
+	if err != nil {
+		return nil, false, err
+	}
+	diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigShimmed(d.GetRawPlan(), r.CoreConfigSchema()), meta)
+	if err != nil {
+		return nil, false, err
+	}
+	if diff.Empty() {
+		return nil, false, nil
+	}
+
+	recorder := &dryRunRecorder{}
+	if _, diags := r.Apply(context.WithValue(ctx, dryRunKey{}, recorder), state, diff, meta); diags.HasError() {
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				return nil, false, fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+			}
+		}
+	}
+
+	statements := make([]interface{}, 0, len(recorder.statements))
+	for _, stmt := range recorder.statements {
+		statements = append(statements, stmt)
+	}
+	return statements, true, nil
+}
+
+// showStatements runs the operation, adding a warning with the statements it
+// ran, so they show up in the output of terraform apply.
+func showStatements(ctx context.Context, d *schema.ResourceData, meta interface{}, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) diag.Diagnostics {
+	recorder := &dryRunRecorder{execute: true}
+	diags := f(context.WithValue(ctx, dryRunKey{}, recorder), d, meta)
+	if len(recorder.statements) == 0 {
+		return diags
+	}
+
+	summary := "Ran statements"
+	if diags.HasError() {
+		summary = "Ran statements before failing"
+	}
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail:   "The following statements were run:\n\n" + strings.Join(recorder.statements, ";\n") + ";",
+	})
+}
+
+// dryRunConn records the statements run through ExecContext instead of
+// running them, if the context comes from a resource in dry run. So are the
+// queries and prepared statements that aren't read-only; they return no rows.
+// Read-only queries are still run, so resources can read what they need to
+// build the statements. Resources showing their statements have them recorded
+// and run.
+type dryRunConn struct {
+	driver.Conn
+}
+
+func (c *dryRunConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	recorder, record := ctx.Value(dryRunKey{}).(*dryRunRecorder)
+	if record && !recorder.execute {
+		recorder.add(redactSQL(interpolateForDisplay(query, args)))
+		return driver.RowsAffected(0), nil
+	}
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	result, err := execer.ExecContext(ctx, query, args)
+	// Skipped statements are prepared instead, which records them.
+	if record && err != driver.ErrSkip {
+		recorder.add(redactSQL(interpolateForDisplay(query, args)))
+	}
+	return result, err
+}
+
+func (c *dryRunConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, locks := dryRunLocks(ctx, query)
+	if locks {
+		return lockRows(), nil
+	}
+	recorder, record := changingRecorder(ctx, query)
+	if record && !recorder.execute {
+		recorder.add(redactSQL(interpolateForDisplay(query, args)))
+		return &dryRunRows{}, nil
+	}
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if record && err != driver.ErrSkip {
+		recorder.add(redactSQL(interpolateForDisplay(query, args)))
+	}
+	return rows, err
+}
+
+func (c *dryRunConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query, locks := dryRunLocks(ctx, query)
+	if locks {
+		return &dryRunStmt{query: query, locks: true}, nil
+	}
+	recorder, record := changingRecorder(ctx, query)
+	if record && !recorder.execute {
+		return &dryRunStmt{query: query, recorder: recorder}, nil
+	}
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil || !record {
+		return stmt, err
+	}
+	return &dryRunStmt{Stmt: stmt, query: query, recorder: recorder}, nil
+}
+
+func (c *dryRunConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *dryRunConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *dryRunConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *dryRunConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *dryRunConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// dryRunStmt records the statement each time it's run. Without a prepared
+// statement to run, it's only recorded. Stubbed lock functions are neither
+// run nor recorded.
+type dryRunStmt struct {
+	driver.Stmt
+	query    string
+	recorder *dryRunRecorder
+	locks    bool
+}
+
+func (s *dryRunStmt) Close() error {
+	if s.Stmt == nil {
+		return nil
+	}
+	return s.Stmt.Close()
+}
+
+func (s *dryRunStmt) NumInput() int {
+	if s.Stmt == nil {
+		return -1
+	}
+	return s.Stmt.NumInput()
+}
+
+func (s *dryRunStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *dryRunStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *dryRunStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if s.locks {
+		return driver.RowsAffected(0), nil
+	}
+	s.recorder.add(redactSQL(interpolateForDisplay(s.query, args)))
+	if s.Stmt == nil {
+		return driver.RowsAffected(0), nil
+	}
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	return s.Stmt.Exec(driverValues(args))
+}
+
+func (s *dryRunStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if s.locks {
+		return lockRows(), nil
+	}
+	s.recorder.add(redactSQL(interpolateForDisplay(s.query, args)))
+	if s.Stmt == nil {
+		return &dryRunRows{}, nil
+	}
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	return s.Stmt.Query(driverValues(args))
+}
+
+// dryRunRows are the rows of queries skipped in dry run, which have none
+// unless they stub a lock function.
+type dryRunRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *dryRunRows) Columns() []string { return r.columns }
+func (r *dryRunRows) Close() error      { return nil }
+func (r *dryRunRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var (
+	// Advisory locks are taken and released by SELECTs of these functions.
+	lockFunctionRegex = regexp.MustCompile(`(?i)\b(GET_LOCK|RELEASE_LOCK|RELEASE_ALL_LOCKS)\s*\(`)
+	lockingReadRegex  = regexp.MustCompile(`(?i)\bFOR\s+(UPDATE|SHARE)\b(\s+OF\s+[^\s,]+(\s*,\s*[^\s,]+)*)?(\s+(NOWAIT|SKIP\s+LOCKED))?|\bLOCK\s+IN\s+SHARE\s+MODE\b`)
+)
+
+// dryRunLocks keeps resources in dry run from locking anything: it tells
+// whether the query calls a lock function, which is stubbed as if it
+// succeeded, and otherwise returns the query without its locking clauses,
+// e.g. FOR UPDATE, so that it still reads. Locks aren't changes, so they
+// aren't recorded. Resources showing their statements lock as they run.
+func dryRunLocks(ctx context.Context, query string) (string, bool) {
+	recorder, ok := ctx.Value(dryRunKey{}).(*dryRunRecorder)
+	if !ok || recorder.execute {
+		return query, false
+	}
+	code := queryCode(query, true)
+	if lockFunctionRegex.MatchString(code) {
+		return query, true
+	}
+	matches := lockingReadRegex.FindAllStringIndex(code, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		query = query[:matches[i][0]] + query[matches[i][1]:]
+	}
+	return query, false
+}
+
+// lockRows are the rows of stubbed lock functions, which succeed with 1.
+func lockRows() driver.Rows {
+	return &dryRunRows{columns: []string{"lock"}, rows: [][]driver.Value{{int64(1)}}}
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		named = append(named, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+	return named
+}
+
+func driverValues(args []driver.NamedValue) []driver.Value {
+	plain := make([]driver.Value, 0, len(args))
+	for _, arg := range args {
+		plain = append(plain, arg.Value)
+	}
+	return plain
+}
+
+// interpolateForDisplay replaces the placeholders of the query with its
+// arguments, so the statement can be shown as it would be run.
+func interpolateForDisplay(query string, args []driver.NamedValue) string {
+	if len(args) == 0 {
+		return query
+	}
+
+	var b strings.Builder
+	var quote rune
+	escaped := false
+	next := 0
+	for _, r := range query {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if r == '\\' && quote != '`' {
+				escaped = true
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '?' && next < len(args):
+			b.WriteString(argSQLString(args[next].Value))
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func argSQLString(value driver.Value) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return quoteLiteral(v)
+	case []byte:
+		return quoteLiteral(string(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,361 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDryRunConn(t *testing.T) {
+	fake := &fakeExecConn{}
+	conn := &dryRunConn{Conn: fake}
+	recorder := &dryRunRecorder{}
+	ctx := context.WithValue(context.Background(), dryRunKey{}, recorder)
+
+	args := []driver.NamedValue{{Ordinal: 1, Value: "jdoe"}, {Ordinal: 2, Value: "%"}, {Ordinal: 3, Value: "it's secret"}}
+	if _, err := conn.ExecContext(ctx, "ALTER USER ?@? IDENTIFIED BY ?", args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "GRANT SELECT ON `db?`.* TO 'jdoe'@'%'", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.execs != 0 {
+		t.Errorf("expected no executions in dry run, got %d", fake.execs)
+	}
+	expected := []string{
+		"ALTER USER 'jdoe'@'%' IDENTIFIED BY '<redacted>'",
+		"GRANT SELECT ON `db?`.* TO 'jdoe'@'%'",
+	}
+	if len(recorder.statements) != len(expected) {
+		t.Fatalf("expected statements %q, got %q", expected, recorder.statements)
+	}
+	for i := range expected {
+		if recorder.statements[i] != expected[i] {
+			t.Errorf("expected statement %q, got %q", expected[i], recorder.statements[i])
+		}
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "DROP USER 'jdoe'@'%'", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.execs != 1 {
+		t.Errorf("expected the statement to run outside dry run, got %d executions", fake.execs)
+	}
+}
+
+// fakeQueryConn counts the queries and prepared statements run on it.
+type fakeQueryConn struct {
+	fakeExecConn
+	queries   int
+	prepares  int
+	lastQuery string
+}
+
+func (c *fakeQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.queries++
+	c.lastQuery = query
+	return &dryRunRows{}, nil
+}
+
+// fakeConnector connects to the same connection every time.
+type fakeConnector struct {
+	conn driver.Conn
+}
+
+func (c *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (c *fakeQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	c.prepares++
+	return &fakeStmt{conn: &c.fakeExecConn}, nil
+}
+
+type fakeStmt struct {
+	conn *fakeExecConn
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), "", nil)
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &dryRunRows{}, nil
+}
+
+func TestDryRunConnQueries(t *testing.T) {
+	fake := &fakeQueryConn{}
+	conn := &dryRunConn{Conn: fake}
+	recorder := &dryRunRecorder{}
+	ctx := context.WithValue(context.Background(), dryRunKey{}, recorder)
+
+	if _, err := conn.QueryContext(ctx, "SELECT * FROM mysql.user WHERE User = ?", []driver.NamedValue{{Ordinal: 1, Value: "jdoe"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.queries != 1 {
+		t.Errorf("expected read-only queries to run in dry run, got %d queries", fake.queries)
+	}
+
+	rows, err := conn.QueryContext(ctx, "CALL mysql.rds_set_configuration(?, ?)", []driver.NamedValue{{Ordinal: 1, Value: "binlog retention hours"}, {Ordinal: 2, Value: int64(24)}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rows.Next(nil); err != io.EOF {
+		t.Errorf("expected no rows of a skipped query, got %v", err)
+	}
+	stmt, err := conn.PrepareContext(ctx, "DELETE FROM `db`.`t` WHERE id = ?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := stmt.(driver.StmtExecContext).ExecContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: int64(7)}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.queries != 1 || fake.prepares != 0 || fake.execs != 0 {
+		t.Errorf("expected no changes in dry run, got %d queries, %d prepares and %d executions", fake.queries, fake.prepares, fake.execs)
+	}
+
+	expected := []string{
+		"CALL mysql.rds_set_configuration('binlog retention hours', 24)",
+		"DELETE FROM `db`.`t` WHERE id = 7",
+	}
+	if len(recorder.statements) != len(expected) {
+		t.Fatalf("expected statements %q, got %q", expected, recorder.statements)
+	}
+	for i := range expected {
+		if recorder.statements[i] != expected[i] {
+			t.Errorf("expected statement %q, got %q", expected[i], recorder.statements[i])
+		}
+	}
+
+	shown := &dryRunRecorder{execute: true}
+	ctx = context.WithValue(context.Background(), dryRunKey{}, shown)
+	if _, err := conn.QueryContext(ctx, "CALL mysql.rds_set_configuration('target delay', 0)", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmt, err = conn.PrepareContext(ctx, "DELETE FROM `db`.`t`")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := stmt.(driver.StmtExecContext).ExecContext(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.queries != 2 || fake.prepares != 1 || fake.execs != 1 {
+		t.Errorf("expected shown statements to run, got %d queries, %d prepares and %d executions", fake.queries, fake.prepares, fake.execs)
+	}
+	if len(shown.statements) != 2 {
+		t.Errorf("expected the shown statements to be recorded once, got %q", shown.statements)
+	}
+}
+
+func TestDryRunLocks(t *testing.T) {
+	fake := &fakeQueryConn{}
+	db := sql.OpenDB(&fakeConnector{conn: &dryRunConn{Conn: fake}})
+	defer db.Close()
+	recorder := &dryRunRecorder{}
+	ctx := context.WithValue(context.Background(), dryRunKey{}, recorder)
+	meta := &MySQLConfiguration{GrantLockTimeout: time.Second}
+
+	unlock, err := lockGrantee(ctx, db, meta, UserOrRole{Name: "jdoe", Host: "%"})
+	if err != nil {
+		t.Fatalf("expected the lock to be stubbed, got %v", err)
+	}
+	unlock()
+	if fake.queries != 0 || len(recorder.statements) != 0 {
+		t.Errorf("expected no locks in dry run, got %d queries and statements %q", fake.queries, recorder.statements)
+	}
+
+	for query, expected := range map[string]string{
+		"SELECT id FROM `db`.`t` WHERE id = 1 FOR UPDATE":                  "SELECT id FROM `db`.`t` WHERE id = 1",
+		"SELECT id FROM t FOR SHARE OF t NOWAIT":                           "SELECT id FROM t",
+		"SELECT id FROM t LOCK IN SHARE MODE":                              "SELECT id FROM t",
+		"SELECT 'FOR UPDATE' FROM t WHERE name = 'GET_LOCK(1)' FOR UPDATE": "SELECT 'FOR UPDATE' FROM t WHERE name = 'GET_LOCK(1)'",
+	} {
+		if _, err := (&dryRunConn{Conn: fake}).QueryContext(ctx, query, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.TrimSpace(fake.lastQuery) != expected {
+			t.Errorf("expected %q to run as %q, got %q", query, expected, fake.lastQuery)
+		}
+	}
+
+	shown := context.WithValue(context.Background(), dryRunKey{}, &dryRunRecorder{execute: true})
+	if _, err := (&dryRunConn{Conn: fake}).QueryContext(shown, "SELECT GET_LOCK('x', 1)", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.lastQuery != "SELECT GET_LOCK('x', 1)" {
+		t.Errorf("expected resources showing their statements to lock, got %q", fake.lastQuery)
+	}
+}
+
+func TestRecordStatement(t *testing.T) {
+	stmtSQL := "SELECT audit_log_filter_set_filter(?, ?)"
+
+	if recordStatement(context.Background(), stmtSQL, "log_all", `{"filter": {"log": true}}`) {
+		t.Errorf("expected statements to run outside dry run")
+	}
+
+	recorder := &dryRunRecorder{}
+	if !recordStatement(context.WithValue(context.Background(), dryRunKey{}, recorder), stmtSQL, "log_all", `{"filter": {"log": true}}`) {
+		t.Errorf("expected statements to be skipped in dry run")
+	}
+	expected := `SELECT audit_log_filter_set_filter('log_all', '{"filter": {"log": true}}')`
+	if len(recorder.statements) != 1 || recorder.statements[0] != expected {
+		t.Errorf("expected statements %q, got %q", []string{expected}, recorder.statements)
+	}
+
+	shown := &dryRunRecorder{execute: true}
+	if recordStatement(context.WithValue(context.Background(), dryRunKey{}, shown), stmtSQL, "log_all", "{}") {
+		t.Errorf("expected shown statements to run")
+	}
+	if len(shown.statements) != 1 {
+		t.Errorf("expected the shown statement to be recorded, got %q", shown.statements)
+	}
+}
+
+func TestPlannedStatements(t *testing.T) {
+	fake := &fakeExecConn{}
+	conn := &dryRunConn{Conn: fake}
+	resource := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			defer lockPrivilegeChanges(ctx, meta)()
+			defer invalidateGrants(ctx, meta, UserOrRole{Name: d.Get("name").(string)})
+			if _, err := conn.ExecContext(ctx, "CREATE ROLE "+quoteIdentifier(d.Get("name").(string)), nil); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(d.Get("name").(string))
+			return nil
+		},
+		ReadContext:   schema.NoopContext,
+		DeleteContext: schema.NoopContext,
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true, ForceNew: true},
+		},
+	}
+	setPlannedStatements(resource)
+
+	plan := func(meta interface{}) *terraform.InstanceDiff {
+		stateType := resource.CoreConfigSchema().ImpliedType()
+		planned := cty.ObjectVal(map[string]cty.Value{
+			"id":                 cty.NullVal(cty.String),
+			"name":               cty.StringVal("dev"),
+			"planned_statements": cty.NullVal(cty.List(cty.String)),
+		})
+		state, err := resource.ShimInstanceStateFromValue(cty.NullVal(stateType))
+		if err != nil {
+			t.Fatalf("making state failed: %v", err)
+		}
+		state.RawState = cty.NullVal(stateType)
+		state.RawPlan = planned
+		state.RawConfig = planned
+
+		diff, err := resource.SimpleDiff(context.Background(), state, terraform.NewResourceConfigShimmed(planned, resource.CoreConfigSchema()), meta)
+		if err != nil {
+			t.Fatalf("planning failed: %v", err)
+		}
+		return diff
+	}
+
+	// Planning neither waits for privilege changes nor drops cached grants.
+	db, role := &sql.DB{}, UserOrRole{Name: "dev"}
+	cache := newGrantsCache()
+	_, generation, _ := cache.get(db, role)
+	cache.set(db, role, generation, []string{"GRANT USAGE ON *.* TO `dev`@`%`"})
+	privilegeChangeMutex.Lock()
+	planned := make(chan *terraform.InstanceDiff)
+	go func() {
+		planned <- plan(&MySQLConfiguration{ShowStatements: true, SerializePrivilegeChanges: true, GrantsCache: cache})
+	}()
+	var diff *terraform.InstanceDiff
+	select {
+	case diff = <-planned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected planning not to wait for privilege changes")
+	}
+	privilegeChangeMutex.Unlock()
+	if _, _, ok := cache.get(db, role); !ok {
+		t.Error("expected planning to keep the cached grants")
+	}
+	if fake.execs != 0 {
+		t.Errorf("expected no executions while planning, got %d", fake.execs)
+	}
+	if attr := diff.Attributes["planned_statements.0"]; attr == nil || attr.New != "CREATE ROLE `dev`" {
+		t.Errorf("expected the CREATE ROLE statement to be planned, got %v", diff.Attributes)
+	}
+
+	diff = plan(&MySQLConfiguration{})
+	for name := range diff.Attributes {
+		if strings.HasPrefix(name, "planned_statements") {
+			t.Errorf("expected no planned statements without show_statements, got %s", name)
+		}
+	}
+}
+
+func TestInterpolateForDisplay(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{"SELECT 1", nil, "SELECT 1"},
+		{"SET DEFAULT ROLE NONE TO ?@?", []interface{}{"jdoe", "%"}, "SET DEFAULT ROLE NONE TO 'jdoe'@'%'"},
+		{"SELECT '?', \"?\", `?`, ?", []interface{}{int64(3)}, "SELECT '?', \"?\", `?`, 3"},
+		{"SELECT 'a\\'?', ?", []interface{}{nil}, "SELECT 'a\\'?', NULL"},
+		{"SELECT ?", []interface{}{[]byte("x")}, "SELECT 'x'"},
+	} {
+		args := make([]driver.NamedValue, 0, len(tc.args))
+		for i, arg := range tc.args {
+			args = append(args, driver.NamedValue{Ordinal: i + 1, Value: arg})
+		}
+		if actual := interpolateForDisplay(tc.query, args); actual != tc.expected {
+			t.Errorf("interpolateForDisplay(%q) = %q, expected %q", tc.query, actual, tc.expected)
+		}
+	}
+}
+
+func TestShowStatements(t *testing.T) {
+	fake := &fakeExecConn{}
+	conn := &dryRunConn{Conn: fake}
+	meta := &MySQLConfiguration{ShowStatements: true}
+
+	diags := showStatements(context.Background(), nil, meta, func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		args := []driver.NamedValue{{Ordinal: 1, Value: "jdoe"}, {Ordinal: 2, Value: "%"}, {Ordinal: 3, Value: "secret"}}
+		if _, err := conn.ExecContext(ctx, "CREATE USER ?@? IDENTIFIED BY ?", args); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := conn.ExecContext(ctx, "GRANT SELECT ON `db`.* TO 'jdoe'@'%'", nil); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	})
+	if fake.execs != 2 {
+		t.Errorf("expected the statements to run, got %d executions", fake.execs)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning, got %v", diags)
+	}
+	expected := "The following statements were run:\n\nCREATE USER 'jdoe'@'%' IDENTIFIED BY '<redacted>';\nGRANT SELECT ON `db`.* TO 'jdoe'@'%';"
+	if diags[0].Detail != expected {
+		t.Errorf("expected detail %q, got %q", expected, diags[0].Detail)
+	}
+
+	diags = showStatements(context.Background(), nil, meta, func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return nil
+	})
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics without statements, got %v", diags)
+	}
+}
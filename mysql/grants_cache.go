@@ -0,0 +1,148 @@
+package mysql
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// grantsCache keeps the SHOW GRANTS rows of each grantee, so that the grants
+// of the same user or role only query them once per Terraform run. The rows
+// of a grantee are dropped whenever its grants change. A nil cache doesn't
+// cache anything.
+type grantsCache struct {
+	mtx sync.Mutex
+	// rows are kept per connection, e.g. to read_endpoint, as they may not
+	// see the same grants yet.
+	rows        map[string]map[*sql.DB][]string
+	generations map[string]int
+
+	// bulk keeps the privileges of all grantees read from
+	// INFORMATION_SCHEMA per connection. bulkMtx makes parallel reads wait
+	// for the first one to load them.
+	bulkMtx sync.Mutex
+	bulk    map[*sql.DB]*bulkGrants
+}
+
+type bulkGrants struct {
+	grants map[string][]*TablePrivilegeGrant
+	// stale grantees changed after the privileges were read.
+	stale map[string]bool
+}
+
+func newGrantsCache() *grantsCache {
+	return &grantsCache{
+		rows:        map[string]map[*sql.DB][]string{},
+		generations: map[string]int{},
+		bulk:        map[*sql.DB]*bulkGrants{},
+	}
+}
+
+// granteeKey is the same for user@% and the role without a host.
+func granteeKey(userOrRole UserOrRole) string {
+	if userOrRole.Host == "%" {
+		userOrRole.Host = ""
+	}
+	return userOrRole.IDString()
+}
+
+// get returns the cached rows, or the generation to pass to set for caching
+// the rows read now.
+func (c *grantsCache) get(db *sql.DB, userOrRole UserOrRole) ([]string, int, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := granteeKey(userOrRole)
+	rows, ok := c.rows[key][db]
+	return rows, c.generations[key], ok
+}
+
+// set caches the rows unless the grants of the grantee changed since get
+// returned the generation.
+func (c *grantsCache) set(db *sql.DB, userOrRole UserOrRole, generation int, rows []string) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := granteeKey(userOrRole)
+	if c.generations[key] != generation {
+		return
+	}
+	if c.rows[key] == nil {
+		c.rows[key] = map[*sql.DB][]string{}
+	}
+	c.rows[key][db] = rows
+}
+
+// invalidate drops the rows of the grantee.
+func (c *grantsCache) invalidate(userOrRole UserOrRole) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := granteeKey(userOrRole)
+	c.generations[key]++
+	delete(c.rows, key)
+	for _, b := range c.bulk {
+		b.stale[key] = true
+		delete(b.grants, key)
+	}
+}
+
+// informationSchemaGrants returns copies of the grants of the grantee, which
+// load reads for all grantees the first time. It returns false if the grants
+// of the grantee changed since they were loaded, or without a cache.
+func (c *grantsCache) informationSchemaGrants(db *sql.DB, userOrRole UserOrRole, load func() (map[string][]*TablePrivilegeGrant, error)) ([]*TablePrivilegeGrant, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+	c.bulkMtx.Lock()
+	defer c.bulkMtx.Unlock()
+
+	c.mtx.Lock()
+	b := c.bulk[db]
+	generations := map[string]int{}
+	for key, generation := range c.generations {
+		generations[key] = generation
+	}
+	c.mtx.Unlock()
+
+	if b == nil {
+		grants, err := load()
+		if err != nil {
+			return nil, false, err
+		}
+		b = &bulkGrants{grants: grants, stale: map[string]bool{}}
+
+		c.mtx.Lock()
+		for key, generation := range c.generations {
+			if generations[key] != generation {
+				b.stale[key] = true
+				delete(b.grants, key)
+			}
+		}
+		c.bulk[db] = b
+		c.mtx.Unlock()
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := granteeKey(userOrRole)
+	if b.stale[key] {
+		return nil, false, nil
+	}
+	grants := []*TablePrivilegeGrant{}
+	for _, grant := range b.grants[key] {
+		copied := *grant
+		copied.Privileges = append([]string{}, grant.Privileges...)
+		grants = append(grants, &copied)
+	}
+	return grants, true, nil
+}
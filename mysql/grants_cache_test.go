@@ -0,0 +1,48 @@
+package mysql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestGrantsCache(t *testing.T) {
+	db, replica := &sql.DB{}, &sql.DB{}
+	user := UserOrRole{Name: "jdoe", Host: "%"}
+	rows := []string{"GRANT USAGE ON *.* TO `jdoe`@`%`"}
+
+	cache := newGrantsCache()
+	if _, _, ok := cache.get(db, user); ok {
+		t.Fatal("expected an empty cache")
+	}
+
+	_, generation, _ := cache.get(db, user)
+	cache.set(db, user, generation, rows)
+	if cached, _, ok := cache.get(db, UserOrRole{Name: "jdoe"}); !ok || !reflect.DeepEqual(cached, rows) {
+		t.Errorf("expected cached rows %v, got %v", rows, cached)
+	}
+	if _, _, ok := cache.get(replica, user); ok {
+		t.Error("expected no rows cached for another connection")
+	}
+	if _, _, ok := cache.get(db, UserOrRole{Name: "jdoe", Host: "localhost"}); ok {
+		t.Error("expected no rows cached for another host")
+	}
+
+	// Rows read before the grants changed mustn't be cached.
+	_, generation, _ = cache.get(replica, user)
+	cache.invalidate(user)
+	if _, _, ok := cache.get(db, user); ok {
+		t.Error("expected rows to be dropped")
+	}
+	cache.set(replica, user, generation, rows)
+	if _, _, ok := cache.get(replica, user); ok {
+		t.Error("expected stale rows not to be cached")
+	}
+
+	var disabled *grantsCache
+	disabled.set(db, user, 0, rows)
+	disabled.invalidate(user)
+	if _, _, ok := disabled.get(db, user); ok {
+		t.Error("expected a nil cache not to cache rows")
+	}
+}
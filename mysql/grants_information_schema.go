@@ -0,0 +1,188 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// The privileges that make up ALL PRIVILEGES on databases and on tables. SHOW
+// GRANTS prints ALL PRIVILEGES instead of them, while INFORMATION_SCHEMA lists
+// each of them.
+var (
+	allDatabasePrivileges = []string{
+		"ALTER", "ALTER ROUTINE", "CREATE", "CREATE ROUTINE", "CREATE TEMPORARY TABLES",
+		"CREATE VIEW", "DELETE", "DROP", "EVENT", "EXECUTE", "INDEX", "INSERT",
+		"LOCK TABLES", "REFERENCES", "SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+	}
+	allTablePrivileges = []string{
+		"ALTER", "CREATE", "CREATE VIEW", "DELETE", "DROP", "INDEX", "INSERT",
+		"REFERENCES", "SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+	}
+)
+
+// parseInformationSchemaGrantee parses the GRANTEE column, e.g. 'jdoe'@'%' or
+// 'role' for MariaDB roles.
+func parseInformationSchemaGrantee(grantee string) UserOrRole {
+	if m := informationSchemaGranteeRegex.FindStringSubmatch(grantee); m != nil {
+		return UserOrRole{Name: m[1], Host: m[2]}
+	}
+	return UserOrRole{Name: strings.Trim(grantee, "'")}
+}
+
+// collapseAllPrivileges replaces the privileges with ALL PRIVILEGES if they
+// make up all privileges of the level, as SHOW GRANTS does.
+func collapseAllPrivileges(privileges []string, all []string) []string {
+	for _, privilege := range all {
+		if !containsString(privileges, privilege) {
+			return privileges
+		}
+	}
+	return []string{"ALL PRIVILEGES"}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// loadInformationSchemaGrants reads the database, table and column privileges
+// of all grantees at once, keyed by granteeKey. Grants on *.*, routines and
+// roles aren't part of them.
+func loadInformationSchemaGrants(ctx context.Context, db *sql.DB) (map[string][]*TablePrivilegeGrant, error) {
+	type object struct {
+		grantee         string
+		database, table string
+	}
+	grants := map[object]*TablePrivilegeGrant{}
+	columns := map[object]map[string][]string{}
+	order := []object{}
+
+	grantFor := func(grantee, database, table string) (object, *TablePrivilegeGrant) {
+		userOrRole := parseInformationSchemaGrantee(grantee)
+		key := object{granteeKey(userOrRole), database, table}
+		if grants[key] == nil {
+			grants[key] = &TablePrivilegeGrant{
+				Database:   database,
+				Table:      table,
+				Privileges: []string{},
+				UserOrRole: userOrRole,
+				TLSOption:  "NONE",
+			}
+			order = append(order, key)
+		}
+		return key, grants[key]
+	}
+
+	queries := []struct {
+		sql  string
+		scan func(rows *sql.Rows) error
+	}{
+		{
+			"SELECT GRANTEE, TABLE_SCHEMA, PRIVILEGE_TYPE, IS_GRANTABLE FROM INFORMATION_SCHEMA.SCHEMA_PRIVILEGES",
+			func(rows *sql.Rows) error {
+				var grantee, database, privilege, grantable string
+				if err := rows.Scan(&grantee, &database, &privilege, &grantable); err != nil {
+					return err
+				}
+				_, grant := grantFor(grantee, database, "*")
+				grant.Privileges = append(grant.Privileges, privilege)
+				grant.Grant = grant.Grant || grantable == "YES"
+				return nil
+			},
+		},
+		{
+			"SELECT GRANTEE, TABLE_SCHEMA, TABLE_NAME, PRIVILEGE_TYPE, IS_GRANTABLE FROM INFORMATION_SCHEMA.TABLE_PRIVILEGES",
+			func(rows *sql.Rows) error {
+				var grantee, database, table, privilege, grantable string
+				if err := rows.Scan(&grantee, &database, &table, &privilege, &grantable); err != nil {
+					return err
+				}
+				_, grant := grantFor(grantee, database, table)
+				grant.Privileges = append(grant.Privileges, privilege)
+				grant.Grant = grant.Grant || grantable == "YES"
+				return nil
+			},
+		},
+		{
+			"SELECT GRANTEE, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, PRIVILEGE_TYPE, IS_GRANTABLE FROM INFORMATION_SCHEMA.COLUMN_PRIVILEGES",
+			func(rows *sql.Rows) error {
+				var grantee, database, table, column, privilege, grantable string
+				if err := rows.Scan(&grantee, &database, &table, &column, &privilege, &grantable); err != nil {
+					return err
+				}
+				key, grant := grantFor(grantee, database, table)
+				if columns[key] == nil {
+					columns[key] = map[string][]string{}
+				}
+				columns[key][privilege] = append(columns[key][privilege], column)
+				grant.Grant = grant.Grant || grantable == "YES"
+				return nil
+			},
+		},
+	}
+
+	for _, query := range queries {
+		logStatement(ctx, query.sql)
+		rows, err := db.QueryContext(ctx, query.sql)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading privileges: %w", err)
+		}
+		for rows.Next() {
+			if err := query.scan(rows); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed reading privileges: %w", err)
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed reading privileges: %w", err)
+		}
+	}
+
+	result := map[string][]*TablePrivilegeGrant{}
+	for _, key := range order {
+		grant := grants[key]
+		all := allTablePrivileges
+		if grant.Table == "*" {
+			all = allDatabasePrivileges
+		}
+		privileges := collapseAllPrivileges(normalizePerms(grant.Privileges), all)
+		for privilege, names := range columns[key] {
+			quoted := make([]string, len(names))
+			for i, name := range names {
+				quoted[i] = quoteIdentifier(name)
+			}
+			privileges = append(privileges, fmt.Sprintf("%s(%s)", privilege, strings.Join(quoted, ",")))
+		}
+		grant.Privileges = normalizePerms(privileges)
+		result[key.grantee] = append(result[key.grantee], grant)
+	}
+	return result, nil
+}
+
+// getMatchingGrantFromInformationSchema is getMatchingGrant for database and
+// table grants, using the privileges of all grantees read at once. It returns
+// false if the privileges of the grantee have to be read with SHOW GRANTS,
+// e.g. because they changed since.
+func getMatchingGrantFromInformationSchema(ctx context.Context, db *sql.DB, cache *grantsCache, desiredGrant *TablePrivilegeGrant) (MySQLGrant, bool, error) {
+	grants, ok, err := cache.informationSchemaGrants(db, desiredGrant.GetUserOrRole(), func() (map[string][]*TablePrivilegeGrant, error) {
+		return loadInformationSchemaGrants(ctx, db)
+	})
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	for _, grant := range grants {
+		if grantsConflict(desiredGrant, grant) {
+			return grant, true, nil
+		}
+	}
+	return nil, true, nil
+}
@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestParseInformationSchemaGrantee(t *testing.T) {
+	for grantee, expected := range map[string]UserOrRole{
+		"'jdoe'@'%'":            {Name: "jdoe", Host: "%"},
+		"'jdoe'@'10.0.0.0/8'":   {Name: "jdoe", Host: "10.0.0.0/8"},
+		"'dev'":                 {Name: "dev"},
+		"'at@sign'@'localhost'": {Name: "at@sign", Host: "localhost"},
+	} {
+		if userOrRole := parseInformationSchemaGrantee(grantee); userOrRole != expected {
+			t.Errorf("parseInformationSchemaGrantee(%s) = %v, expected %v", grantee, userOrRole, expected)
+		}
+	}
+}
+
+func TestCollapseAllPrivileges(t *testing.T) {
+	privileges := collapseAllPrivileges(append([]string{"DELETE HISTORY"}, allTablePrivileges...), allTablePrivileges)
+	if !reflect.DeepEqual(privileges, []string{"ALL PRIVILEGES"}) {
+		t.Errorf("expected ALL PRIVILEGES, got %v", privileges)
+	}
+	privileges = collapseAllPrivileges([]string{"INSERT", "SELECT"}, allTablePrivileges)
+	if !reflect.DeepEqual(privileges, []string{"INSERT", "SELECT"}) {
+		t.Errorf("expected privileges to be kept, got %v", privileges)
+	}
+}
+
+func TestInformationSchemaGrantsCache(t *testing.T) {
+	db := &sql.DB{}
+	user := UserOrRole{Name: "jdoe", Host: "%"}
+	other := UserOrRole{Name: "other", Host: "%"}
+	loads := 0
+	load := func() (map[string][]*TablePrivilegeGrant, error) {
+		loads++
+		return map[string][]*TablePrivilegeGrant{
+			granteeKey(user): {{Database: "db", Table: "*", Privileges: []string{"SELECT"}, UserOrRole: user}},
+		}, nil
+	}
+
+	cache := newGrantsCache()
+	grants, ok, err := cache.informationSchemaGrants(db, user, load)
+	if err != nil || !ok || len(grants) != 1 {
+		t.Fatalf("expected the grant of %v, got %v, %t, %v", user, grants, ok, err)
+	}
+	// Callers may change the grants they get.
+	grants[0].Privileges = append(grants[0].Privileges, "INSERT")
+
+	grants, ok, _ = cache.informationSchemaGrants(db, user, load)
+	if !ok || !reflect.DeepEqual(grants[0].Privileges, []string{"SELECT"}) || loads != 1 {
+		t.Errorf("expected the cached grant once loaded, got %v after %d loads", grants, loads)
+	}
+	if grants, ok, _ := cache.informationSchemaGrants(db, other, load); !ok || len(grants) != 0 {
+		t.Errorf("expected no grants of %v, got %v", other, grants)
+	}
+
+	cache.invalidate(user)
+	if _, ok, _ := cache.informationSchemaGrants(db, user, load); ok {
+		t.Error("expected changed grants to be read with SHOW GRANTS")
+	}
+
+	var disabled *grantsCache
+	if _, ok, _ := disabled.informationSchemaGrants(db, user, load); ok {
+		t.Error("expected a nil cache not to load grants")
+	}
+}
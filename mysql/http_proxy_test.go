@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"golang.org/x/net/proxy"
+)
+
+func TestHTTPProxyDialer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	requests := make(chan *http.Request, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		requests <- req
+		// The greeting is sent along with the response, as MySQL servers would.
+		io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\ngreeting")
+	}()
+
+	proxyURL, _ := url.Parse("http://user:secret@" + listener.Addr().String())
+	dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := dialer.Dial("tcp", "mysql.internal:3306")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	req := <-requests
+	if req.Method != http.MethodConnect || req.Host != "mysql.internal:3306" {
+		t.Errorf("unexpected request %s %s", req.Method, req.Host)
+	}
+	if auth := req.Header.Get("Proxy-Authorization"); auth != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("unexpected Proxy-Authorization %q", auth)
+	}
+
+	greeting := make([]byte, len("greeting"))
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		t.Fatal(err)
+	}
+	if string(greeting) != "greeting" {
+		t.Errorf("expected greeting, got %q", greeting)
+	}
+}
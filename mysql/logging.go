@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const redactedValue = "<redacted>"
+
+// sensitiveLiteralRegex finds the string literals of passwords and auth
+// strings, e.g. in IDENTIFIED BY '...', IDENTIFIED WITH plugin AS '...',
+// SOURCE_PASSWORD = '...' and SET PASSWORD FOR user = '...'.
+var sensitiveLiteralRegex = regexp.MustCompile(`(?i)(\bIDENTIFIED\s+(?:(?:WITH|VIA)\s+\S+\s+)?(?:BY|AS|USING)\s+(?:PASSWORD\s*\(\s*)?|PASSWORD\s*=\s*(?:PASSWORD\s*\(\s*)?|\bSET\s+PASSWORD\b[^=]*=\s*(?:PASSWORD\s*\(\s*)?)'(?:[^'\\]|\\.|'')*'`)
+
+// logFieldKeys are the attributes added to the logs of each resource having
+// them.
+var logFieldKeys = []string{"user", "host", "role", "database"}
+
+// redactSQL replaces passwords and auth strings in the statement, so it can be
+// logged.
+func redactSQL(stmt string) string {
+	return sensitiveLiteralRegex.ReplaceAllString(stmt, "${1}'"+redactedValue+"'")
+}
+
+// logStatement logs the statement about to be run, with passwords redacted.
+// The arguments mustn't be sensitive, as they are logged as they are.
+func logStatement(ctx context.Context, stmt string, args ...interface{}) {
+	fields := map[string]interface{}{
+		"statement": redactSQL(stmt),
+	}
+	if len(args) > 0 {
+		fields["args"] = args
+	}
+	tflog.Debug(ctx, "Executing statement", fields)
+}
+
+// setLogFields makes the operations of the resource log its type, ID and
+// the user and database it manages.
+func setLogFields(name string, resource *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			ctx = tflog.SetField(ctx, "resource", name)
+			if d.Id() != "" {
+				ctx = tflog.SetField(ctx, "id", d.Id())
+			}
+			for _, key := range logFieldKeys {
+				if _, ok := resource.Schema[key]; !ok {
+					continue
+				}
+				if value, ok := d.GetOk(key); ok {
+					ctx = tflog.SetField(ctx, key, value)
+				}
+			}
+			return f(ctx, d, meta)
+		}
+	}
+
+	resource.CreateContext = wrap(resource.CreateContext)
+	resource.ReadContext = wrap(resource.ReadContext)
+	resource.UpdateContext = wrap(resource.UpdateContext)
+	resource.DeleteContext = wrap(resource.DeleteContext)
+}
@@ -0,0 +1,47 @@
+package mysql
+
+import "testing"
+
+func TestRedactSQL(t *testing.T) {
+	for _, tc := range []struct {
+		stmt     string
+		expected string
+	}{
+		{
+			"CREATE USER 'jdoe'@'%' IDENTIFIED BY 'secret'",
+			"CREATE USER 'jdoe'@'%' IDENTIFIED BY '<redacted>'",
+		},
+		{
+			"ALTER USER 'jdoe'@'%' IDENTIFIED WITH `caching_sha2_password` BY 'it''s \\' secret' REQUIRE SSL",
+			"ALTER USER 'jdoe'@'%' IDENTIFIED WITH `caching_sha2_password` BY '<redacted>' REQUIRE SSL",
+		},
+		{
+			"CREATE USER 'jdoe'@'%' IDENTIFIED WITH mysql_native_password AS '*94BDCEBE19083CE2A1F959FD02F964C7AF4CFC29'",
+			"CREATE USER 'jdoe'@'%' IDENTIFIED WITH mysql_native_password AS '<redacted>'",
+		},
+		{
+			"CREATE USER 'jdoe'@'%' IDENTIFIED VIA ed25519 USING PASSWORD('secret')",
+			"CREATE USER 'jdoe'@'%' IDENTIFIED VIA ed25519 USING PASSWORD('<redacted>')",
+		},
+		{
+			"SET PASSWORD FOR 'jdoe'@'%' = PASSWORD('secret')",
+			"SET PASSWORD FOR 'jdoe'@'%' = PASSWORD('<redacted>')",
+		},
+		{
+			"CHANGE REPLICATION SOURCE TO SOURCE_USER = 'repl', SOURCE_PASSWORD = 'secret' FOR CHANNEL ''",
+			"CHANGE REPLICATION SOURCE TO SOURCE_USER = 'repl', SOURCE_PASSWORD = '<redacted>' FOR CHANNEL ''",
+		},
+		{
+			"GRANT SELECT ON `db`.* TO 'jdoe'@'%'",
+			"GRANT SELECT ON `db`.* TO 'jdoe'@'%'",
+		},
+		{
+			"ALTER USER ?@? IDENTIFIED WITH `mysql_native_password` AS ?",
+			"ALTER USER ?@? IDENTIFIED WITH `mysql_native_password` AS ?",
+		},
+	} {
+		if actual := redactSQL(tc.stmt); actual != tc.expected {
+			t.Errorf("redactSQL(%q) = %q, expected %q", tc.stmt, actual, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// queryMetrics counts the statements the provider runs. A nil queryMetrics
+// doesn't count anything.
+type queryMetrics struct {
+	mtx    sync.Mutex
+	file   string
+	report metricsReport
+}
+
+type metricsReport struct {
+	Operations int64   `json:"operations"`
+	Statements int64   `json:"statements"`
+	ShowGrants int64   `json:"show_grants"`
+	Retries    int64   `json:"retries"`
+	Errors     int64   `json:"errors"`
+	DBTimeSec  float64 `json:"db_time_sec"`
+}
+
+func newQueryMetrics(file string) *queryMetrics {
+	return &queryMetrics{file: file}
+}
+
+func (m *queryMetrics) statement(query string, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.report.Statements++
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(query)), "SHOW GRANTS") {
+		m.report.ShowGrants++
+	}
+	if err != nil && err != driver.ErrSkip {
+		m.report.Errors++
+	}
+	m.report.DBTimeSec += elapsed.Seconds()
+}
+
+func (m *queryMetrics) retry() {
+	if m == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.report.Retries++
+}
+
+// operationDone logs the metrics so far and writes them to the report file.
+// Providers aren't told when an apply ends, so the file is rewritten after
+// every operation and holds the totals once Terraform is done.
+func (m *queryMetrics) operationDone() {
+	if m == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.report.Operations++
+	r := m.report
+	log.Printf("[DEBUG] Metrics: %d operations ran %d statements (%d SHOW GRANTS, %d retries, %d errors) in %.3fs",
+		r.Operations, r.Statements, r.ShowGrants, r.Retries, r.Errors, r.DBTimeSec)
+
+	if m.file == "" {
+		return
+	}
+	if err := writeMetricsReport(m.file, r); err != nil {
+		log.Printf("[WARN] Failed writing metrics to %s: %v", m.file, err)
+	}
+}
+
+// writeMetricsReport replaces the file at once, so it's never read half
+// written.
+func writeMetricsReport(file string, r metricsReport) error {
+	contents, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(contents, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// setMetrics makes the operations of the resource report the metrics when
+// they're done.
+func setMetrics(resource *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			defer meta.(*MySQLConfiguration).Metrics.operationDone()
+			return f(ctx, d, meta)
+		}
+	}
+
+	resource.CreateContext = wrap(resource.CreateContext)
+	resource.ReadContext = wrap(resource.ReadContext)
+	resource.UpdateContext = wrap(resource.UpdateContext)
+	resource.DeleteContext = wrap(resource.DeleteContext)
+}
+
+// metricsConn counts the statements run on the connection and the time they
+// take, retries included.
+type metricsConn struct {
+	driver.Conn
+	metrics *queryMetrics
+}
+
+func (c *metricsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.metrics.statement(query, time.Since(start), err)
+	return result, err
+}
+
+func (c *metricsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.metrics.statement(query, time.Since(start), err)
+	return rows, err
+}
+
+func (c *metricsConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *metricsConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *metricsConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *metricsConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *metricsConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *metricsConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
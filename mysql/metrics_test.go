@@ -0,0 +1,47 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestQueryMetrics(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "metrics.json")
+	metrics := newQueryMetrics(file)
+	deadlock := &mysql.MySQLError{Number: lockDeadlockErrCode, Message: "Deadlock found"}
+	fake := &fakeExecConn{errs: []error{deadlock}}
+	retry := &retryConn{conn: fake, policy: RetryPolicy{MaxRetries: 1, Interval: time.Millisecond}, metrics: metrics}
+	conn := &metricsConn{Conn: retry, metrics: metrics}
+
+	for _, query := range []string{"GRANT SELECT ON *.* TO 'jdoe'@'%'", "show grants for 'jdoe'@'%'"} {
+		if _, err := conn.ExecContext(context.Background(), query, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	metrics.operationDone()
+
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed reading metrics: %v", err)
+	}
+	var report metricsReport
+	if err := json.Unmarshal(contents, &report); err != nil {
+		t.Fatalf("failed parsing metrics: %v", err)
+	}
+	report.DBTimeSec = 0
+	expected := metricsReport{Operations: 1, Statements: 2, ShowGrants: 1, Retries: 1}
+	if report != expected {
+		t.Errorf("expected %+v, got %+v", expected, report)
+	}
+
+	var disabled *queryMetrics
+	disabled.statement("SELECT 1", time.Second, nil)
+	disabled.retry()
+	disabled.operationDone()
+}
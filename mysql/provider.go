@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,7 +23,6 @@ import (
 	"google.golang.org/api/googleapi"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -31,26 +31,100 @@ import (
 
 	cloudsqlconn "cloud.google.com/go/cloudsqlconn"
 	cloudsql "cloud.google.com/go/cloudsqlconn/mysql/mysql"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
-	azidentity "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 )
 
 const (
 	cleartextPasswords = "cleartext"
 	nativePasswords    = "native"
 	unknownUserErrCode = 1396
+	// roleNotGrantedErrCode is ER_ROLE_NOT_GRANTED, e.g. of SET DEFAULT ROLE.
+	roleNotGrantedErrCode = 3530
+
+	// defaultResourceTimeout is the SDK's default, for resources without
+	// a timeouts block.
+	defaultResourceTimeout = 20 * time.Minute
+)
+
+// serverFlavor is the kind of server, as told by its version string.
+type serverFlavor int
+
+const (
+	flavorMySQL serverFlavor = iota
+	flavorMariaDB
+	flavorTiDB
 )
 
+func (f serverFlavor) String() string {
+	switch f {
+	case flavorMariaDB:
+		return "MariaDB"
+	case flavorTiDB:
+		return "TiDB"
+	default:
+		return "MySQL"
+	}
+}
+
 type OneConnection struct {
 	Db      *sql.DB
 	Version *version.Version
+	Flavor  serverFlavor
+	// AnsiQuotes is set when the server's sql_mode has ANSI_QUOTES, so SHOW
+	// statements quote identifiers with double quotes.
+	AnsiQuotes bool
 }
 
 type MySQLConfiguration struct {
 	Config                 *mysql.Config
 	MaxConnLifetime        time.Duration
 	MaxOpenConns           int
+	MaxIdleConns           int
+	MaxConnIdleTime        time.Duration
 	ConnectRetryTimeoutSec time.Duration
+	ConnectRetry           RetryPolicy
+	// PasswordFunc returns the current password for new connections, if the
+	// password expires, e.g. with Azure AD tokens.
+	PasswordFunc func(ctx context.Context) (string, error)
+	// FallbackEndpoints are tried in order when endpoint can't be reached.
+	FallbackEndpoints []string
+	// AuroraWriter makes connections to Aurora readers go to the writer instead.
+	AuroraWriter bool
+	// DefaultAuthPlugin is used for new users without an auth_plugin.
+	DefaultAuthPlugin string
+	// InitCommands are run on every new connection.
+	InitCommands []string
+	// SerializePrivilegeChanges makes changes of accounts and grants run one
+	// at a time.
+	SerializePrivilegeChanges bool
+	// FlushPrivileges runs FLUSH PRIVILEGES after changes of accounts and
+	// grants.
+	FlushPrivileges bool
+	// GrantLockTimeout makes grant changes hold an advisory lock of the
+	// grantee for up to that long, if set.
+	GrantLockTimeout time.Duration
+	// InformationSchemaGrants makes refreshes of database and table grants
+	// read the privileges of all grantees from INFORMATION_SCHEMA at once.
+	InformationSchemaGrants bool
+	// RDSCompatible grants ALL PRIVILEGES as the privileges the provider's
+	// user may grant.
+	RDSCompatible bool
+	// SkipGrantReadAfterCreate trusts new grants instead of reading them back.
+	SkipGrantReadAfterCreate bool
+	// TransientRetry retries statements failing with deadlocks, lock wait
+	// timeouts or lost connections, if set.
+	TransientRetry *RetryPolicy
+	// DryRun makes changes of resources only report the statements they
+	// would run.
+	DryRun bool
+	// ShowStatements adds the statements changes of resources ran to their
+	// diagnostics.
+	ShowStatements bool
+	// ReadConfiguration connects to the replica refreshes read from, if set.
+	ReadConfiguration *MySQLConfiguration
+	// Metrics counts the statements run, if set.
+	Metrics *queryMetrics
+	// GrantsCache keeps SHOW GRANTS rows for the rest of the Terraform run.
+	GrantsCache *grantsCache
 }
 
 type CustomTLS struct {
@@ -58,11 +132,16 @@ type CustomTLS struct {
 	CACert     string `json:"ca_cert"`
 	ClientCert string `json:"client_cert"`
 	ClientKey  string `json:"client_key"`
+	SkipVerify bool   `json:"skip_verify"`
+	ServerName string `json:"server_name"`
 }
 
 var (
 	connectionCacheMtx sync.Mutex
 	connectionCache    map[string]*OneConnection
+
+	cloudSQLDriversMtx sync.Mutex
+	cloudSQLDrivers    = map[string]bool{}
 )
 
 func init() {
@@ -73,7 +152,7 @@ func init() {
 }
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"endpoint": {
 				Type:        schema.TypeString,
@@ -89,9 +168,20 @@ func Provider() *schema.Provider {
 				},
 			},
 
+			"fallback_endpoints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"read_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("MYSQL_USERNAME", nil),
 			},
 
@@ -101,6 +191,80 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("MYSQL_PASSWORD", nil),
 			},
 
+			"vault_credentials": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("VAULT_ADDR", nil),
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							DefaultFunc: schema.EnvDefaultFunc("VAULT_TOKEN", nil),
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("VAULT_NAMESPACE", ""),
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"username_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "username",
+						},
+						"password_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "password",
+						},
+					},
+				},
+			},
+
+			"aws_secret": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"vault_credentials"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"secret_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"aws_secret.0.secret_id", "aws_secret.0.parameter"},
+						},
+						"parameter": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"username_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "username",
+						},
+						"password_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "password",
+						},
+					},
+				},
+			},
+
 			"proxy": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -108,7 +272,7 @@ func Provider() *schema.Provider {
 					"ALL_PROXY",
 					"all_proxy",
 				}, nil),
-				ValidateFunc: validation.StringMatch(regexp.MustCompile("^socks5h?://.*:\\d+$"), "The proxy URL is not a valid socks url."),
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^(socks5h?|http)://.*:\\d+$"), "The proxy URL is not a valid socks or http url."),
 			},
 
 			"tls": {
@@ -125,6 +289,7 @@ func Provider() *schema.Provider {
 			"custom_tls": {
 				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
 				Default:  nil,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -135,20 +300,104 @@ func Provider() *schema.Provider {
 						},
 						"ca_cert": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 						},
 						"client_cert": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"custom_tls.0.client_key"},
+						},
+						"client_key": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							RequiredWith: []string{"custom_tls.0.client_cert"},
+						},
+						"skip_verify": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"server_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"ssh_tunnel": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
-						"client_key": {
+						"user": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+						"private_key": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"private_key_passphrase": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"use_agent": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"host_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"jump_host": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"jump_user": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"jump_host_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 					},
 				},
 			},
 
+			"connect_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+
+			"read_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+
+			"write_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+
 			"max_conn_lifetime_sec": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -159,12 +408,59 @@ func Provider() *schema.Provider {
 				Optional: true,
 			},
 
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"max_conn_idle_time_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"max_execution_time_ms": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"lock_wait_timeout_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
 			"conn_params": {
 				Type:     schema.TypeMap,
 				Optional: true,
 				Default:  nil,
 			},
 
+			"aurora_writer": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"init_commands": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"cleartext_requires_tls": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"default_auth_plugin": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"authentication_plugin": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -178,6 +474,120 @@ func Provider() *schema.Provider {
 				Default:  300,
 			},
 
+			"max_connect_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"connect_retry_interval_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"connect_retry_max_interval_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"connect_retry_backoff": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"serialize_privilege_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"flush_privileges": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"information_schema_grants": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"rds_compatible": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"skip_grant_read_after_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"grant_lock_timeout_sec": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"dry_run": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"show_statements": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"metrics": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"metrics_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"max_transient_error_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"transient_error_retry_interval_ms": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      200,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"transient_error_retry_max_interval_ms": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"transient_error_retry_backoff": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"iam_database_authentication": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -188,26 +598,106 @@ func Provider() *schema.Provider {
 				Optional: true,
 				Default:  false,
 			},
+			"azure_credential": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  azureCredentialDefault,
+				ValidateFunc: validation.StringInSlice([]string{
+					azureCredentialDefault,
+					azureCredentialManagedIdentity,
+					azureCredentialEnvironment,
+					azureCredentialCli,
+				}, false),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"mysql_tables": dataSourceTables(),
+			"mysql_binary_logs":               dataSourceBinaryLogs(),
+			"mysql_charsets":                  dataSourceCharsets(),
+			"mysql_databases":                 dataSourceDatabases(),
+			"mysql_engines":                   dataSourceEngines(),
+			"mysql_group_replication_members": dataSourceGroupReplicationMembers(),
+			"mysql_innodb_cluster":            dataSourceInnoDBCluster(),
+			"mysql_query":                     dataSourceQuery(),
+			"mysql_replica_status":            dataSourceReplicaStatus(),
+			"mysql_roles":                     dataSourceRoles(),
+			"mysql_schema_privileges":         dataSourceSchemaPrivileges(),
+			"mysql_table_metadata":            dataSourceTableMetadata(),
+			"mysql_tables":                    dataSourceTables(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"mysql_database":        resourceDatabase(),
-			"mysql_global_variable": resourceGlobalVariable(),
-			"mysql_grant":           resourceGrant(),
-			"mysql_role":            resourceRole(),
-			"mysql_sql":             resourceSql(),
-			"mysql_user_password":   resourceUserPassword(),
-			"mysql_user":            resourceUser(),
-			"mysql_ti_config":       resourceTiConfigVariable(),
-			"mysql_rds_config":      resourceRDSConfig(),
+			"mysql_component":                resourceComponent(),
+			"mysql_database":                 withStateUpgrade(resourceDatabase(), databaseStateID),
+			"mysql_flush":                    resourceFlush(),
+			"mysql_global_variable":          resourceGlobalVariable(),
+			"mysql_grant":                    withStateUpgrade(resourceGrant(), grantStateID),
+			"mysql_group_replication":        resourceGroupReplication(),
+			"mysql_master_key_rotation":      resourceMasterKeyRotation(),
+			"mysql_histogram":                resourceHistogram(),
+			"mysql_loadable_function":        resourceLoadableFunction(),
+			"mysql_mandatory_roles":          resourceMandatoryRoles(),
+			"mysql_partition_window":         resourcePartitionWindow(),
+			"mysql_persisted_variable":       resourcePersistedVariable(),
+			"mysql_plugin":                   resourcePlugin(),
+			"mysql_placement_policy":         resourcePlacementPolicy(),
+			"mysql_proxy_user":               resourceProxyUser(),
+			"mysql_replication_filter":       resourceReplicationFilter(),
+			"mysql_replication_source":       resourceReplicationSource(),
+			"mysql_rds_external_replication": resourceRDSExternalReplication(),
+			"mysql_resource_group":           resourceResourceGroup(),
+			"mysql_role":                     withStateUpgrade(resourceRole(), roleStateID),
+			"mysql_server_definition":        resourceServerDefinition(),
+			"mysql_sql":                      resourceSql(),
+			"mysql_user_password":            resourceUserPassword(),
+			"mysql_user":                     withStateUpgrade(resourceUser(), userStateID),
+			"mysql_user_resource_limits":     resourceUserResourceLimits(),
+			"mysql_users":                    resourceUsers(),
+			"mysql_ti_config":                resourceTiConfigVariable(),
+			"mysql_tiflash_replica":          resourceTiFlashReplica(),
+			"mysql_rds_config":               resourceRDSConfig(),
+			"mysql_table":                    resourceTable(),
+			"mysql_stored_procedure":         resourceStoredProcedure(),
+			"mysql_function":                 resourceFunction(),
+			"mysql_trigger":                  resourceTrigger(),
+			"mysql_audit_log_filter":         resourceAuditLogFilter(),
+			"mysql_audit_log_user":           resourceAuditLogUser(),
+			"mysql_firewall_group":           resourceFirewallGroup(),
+			"mysql_firewall_group_allowlist": resourceFirewallGroupAllowlist(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, resource := range provider.ResourcesMap {
+		setDefaultTimeouts(resource)
+		setLogFields(name, resource)
+		setPrimaryReads(resource)
+		setPlannedStatements(resource)
+		setDryRun(resource)
+		setMetrics(resource)
+	}
+
+	return provider
+}
+
+// setDefaultTimeouts adds a timeouts block to the resource. The SDK cancels
+// the context of each operation once its timeout is over, and the driver then
+// closes the connection running the statement.
+func setDefaultTimeouts(resource *schema.Resource) {
+	if resource.Timeouts != nil {
+		return
+	}
+
+	timeout := schema.DefaultTimeout(defaultResourceTimeout)
+	resource.Timeouts = &schema.ResourceTimeout{
+		Create: timeout,
+		Read:   timeout,
+		Delete: timeout,
+	}
+	if resource.UpdateContext != nil {
+		resource.Timeouts.Update = timeout
+	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -216,11 +706,35 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	var authPlugin = d.Get("authentication_plugin").(string)
 	var allowClearTextPasswords = authPlugin == cleartextPasswords
 	var allowNativePasswords = authPlugin == nativePasswords
+	var username = d.Get("username").(string)
 	var password = d.Get("password").(string)
 	var iam_auth = d.Get("iam_database_authentication").(bool)
 	var private_ip = d.Get("private_ip").(bool)
 	var tlsConfig = d.Get("tls").(string)
 	var tlsConfigStruct *tls.Config
+	var passwordFunc func(ctx context.Context) (string, error)
+
+	if vaultCreds := d.Get("vault_credentials").([]interface{}); len(vaultCreds) > 0 && vaultCreds[0] != nil {
+		var err error
+		username, password, err = fetchVaultCredentials(ctx, vaultCredentialsFromMap(vaultCreds[0].(map[string]interface{})))
+		if err != nil {
+			return nil, diag.Errorf("failed to get credentials from Vault: %v", err)
+		}
+	}
+	if awsSecret := d.Get("aws_secret").([]interface{}); len(awsSecret) > 0 && awsSecret[0] != nil {
+		secretUsername, secretPassword, err := fetchAWSCredentials(ctx, awsSecretFromMap(awsSecret[0].(map[string]interface{})))
+		if err != nil {
+			return nil, diag.Errorf("failed to get credentials from AWS: %v", err)
+		}
+		// Plain string secrets only hold the password.
+		if secretUsername != "" {
+			username = secretUsername
+		}
+		password = secretPassword
+	}
+	if username == "" {
+		return nil, diag.Errorf("username must be set, or come from vault_credentials or aws_secret")
+	}
 
 	customTLSMap := d.Get("custom_tls").([]interface{})
 	if len(customTLSMap) > 0 {
@@ -236,37 +750,43 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 			return nil, diag.Errorf("failed to unmarshal tls config: %v", customTLSJson)
 		}
 
-		var pem []byte
-		rootCertPool := x509.NewCertPool()
-		if strings.HasPrefix(customTLS.CACert, "-----BEGIN") {
-			pem = []byte(customTLS.CACert)
-		} else {
-			pem, err = os.ReadFile(customTLS.CACert)
-			if err != nil {
-				return nil, diag.Errorf("failed to read CA cert: %v", err)
-			}
+		tlsConfigStruct = &tls.Config{
+			ServerName:         customTLS.ServerName,
+			InsecureSkipVerify: customTLS.SkipVerify,
 		}
 
-		if ok := rootCertPool.AppendCertsFromPEM(pem); !ok {
-			return nil, diag.Errorf("failed to append pem: %v", pem)
-		}
+		// Without a CA certificate, the system roots are used.
+		if customTLS.CACert != "" {
+			var pem []byte
+			rootCertPool := x509.NewCertPool()
+			if strings.HasPrefix(customTLS.CACert, "-----BEGIN") {
+				pem = []byte(customTLS.CACert)
+			} else {
+				pem, err = os.ReadFile(customTLS.CACert)
+				if err != nil {
+					return nil, diag.Errorf("failed to read CA cert: %v", err)
+				}
+			}
 
-		clientCert := make([]tls.Certificate, 0, 1)
-		var certs tls.Certificate
-		if strings.HasPrefix(customTLS.ClientCert, "-----BEGIN") {
-			certs, err = tls.X509KeyPair([]byte(customTLS.ClientCert), []byte(customTLS.ClientKey))
-		} else {
-			certs, err = tls.LoadX509KeyPair(customTLS.ClientCert, customTLS.ClientKey)
-		}
-		if err != nil {
-			return nil, diag.Errorf("error loading keypair: %v", err)
+			if ok := rootCertPool.AppendCertsFromPEM(pem); !ok {
+				return nil, diag.Errorf("failed to append pem: %v", pem)
+			}
+			tlsConfigStruct.RootCAs = rootCertPool
 		}
 
-		clientCert = append(clientCert, certs)
-		tlsConfigStruct = &tls.Config{
-			RootCAs:      rootCertPool,
-			Certificates: clientCert,
+		if customTLS.ClientCert != "" {
+			var certs tls.Certificate
+			if strings.HasPrefix(customTLS.ClientCert, "-----BEGIN") {
+				certs, err = tls.X509KeyPair([]byte(customTLS.ClientCert), []byte(customTLS.ClientKey))
+			} else {
+				certs, err = tls.LoadX509KeyPair(customTLS.ClientCert, customTLS.ClientKey)
+			}
+			if err != nil {
+				return nil, diag.Errorf("error loading keypair: %v", err)
+			}
+			tlsConfigStruct.Certificates = []tls.Certificate{certs}
 		}
+
 		mysql.RegisterTLSConfig(customTLS.ConfigKey, tlsConfigStruct)
 		tlsConfig = customTLS.ConfigKey
 	}
@@ -275,27 +795,15 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	if len(endpoint) > 0 && endpoint[0] == '/' {
 		proto = "unix"
 	} else if strings.HasPrefix(endpoint, "cloudsql://") {
-		proto = "cloudsql"
 		endpoint = strings.ReplaceAll(endpoint, "cloudsql://", "")
-		var err error
-		if iam_auth { // Access token will be in the password field
-
-			var opts []cloudsqlconn.Option
-
-			token := oauth2.StaticTokenSource(&oauth2.Token{
-				AccessToken: password,
-			})
-			opts = append(opts, cloudsqlconn.WithIAMAuthN())
-			opts = append(opts, cloudsqlconn.WithIAMAuthNTokenSources(token, token))
-			_, err = cloudsql.RegisterDriver("cloudsql", opts...)
-		} else {
-			var endpointParams []cloudsqlconn.DialOption
-			if private_ip {
-				endpointParams = append(endpointParams, cloudsqlconn.WithPrivateIP())
-			}
-
-			_, err = cloudsql.RegisterDriver("cloudsql", cloudsqlconn.WithDefaultDialOptions(endpointParams...))
+		// The connector only accepts project:region:instance.
+		if !strings.Contains(endpoint, ":") && strings.Count(endpoint, "/") == 2 {
+			endpoint = strings.ReplaceAll(endpoint, "/", ":")
 		}
+		var err error
+		// With IAM authentication, the password is an access token, if given.
+		// Otherwise, the connector uses Application Default Credentials.
+		proto, err = registerCloudSQLDriver(iam_auth, private_ip, password)
 		if err != nil {
 			return nil, diag.Errorf("failed to register driver %v", err)
 		}
@@ -304,31 +812,29 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		// Azure AD does not support native password authentication but go-sql-driver/mysql
 		// has to be configured only with ?allowClearTextPasswords=true not with allowNativePasswords=false in this case
 		allowClearTextPasswords = true
-		azCredential, err := azidentity.NewDefaultAzureCredential(nil)
 		endpoint = strings.ReplaceAll(endpoint, "azure://", "")
-		azScope := "https://ossrdbms-aad.database.windows.net"
-		if os.Getenv("ARM_ENVIRONMENT") == "china" {
-			azScope = "https://ossrdbms-aad.database.chinacloudapi.cn"
-		} else if os.Getenv("ARM_ENVIRONMENT") == "german" {
-			azScope = "https://ossrdbms-aad.database.chinacloudapi.de"
-		} else if os.Getenv("ARM_ENVIRONMENT") == "usgovernment" {
-			azScope = "https://ossrdbms-aad.database.usgovcloudapi.net"
-		}
 
+		azCredential, err := newAzureCredential(d.Get("azure_credential").(string))
 		if err != nil {
 			return nil, diag.Errorf("failed to create Azure credential %v", err)
 		}
 
-		azToken, err := azCredential.GetToken(
-			ctx,
-			policy.TokenRequestOptions{Scopes: []string{azScope + "/.default"}},
-		)
-
+		azTokens := &azureTokenSource{credential: azCredential, scope: azureTokenScope()}
+		password, err = azTokens.Password(ctx)
 		if err != nil {
-			return nil, diag.Errorf("failed to get token from Azure AD %v", err)
+			return nil, diag.FromErr(err)
 		}
+		passwordFunc = azTokens.Password
+	}
+
+	if allowClearTextPasswords && d.Get("cleartext_requires_tls").(bool) && !connectionEncrypted(proto, tlsConfig) {
+		return nil, diag.Errorf("cleartext passwords could be sent unencrypted, set tls to true, skip-verify or custom_tls")
+	}
 
-		password = azToken.Token
+	// Only the classic protocol is supported; X Protocol servers wait for the
+	// client to talk first, so connecting would just time out.
+	if _, port, err := net.SplitHostPort(endpoint); err == nil && proto == "tcp" && port == "33060" {
+		log.Printf("[WARN] %s looks like an X Protocol port, which isn't supported; use the classic protocol port, usually 3306", endpoint)
 	}
 
 	for k, vint := range d.Get("conn_params").(map[string]interface{}) {
@@ -339,8 +845,19 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		connParams[k] = v
 	}
 
+	// The session timeouts are connection parameters too; the ones given in
+	// conn_params win.
+	for key, param := range map[string]string{
+		"max_execution_time_ms": "max_execution_time",
+		"lock_wait_timeout_sec": "lock_wait_timeout",
+	} {
+		if _, ok := connParams[param]; !ok && d.Get(key).(int) > 0 {
+			connParams[param] = strconv.Itoa(d.Get(key).(int))
+		}
+	}
+
 	conf := mysql.Config{
-		User:                    d.Get("username").(string),
+		User:                    username,
 		Passwd:                  password,
 		Net:                     proto,
 		Addr:                    endpoint,
@@ -355,20 +872,104 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		conf.TLS = tlsConfigStruct
 	}
 
+	// The values are validated already.
+	for key, timeout := range map[string]*time.Duration{
+		"connect_timeout": &conf.Timeout,
+		"read_timeout":    &conf.ReadTimeout,
+		"write_timeout":   &conf.WriteTimeout,
+	} {
+		if value := d.Get(key).(string); value != "" {
+			*timeout, _ = time.ParseDuration(value)
+		}
+	}
+
 	dialer, err := makeDialer(d)
 	if err != nil {
 		return nil, diag.Errorf("failed making dialer: %v", err)
 	}
 
-	mysql.RegisterDialContext("tcp", func(ctx context.Context, network string) (net.Conn, error) {
-		return dialer.Dial("tcp", network)
-	})
+	if sshTunnels := d.Get("ssh_tunnel").([]interface{}); len(sshTunnels) > 0 && sshTunnels[0] != nil {
+		if proto != "tcp" {
+			return nil, diag.Errorf("ssh_tunnel only works with TCP endpoints")
+		}
+		// Connections of other provider configurations mustn't go through the
+		// tunnel, so it gets a network of its own. The network name is part of
+		// the DSN, which keeps connections through different tunnels apart.
+		conf.Net = registerSSHTunnelDialer(sshTunnelFromMap(sshTunnels[0].(map[string]interface{})), d.Get("proxy").(string), dialer)
+	} else {
+		mysql.RegisterDialContext("tcp", func(ctx context.Context, network string) (net.Conn, error) {
+			// The context carries connect_timeout, if the dialer supports it.
+			if contextDialer, ok := dialer.(proxy.ContextDialer); ok {
+				return contextDialer.DialContext(ctx, "tcp", network)
+			}
+			return dialer.Dial("tcp", network)
+		})
+	}
 
 	mysqlConf := &MySQLConfiguration{
 		Config:                 &conf,
 		MaxConnLifetime:        time.Duration(d.Get("max_conn_lifetime_sec").(int)) * time.Second,
 		MaxOpenConns:           d.Get("max_open_conns").(int),
+		MaxIdleConns:           d.Get("max_idle_conns").(int),
+		MaxConnIdleTime:        time.Duration(d.Get("max_conn_idle_time_sec").(int)) * time.Second,
 		ConnectRetryTimeoutSec: time.Duration(d.Get("connect_retry_timeout_sec").(int)) * time.Second,
+		ConnectRetry: RetryPolicy{
+			MaxRetries:  d.Get("max_connect_retries").(int),
+			Interval:    time.Duration(d.Get("connect_retry_interval_sec").(int)) * time.Second,
+			MaxInterval: time.Duration(d.Get("connect_retry_max_interval_sec").(int)) * time.Second,
+			Backoff:     d.Get("connect_retry_backoff").(bool),
+		},
+		PasswordFunc:              passwordFunc,
+		AuroraWriter:              d.Get("aurora_writer").(bool),
+		DefaultAuthPlugin:         d.Get("default_auth_plugin").(string),
+		SerializePrivilegeChanges: d.Get("serialize_privilege_changes").(bool),
+		FlushPrivileges:           d.Get("flush_privileges").(bool),
+		GrantLockTimeout:          time.Duration(d.Get("grant_lock_timeout_sec").(int)) * time.Second,
+		InformationSchemaGrants:   d.Get("information_schema_grants").(bool),
+		SkipGrantReadAfterCreate:  d.Get("skip_grant_read_after_create").(bool),
+		RDSCompatible:             d.Get("rds_compatible").(bool),
+		DryRun:                    d.Get("dry_run").(bool),
+		ShowStatements:            d.Get("show_statements").(bool),
+		GrantsCache:               newGrantsCache(),
+	}
+
+	if metricsFile := d.Get("metrics_file").(string); d.Get("metrics").(bool) || metricsFile != "" {
+		mysqlConf.Metrics = newQueryMetrics(metricsFile)
+	}
+
+	for _, fallback := range d.Get("fallback_endpoints").([]interface{}) {
+		mysqlConf.FallbackEndpoints = append(mysqlConf.FallbackEndpoints, fallback.(string))
+	}
+	if len(mysqlConf.FallbackEndpoints) > 0 && proto != "tcp" {
+		return nil, diag.Errorf("fallback_endpoints only work with TCP endpoints")
+	}
+	if mysqlConf.AuroraWriter && proto != "tcp" {
+		return nil, diag.Errorf("aurora_writer only works with TCP endpoints")
+	}
+
+	if retries := d.Get("max_transient_error_retries").(int); retries > 0 {
+		mysqlConf.TransientRetry = &RetryPolicy{
+			MaxRetries:  retries,
+			Interval:    time.Duration(d.Get("transient_error_retry_interval_ms").(int)) * time.Millisecond,
+			MaxInterval: time.Duration(d.Get("transient_error_retry_max_interval_ms").(int)) * time.Millisecond,
+			Backoff:     d.Get("transient_error_retry_backoff").(bool),
+		}
+	}
+
+	for _, command := range d.Get("init_commands").([]interface{}) {
+		mysqlConf.InitCommands = append(mysqlConf.InitCommands, command.(string))
+	}
+
+	if readEndpoint := d.Get("read_endpoint").(string); readEndpoint != "" {
+		if proto != "tcp" {
+			return nil, diag.Errorf("read_endpoint only works with TCP endpoints")
+		}
+		readConf := *mysqlConf
+		readConf.Config = conf.Clone()
+		readConf.Config.Addr = readEndpoint
+		readConf.FallbackEndpoints = nil
+		readConf.AuroraWriter = false
+		mysqlConf.ReadConfiguration = &readConf
 	}
 
 	return mysqlConf, nil
@@ -396,6 +997,7 @@ func afterConnectVersion(ctx context.Context, mysqlConf *MySQLConfiguration, db
 }
 
 var identQuoteReplacer = strings.NewReplacer("`", "``")
+var literalQuoteReplacer = strings.NewReplacer("'", "''", "\\", "\\\\")
 
 func makeDialer(d *schema.ResourceData) (proxy.Dialer, error) {
 	proxyFromEnv := proxy.FromEnvironment()
@@ -417,10 +1019,35 @@ func makeDialer(d *schema.ResourceData) (proxy.Dialer, error) {
 	return proxyFromEnv, nil
 }
 
+// connectionEncrypted tells whether connections with the network and the tls
+// setting are always encrypted. Cloud SQL connections are, and unix sockets
+// don't leave the host. With preferred, servers without TLS get unencrypted
+// connections.
+func connectionEncrypted(proto string, tlsConfig string) bool {
+	if proto != "tcp" {
+		return true
+	}
+	return tlsConfig != "false" && tlsConfig != "preferred" && tlsConfig != ""
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration like 30s or 1m: %v", k, err))
+	}
+	return
+}
+
+// quoteIdentifier quotes with backticks, which work with ANSI_QUOTES too.
 func quoteIdentifier(in string) string {
 	return fmt.Sprintf("`%s`", identQuoteReplacer.Replace(in))
 }
 
+// quoteLiteral quotes a string literal for statements that can't use
+// placeholders, e.g. because their body may contain question marks.
+func quoteLiteral(in string) string {
+	return fmt.Sprintf("'%s'", literalQuoteReplacer.Replace(in))
+}
+
 func serverVersion(db *sql.DB) (*version.Version, error) {
 	var versionString string
 	err := db.QueryRow("SELECT @@GLOBAL.version").Scan(&versionString)
@@ -429,7 +1056,28 @@ func serverVersion(db *sql.DB) (*version.Version, error) {
 	}
 
 	versionString = strings.SplitN(versionString, ":", 2)[0]
-	return version.NewVersion(versionString)
+	currentVersion, err := version.NewVersion(versionString)
+	if err != nil {
+		return nil, err
+	}
+	// TiDB reports the MySQL version it's compatible with, followed by its
+	// own, e.g. 8.0.11-TiDB-v7.5.0. As a pre-release, it would compare lower
+	// than 8.0.11.
+	if serverFlavorFromVersion(versionString) == flavorTiDB {
+		return currentVersion.Core(), nil
+	}
+	return currentVersion, nil
+}
+
+func serverFlavorFromVersion(versionString string) serverFlavor {
+	switch {
+	case strings.Contains(versionString, "TiDB"):
+		return flavorTiDB
+	case strings.Contains(versionString, "MariaDB"):
+		return flavorMariaDB
+	default:
+		return flavorMySQL
+	}
 }
 
 func serverVersionString(db *sql.DB) (string, error) {
@@ -456,6 +1104,53 @@ func serverRds(db *sql.DB) (bool, error) {
 	return false, nil
 }
 
+// serverAnsiQuotes tells whether the session's sql_mode has ANSI_QUOTES,
+// which the ANSI mode includes as well.
+func serverAnsiQuotes(db *sql.DB) (bool, error) {
+	var sqlMode string
+	if err := db.QueryRow("SELECT @@SESSION.sql_mode").Scan(&sqlMode); err != nil {
+		return false, err
+	}
+	for _, mode := range strings.Split(sqlMode, ",") {
+		if strings.EqualFold(strings.TrimSpace(mode), "ANSI_QUOTES") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getRdsDatabaseFromMeta returns the connection, failing if the server isn't
+// RDS, for features that rely on the RDS stored procedures.
+func getRdsDatabaseFromMeta(ctx context.Context, meta interface{}, feature string) (*sql.DB, error) {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	isRds, err := serverRds(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed detecting RDS: %v", err)
+	}
+	if !isRds {
+		return nil, fmt.Errorf("%s are only supported on RDS", feature)
+	}
+	return db, nil
+}
+
+// getTiDBDatabaseFromMeta returns the connection, failing if the server isn't
+// TiDB, for features that don't exist anywhere else.
+func getTiDBDatabaseFromMeta(ctx context.Context, meta interface{}, feature string) (*sql.DB, error) {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if getFlavorFromMeta(ctx, meta) != flavorTiDB {
+		return nil, fmt.Errorf("%s are only supported on TiDB", feature)
+	}
+	return db, nil
+}
+
 func connectToMySQL(ctx context.Context, conf *MySQLConfiguration) (*sql.DB, error) {
 	conn, err := connectToMySQLInternal(ctx, conf)
 	if err != nil {
@@ -470,10 +1165,15 @@ func connectToMySQLInternal(ctx context.Context, conf *MySQLConfiguration) (*One
 	connectionCacheMtx.Lock()
 	defer connectionCacheMtx.Unlock()
 
-	dsn := conf.Config.FormatDSN()
-	log.Printf("[DEBUG] Using dsn: %s", dsn)
-	if connectionCache[dsn] != nil {
-		return connectionCache[dsn], nil
+	logConfig := conf.Config.Clone()
+	if logConfig.Passwd != "" {
+		logConfig.Passwd = redactedValue
+	}
+	log.Printf("[DEBUG] Using dsn: %s", logConfig.FormatDSN())
+	cacheKey := connectionCacheKey(conf)
+	if connectionCache[cacheKey] != nil {
+		logPoolStats(connectionCache[cacheKey].Db)
+		return connectionCache[cacheKey], nil
 	}
 
 	connection, err := createNewConnection(ctx, conf)
@@ -481,17 +1181,48 @@ func connectToMySQLInternal(ctx context.Context, conf *MySQLConfiguration) (*One
 		return nil, fmt.Errorf("could not create new connection: %v", err)
 	}
 
-	connectionCache[dsn] = connection
-	return connectionCache[dsn], nil
+	connectionCache[cacheKey] = connection
+	return connectionCache[cacheKey], nil
 }
 
-func createNewConnection(ctx context.Context, conf *MySQLConfiguration) (*OneConnection, error) {
-	var db *sql.DB
-	var err error
+// connectionCacheKey tells apart the connections of configurations that
+// differ in more than the DSN: connections with other init commands have other
+// sessions, and the connector options change where connections go and what
+// happens to their statements. Metrics count the statements of their own
+// provider configuration, so they are told apart by identity. Password
+// functions only refresh the password of the DSN, so having one is enough.
+func connectionCacheKey(conf *MySQLConfiguration) string {
+	return fmt.Sprintf("%s\ninit_commands=%q\nfallback_endpoints=%q\naurora_writer=%t\npassword_func=%t\ntransient_retry=%+v\nmetrics=%p\ndry_run=%t\nshow_statements=%t",
+		conf.Config.FormatDSN(), conf.InitCommands, conf.FallbackEndpoints, conf.AuroraWriter, conf.PasswordFunc != nil,
+		conf.TransientRetry, conf.Metrics, conf.DryRun, conf.ShowStatements)
+}
+
+// defaultMaxIdleConns matches the default parallelism of Terraform, so that
+// the connections of parallel resources are reused instead of closed.
+const defaultMaxIdleConns = 10
 
+func maxIdleConns(conf *MySQLConfiguration) int {
+	idle := conf.MaxIdleConns
+	if idle == 0 {
+		idle = defaultMaxIdleConns
+	}
+	if conf.MaxOpenConns > 0 && idle > conf.MaxOpenConns {
+		idle = conf.MaxOpenConns
+	}
+	return idle
+}
+
+func logPoolStats(db *sql.DB) {
+	stats := db.Stats()
+	log.Printf("[DEBUG] Connection pool: %d open, %d in use, %d idle, %d waits for %s, closed %d as idle, %d for idle time, %d for lifetime",
+		stats.OpenConnections, stats.InUse, stats.Idle, stats.WaitCount, stats.WaitDuration,
+		stats.MaxIdleClosed, stats.MaxIdleTimeClosed, stats.MaxLifetimeClosed)
+}
+
+func createNewConnection(ctx context.Context, conf *MySQLConfiguration) (*OneConnection, error) {
 	driverName := "mysql"
-	if conf.Config.Net == "cloudsql" {
-		driverName = "cloudsql"
+	if strings.HasPrefix(conf.Config.Net, "cloudsql") {
+		driverName = conf.Config.Net
 	}
 	log.Printf("[DEBUG] Using driverName: %s", driverName)
 
@@ -499,25 +1230,13 @@ func createNewConnection(ctx context.Context, conf *MySQLConfiguration) (*OneCon
 	// when Terraform thinks it's available and when it is actually available.
 	// This is particularly acute when provisioning a server and then immediately
 	// trying to provision a database on it.
-	retryError := resource.RetryContext(ctx, conf.ConnectRetryTimeoutSec, func() *resource.RetryError {
-		db, err = sql.Open(driverName, conf.Config.FormatDSN())
-		if err != nil {
-			if mysqlErrorNumber(err) != 0 || cloudsqlErrorNumber(err) != 0 || ctx.Err() != nil {
-				return resource.NonRetryableError(err)
-			}
-			return resource.RetryableError(err)
+	policy := conf.ConnectRetry
+	policy.Timeout = conf.ConnectRetryTimeoutSec
+	db, retryError := openWithRetry(ctx, policy, func() (*sql.DB, error) {
+		if conf.PasswordFunc != nil || len(conf.FallbackEndpoints) > 0 || conf.AuroraWriter || len(conf.InitCommands) > 0 || conf.TransientRetry != nil || conf.Metrics != nil || conf.DryRun || conf.ShowStatements {
+			return sql.OpenDB(newFailoverConnector(conf)), nil
 		}
-
-		err = db.PingContext(ctx)
-		if err != nil {
-			if mysqlErrorNumber(err) != 0 || cloudsqlErrorNumber(err) != 0 || ctx.Err() != nil {
-				return resource.NonRetryableError(err)
-			}
-
-			return resource.RetryableError(err)
-		}
-
-		return nil
+		return sql.Open(driverName, conf.Config.FormatDSN())
 	})
 
 	if retryError != nil {
@@ -525,18 +1244,97 @@ func createNewConnection(ctx context.Context, conf *MySQLConfiguration) (*OneCon
 	}
 	db.SetConnMaxLifetime(conf.MaxConnLifetime)
 	db.SetMaxOpenConns(conf.MaxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns(conf))
+	db.SetConnMaxIdleTime(conf.MaxConnIdleTime)
 
 	currentVersion, err := afterConnectVersion(ctx, conf, db)
 	if err != nil {
 		return nil, fmt.Errorf("failed running after connect command: %v", err)
 	}
 
+	ansiQuotes, err := serverAnsiQuotes(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting sql_mode: %v", err)
+	}
+
+	versionString, err := serverVersionString(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting server version: %v", err)
+	}
+
 	return &OneConnection{
-		Db:      db,
-		Version: currentVersion,
+		Db:         db,
+		Version:    currentVersion,
+		Flavor:     serverFlavorFromVersion(versionString),
+		AnsiQuotes: ansiQuotes,
 	}, nil
 }
 
+// openWithRetry opens the database and pings it until it answers, following
+// the policy. Each attempt opens a database of its own, and the ones of
+// failed attempts are closed.
+func openWithRetry(ctx context.Context, policy RetryPolicy, open func() (*sql.DB, error)) (*sql.DB, error) {
+	var db *sql.DB
+	err := policy.Retry(ctx, func() (bool, error) {
+		attempt, err := open()
+		if err != nil {
+			if mysqlErrorNumber(err) != 0 || cloudsqlErrorNumber(err) != 0 || ctx.Err() != nil {
+				return false, err
+			}
+			return true, err
+		}
+
+		if err := attempt.PingContext(ctx); err != nil {
+			attempt.Close()
+			if mysqlErrorNumber(err) != 0 || cloudsqlErrorNumber(err) != 0 || ctx.Err() != nil {
+				return false, err
+			}
+			return true, err
+		}
+
+		db = attempt
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// registerCloudSQLDriver registers the Cloud SQL connector for the options
+// and returns its name, which is both the driver and the network name. Drivers
+// can't be registered twice, so each combination of options gets its own.
+// Access tokens are part of the name, so provider configurations with
+// different tokens don't share the token source of their connector.
+func registerCloudSQLDriver(iamAuth bool, privateIP bool, accessToken string) (string, error) {
+	name := "cloudsql"
+	var opts []cloudsqlconn.Option
+	if iamAuth {
+		name += "-iam"
+		opts = append(opts, cloudsqlconn.WithIAMAuthN())
+		if accessToken != "" {
+			name += "-token-" + hashSum(accessToken)[:16]
+			tokens := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})
+			opts = append(opts, cloudsqlconn.WithIAMAuthNTokenSources(tokens, tokens))
+		}
+	}
+	if privateIP {
+		name += "-private"
+		opts = append(opts, cloudsqlconn.WithDefaultDialOptions(cloudsqlconn.WithPrivateIP()))
+	}
+
+	cloudSQLDriversMtx.Lock()
+	defer cloudSQLDriversMtx.Unlock()
+	if cloudSQLDrivers[name] {
+		return name, nil
+	}
+	if _, err := cloudsql.RegisterDriver(name, opts...); err != nil {
+		return "", err
+	}
+	cloudSQLDrivers[name] = true
+	return name, nil
+}
+
 // 0 == not mysql error or not error at all.
 func mysqlErrorNumber(err error) uint16 {
 	if err == nil {
@@ -2,12 +2,17 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/go-version"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -50,6 +55,21 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestServerFlavorFromVersion(t *testing.T) {
+	for versionString, expected := range map[string]serverFlavor{
+		"8.0.36":                    flavorMySQL,
+		"8.0.36-28":                 flavorMySQL,
+		"10.11.6-MariaDB-1:10.11.6": flavorMariaDB,
+		"5.5.5-10.6.16-MariaDB-log": flavorMariaDB,
+		"5.7.25-TiDB-v7.5.0":        flavorTiDB,
+		"8.0.11-TiDB-v8.1.0":        flavorTiDB,
+	} {
+		if got := serverFlavorFromVersion(versionString); got != expected {
+			t.Errorf("serverFlavorFromVersion(%s) = %s; expected %s", versionString, got, expected)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	ctx := context.Background()
 	for _, name := range []string{"MYSQL_ENDPOINT", "MYSQL_USERNAME"} {
@@ -150,6 +170,27 @@ func testAccPreCheckSkipMariaDB(t *testing.T) {
 	}
 }
 
+func testAccPreCheckSkipNotMariaDB(t *testing.T) {
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+	if err != nil {
+		t.Fatalf("Cannot connect to DB (SkipNotMariaDB): %v", err)
+		return
+	}
+
+	currentVersionString, err := serverVersionString(db)
+	if err != nil {
+		t.Fatalf("Cannot get DB version string (SkipNotMariaDB): %v", err)
+		return
+	}
+
+	if !strings.Contains(currentVersionString, "MariaDB") {
+		t.Skip("Skip on non-MariaDB")
+	}
+}
+
 func testAccPreCheckSkipNotMySQL8(t *testing.T) {
 	testAccPreCheck(t)
 
@@ -215,3 +256,160 @@ func testAccPreCheckSkipNotTiDB(t *testing.T) {
 		t.Skip(msg)
 	}
 }
+
+func TestProviderResourceTimeouts(t *testing.T) {
+	for name, resource := range Provider().ResourcesMap {
+		if resource.Timeouts == nil || resource.Timeouts.Create == nil || resource.Timeouts.Read == nil || resource.Timeouts.Delete == nil {
+			t.Errorf("%s has no create, read and delete timeouts", name)
+		}
+		if (resource.UpdateContext != nil) != (resource.Timeouts.Update != nil) {
+			t.Errorf("%s should have an update timeout only if it can be updated", name)
+		}
+	}
+}
+
+func TestMaxIdleConns(t *testing.T) {
+	for _, tc := range []struct {
+		maxIdle, maxOpen, expected int
+	}{
+		{0, 0, defaultMaxIdleConns},
+		{0, 4, 4},
+		{20, 0, 20},
+		{20, 5, 5},
+	} {
+		conf := &MySQLConfiguration{MaxIdleConns: tc.maxIdle, MaxOpenConns: tc.maxOpen}
+		if idle := maxIdleConns(conf); idle != tc.expected {
+			t.Errorf("maxIdleConns(%d, %d) = %d, expected %d", tc.maxIdle, tc.maxOpen, idle, tc.expected)
+		}
+	}
+}
+
+func TestConnectionCacheKey(t *testing.T) {
+	base := func() *MySQLConfiguration {
+		return &MySQLConfiguration{Config: &mysql.Config{User: "root", Net: "tcp", Addr: "db:3306"}}
+	}
+	if connectionCacheKey(base()) != connectionCacheKey(base()) {
+		t.Errorf("the same configuration got different connection cache keys")
+	}
+
+	metrics := newQueryMetrics("")
+	withMetrics := base()
+	withMetrics.Metrics = metrics
+	sameMetrics := base()
+	sameMetrics.Metrics = metrics
+	if connectionCacheKey(withMetrics) != connectionCacheKey(sameMetrics) {
+		t.Errorf("configurations with the same metrics got different connection cache keys")
+	}
+
+	for name, change := range map[string]func(conf *MySQLConfiguration){
+		"init_commands":      func(conf *MySQLConfiguration) { conf.InitCommands = []string{"SET SESSION sql_mode = ''"} },
+		"fallback_endpoints": func(conf *MySQLConfiguration) { conf.FallbackEndpoints = []string{"replica:3306"} },
+		"aurora_writer":      func(conf *MySQLConfiguration) { conf.AuroraWriter = true },
+		"password_func": func(conf *MySQLConfiguration) {
+			conf.PasswordFunc = func(ctx context.Context) (string, error) { return "", nil }
+		},
+		"transient_retry": func(conf *MySQLConfiguration) { conf.TransientRetry = &RetryPolicy{MaxRetries: 3} },
+		"metrics":         func(conf *MySQLConfiguration) { conf.Metrics = newQueryMetrics("") },
+		"dry_run":         func(conf *MySQLConfiguration) { conf.DryRun = true },
+		"show_statements": func(conf *MySQLConfiguration) { conf.ShowStatements = true },
+	} {
+		conf := base()
+		change(conf)
+		if connectionCacheKey(conf) == connectionCacheKey(base()) {
+			t.Errorf("configurations differing in %s share the connection cache key", name)
+		}
+		if name == "metrics" && connectionCacheKey(conf) == connectionCacheKey(withMetrics) {
+			t.Errorf("configurations with different metrics share the connection cache key")
+		}
+	}
+	retry := base()
+	retry.TransientRetry = &RetryPolicy{MaxRetries: 3}
+	otherRetry := base()
+	otherRetry.TransientRetry = &RetryPolicy{MaxRetries: 5}
+	if connectionCacheKey(retry) == connectionCacheKey(otherRetry) {
+		t.Errorf("configurations with different transient retries share the connection cache key")
+	}
+}
+
+// flakyConnector fails the first connections, as a server that is still
+// starting does.
+type flakyConnector struct {
+	failures int
+	connects int
+}
+
+func (c *flakyConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.connects++
+	if c.connects <= c.failures {
+		return nil, errors.New("connection refused")
+	}
+	return &fakeExecConn{}, nil
+}
+
+func (c *flakyConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestOpenWithRetry(t *testing.T) {
+	connector := &flakyConnector{failures: 1}
+	opens := 0
+	db, err := openWithRetry(context.Background(), RetryPolicy{MaxRetries: 3, Interval: time.Millisecond}, func() (*sql.DB, error) {
+		opens++
+		return sql.OpenDB(connector), nil
+	})
+	if err != nil {
+		t.Fatalf("expected the second attempt to connect, got %v", err)
+	}
+	defer db.Close()
+	if opens != 2 || connector.connects != 2 {
+		t.Errorf("expected 2 attempts, got %d opens and %d connects", opens, connector.connects)
+	}
+
+	connector = &flakyConnector{failures: 10}
+	if _, err := openWithRetry(context.Background(), RetryPolicy{MaxRetries: 2, Interval: time.Millisecond}, func() (*sql.DB, error) {
+		return sql.OpenDB(connector), nil
+	}); err == nil {
+		t.Errorf("expected connecting to fail once the retries are used up")
+	}
+}
+
+func TestRegisterCloudSQLDriverPerToken(t *testing.T) {
+	first, err := registerCloudSQLDriver(true, false, "first-token")
+	if err != nil {
+		t.Fatalf("registering the Cloud SQL driver failed: %v", err)
+	}
+	second, err := registerCloudSQLDriver(true, false, "second-token")
+	if err != nil {
+		t.Fatalf("registering the Cloud SQL driver failed: %v", err)
+	}
+	again, err := registerCloudSQLDriver(true, false, "first-token")
+	if err != nil {
+		t.Fatalf("registering the Cloud SQL driver failed: %v", err)
+	}
+
+	if first == second {
+		t.Errorf("different tokens share the driver %s", first)
+	}
+	if first != again {
+		t.Errorf("the same token got the drivers %s and %s", first, again)
+	}
+}
+
+func TestConnectionEncrypted(t *testing.T) {
+	for _, tc := range []struct {
+		proto, tls string
+		expected   bool
+	}{
+		{"tcp", "false", false},
+		{"tcp", "preferred", false},
+		{"tcp", "true", true},
+		{"tcp", "skip-verify", true},
+		{"tcp", "custom", true},
+		{"unix", "false", true},
+		{"cloudsql-iam", "false", true},
+	} {
+		if encrypted := connectionEncrypted(tc.proto, tc.tls); encrypted != tc.expected {
+			t.Errorf("connectionEncrypted(%q, %q) = %t, expected %t", tc.proto, tc.tls, encrypted, tc.expected)
+		}
+	}
+}
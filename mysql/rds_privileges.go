@@ -0,0 +1,142 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// routinePrivileges make up ALL PRIVILEGES on procedures and functions.
+var routinePrivileges = []string{"ALTER ROUTINE", "EXECUTE"}
+
+// grantableGlobalPrivileges returns the global privileges the connected user
+// may grant. On RDS and Aurora, these lack e.g. SUPER, so granting ALL
+// PRIVILEGES fails.
+func grantableGlobalPrivileges(ctx context.Context, db *sql.DB) ([]string, error) {
+	var currentUser string
+	if err := db.QueryRowContext(ctx, "SELECT CURRENT_USER()").Scan(&currentUser); err != nil {
+		return nil, fmt.Errorf("failed getting current user: %w", err)
+	}
+	grantee := fmt.Sprintf("'%s'", currentUser)
+	if i := strings.LastIndex(currentUser, "@"); i >= 0 {
+		grantee = fmt.Sprintf("'%s'@'%s'", currentUser[:i], currentUser[i+1:])
+	}
+
+	stmtSQL := "SELECT PRIVILEGE_TYPE FROM INFORMATION_SCHEMA.USER_PRIVILEGES WHERE GRANTEE = ? AND IS_GRANTABLE = 'YES'"
+	logStatement(ctx, stmtSQL, grantee)
+	rows, err := db.QueryContext(ctx, stmtSQL, grantee)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading privileges of %s: %w", currentUser, err)
+	}
+	defer rows.Close()
+
+	privileges := []string{}
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, fmt.Errorf("failed reading privileges of %s: %w", currentUser, err)
+		}
+		if privilege != "USAGE" && privilege != "PROXY" {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges, rows.Err()
+}
+
+// rdsAllPrivileges returns what ALL PRIVILEGES stands for on the level of the
+// grant, limited to what the connected user may grant.
+func rdsAllPrivileges(ctx context.Context, db *sql.DB, grant MySQLGrant) ([]string, error) {
+	grantable, err := grantableGlobalPrivileges(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var level []string
+	switch g := grant.(type) {
+	case *ProcedurePrivilegeGrant:
+		level = routinePrivileges
+	case *TablePrivilegeGrant:
+		if g.Database == "*" {
+			return normalizePerms(grantable), nil
+		}
+		level = allDatabasePrivileges
+		if g.Table != "*" && g.Table != "" {
+			level = allTablePrivileges
+		}
+	default:
+		return nil, nil
+	}
+
+	privileges := []string{}
+	for _, privilege := range level {
+		if containsString(grantable, privilege) {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges, nil
+}
+
+// expandAllPrivileges replaces ALL PRIVILEGES with the given privileges.
+func expandAllPrivileges(privileges []string, all []string) []string {
+	if !containsAllPrivilege(privileges) {
+		return privileges
+	}
+	expanded := append([]string{}, all...)
+	for _, privilege := range privileges {
+		if !kReAllPrivileges.MatchString(privilege) {
+			expanded = append(expanded, privilege)
+		}
+	}
+	return normalizePerms(expanded)
+}
+
+// collapseExpandedPrivileges undoes expandAllPrivileges if the privileges
+// include all of the given ones.
+func collapseExpandedPrivileges(privileges []string, all []string) []string {
+	if len(all) == 0 {
+		return privileges
+	}
+	for _, privilege := range all {
+		if !containsString(privileges, privilege) {
+			return privileges
+		}
+	}
+	collapsed := []string{"ALL PRIVILEGES"}
+	for _, privilege := range privileges {
+		if !containsString(all, privilege) {
+			collapsed = append(collapsed, privilege)
+		}
+	}
+	return normalizePerms(collapsed)
+}
+
+// withPrivileges returns a copy of the grant with the given privileges.
+func withPrivileges(grant MySQLGrant, privileges []string) MySQLGrant {
+	switch g := grant.(type) {
+	case *TablePrivilegeGrant:
+		copied := *g
+		copied.Privileges = privileges
+		return &copied
+	case *ProcedurePrivilegeGrant:
+		copied := *g
+		copied.Privileges = privileges
+		return &copied
+	}
+	return grant
+}
+
+// rdsCompatibleGrant expands ALL PRIVILEGES of the grant if the provider is
+// configured with rds_compatible.
+func rdsCompatibleGrant(ctx context.Context, db *sql.DB, meta interface{}, grant MySQLGrant) (MySQLGrant, []string, error) {
+	grantWithPrivileges, ok := grant.(MySQLGrantWithPrivileges)
+	if !meta.(*MySQLConfiguration).RDSCompatible || !ok || !containsAllPrivilege(grantWithPrivileges.GetPrivileges()) {
+		return grant, nil, nil
+	}
+
+	all, err := rdsAllPrivileges(ctx, db, grant)
+	if err != nil {
+		return nil, nil, err
+	}
+	return withPrivileges(grant, expandAllPrivileges(grantWithPrivileges.GetPrivileges(), all)), all, nil
+}
@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandAllPrivileges(t *testing.T) {
+	all := []string{"ALTER", "DELETE", "SELECT"}
+
+	expanded := expandAllPrivileges([]string{"ALL PRIVILEGES", "SELECT(A)"}, all)
+	expected := []string{"ALTER", "DELETE", "SELECT", "SELECT(`A`)"}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Errorf("expected %v, got %v", expected, expanded)
+	}
+	if kept := expandAllPrivileges([]string{"SELECT"}, all); !reflect.DeepEqual(kept, []string{"SELECT"}) {
+		t.Errorf("expected privileges without ALL to be kept, got %v", kept)
+	}
+
+	collapsed := collapseExpandedPrivileges(expanded, all)
+	expected = []string{"ALL PRIVILEGES", "SELECT(`A`)"}
+	if !reflect.DeepEqual(collapsed, expected) {
+		t.Errorf("expected %v, got %v", expected, collapsed)
+	}
+	if kept := collapseExpandedPrivileges([]string{"DELETE", "SELECT"}, all); !reflect.DeepEqual(kept, []string{"DELETE", "SELECT"}) {
+		t.Errorf("expected partial privileges to be kept, got %v", kept)
+	}
+}
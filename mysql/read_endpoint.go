@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type primaryReadsKey struct{}
+
+// setPrimaryReads makes the reads done by Create, Update and Delete of the
+// resource go to the primary, so they see the changes just made even if the
+// replica of read_endpoint lags behind.
+func setPrimaryReads(resource *schema.Resource) {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return f(context.WithValue(ctx, primaryReadsKey{}, true), d, meta)
+		}
+	}
+
+	resource.CreateContext = wrap(resource.CreateContext)
+	resource.UpdateContext = wrap(resource.UpdateContext)
+	resource.DeleteContext = wrap(resource.DeleteContext)
+}
+
+// getReadDatabaseFromMeta returns the connection to read_endpoint for
+// refreshes and data sources, or the primary one if it isn't set.
+func getReadDatabaseFromMeta(ctx context.Context, meta interface{}) (*sql.DB, error) {
+	readConf := meta.(*MySQLConfiguration).ReadConfiguration
+	if readConf == nil || ctx.Value(primaryReadsKey{}) != nil {
+		return getDatabaseFromMeta(ctx, meta)
+	}
+
+	oneConnection, err := connectToMySQLInternal(ctx, readConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to read endpoint: %v", err)
+	}
+	return oneConnection.Db, nil
+}
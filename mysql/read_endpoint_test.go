@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSetPrimaryReads(t *testing.T) {
+	primary := map[string]bool{}
+	record := func(name string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			primary[name] = ctx.Value(primaryReadsKey{}) != nil
+			return nil
+		}
+	}
+	resource := &schema.Resource{
+		CreateContext: record("create"),
+		ReadContext:   record("read"),
+		UpdateContext: record("update"),
+		DeleteContext: record("delete"),
+	}
+	setPrimaryReads(resource)
+
+	ctx := context.Background()
+	resource.CreateContext(ctx, nil, nil)
+	resource.ReadContext(ctx, nil, nil)
+	resource.UpdateContext(ctx, nil, nil)
+	resource.DeleteContext(ctx, nil, nil)
+
+	expected := map[string]bool{"create": true, "read": false, "update": true, "delete": true}
+	for name, expectedPrimary := range expected {
+		if primary[name] != expectedPrimary {
+			t.Errorf("%s: expected primary reads %v, got %v", name, expectedPrimary, primary[name])
+		}
+	}
+
+	readOnly := &schema.Resource{ReadContext: record("read")}
+	setPrimaryReads(readOnly)
+	if readOnly.CreateContext != nil || readOnly.UpdateContext != nil || readOnly.DeleteContext != nil {
+		t.Errorf("expected missing operations to stay unset")
+	}
+}
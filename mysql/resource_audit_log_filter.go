@@ -0,0 +1,234 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAuditLogFilter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdateAuditLogFilter,
+		UpdateContext: CreateOrUpdateAuditLogFilter,
+		ReadContext:   ReadAuditLogFilter,
+		DeleteContext: DeleteAuditLogFilter,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"definition": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func CreateOrUpdateAuditLogFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Setting an existing filter replaces it and re-attaches its sessions.
+	name := d.Get("name").(string)
+	err = callAuditLogFunction(ctx, db, "SELECT audit_log_filter_set_filter(?, ?)", name, d.Get("definition").(string))
+	if err != nil {
+		return diag.Errorf("failed setting audit log filter: %v", err)
+	}
+
+	d.SetId(name)
+
+	return ReadAuditLogFilter(ctx, d, meta)
+}
+
+func ReadAuditLogFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT FILTER FROM mysql.audit_log_filter WHERE NAME = ?"
+	logStatement(ctx, stmtSQL)
+
+	var definition string
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&definition)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Audit log filter (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading audit log filter: %v", err)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("definition", definition)
+
+	return nil
+}
+
+func DeleteAuditLogFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = callAuditLogFunction(ctx, db, "SELECT audit_log_filter_remove_filter(?)", d.Id())
+	if err != nil {
+		return diag.Errorf("failed removing audit log filter: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceAuditLogUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdateAuditLogUser,
+		UpdateContext: CreateOrUpdateAuditLogUser,
+		ReadContext:   ReadAuditLogUser,
+		DeleteContext: DeleteAuditLogUser,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportAuditLogUser,
+		},
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "%",
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+// auditLogAccount returns the account name as expected by the audit log
+// functions. User "%" is the default account, which has no host.
+func auditLogAccount(user string, host string) string {
+	if user == "%" {
+		return "%"
+	}
+	return fmt.Sprintf("%s@%s", user, host)
+}
+
+func CreateOrUpdateAuditLogUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	account := auditLogAccount(d.Get("user").(string), d.Get("host").(string))
+	err = callAuditLogFunction(ctx, db, "SELECT audit_log_filter_set_user(?, ?)", account, d.Get("filter").(string))
+	if err != nil {
+		return diag.Errorf("failed assigning audit log filter: %v", err)
+	}
+
+	d.SetId(account)
+
+	return ReadAuditLogUser(ctx, d, meta)
+}
+
+func ReadAuditLogUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	user := d.Get("user").(string)
+	host := d.Get("host").(string)
+	if user == "%" {
+		host = ""
+	}
+
+	stmtSQL := "SELECT FILTERNAME FROM mysql.audit_log_user WHERE USER = ? AND HOST = ?"
+	logStatement(ctx, stmtSQL)
+
+	var filter string
+	err = db.QueryRowContext(ctx, stmtSQL, user, host).Scan(&filter)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Audit log filter assignment (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading audit log filter assignment: %v", err)
+	}
+
+	d.Set("filter", filter)
+
+	return nil
+}
+
+func DeleteAuditLogUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = callAuditLogFunction(ctx, db, "SELECT audit_log_filter_remove_user(?)", d.Id())
+	if err != nil {
+		return diag.Errorf("failed removing audit log filter assignment: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportAuditLogUser(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	account := parseRoleSpec(d.Id())
+	if account.Name == "%" {
+		d.Set("user", "%")
+		d.Set("host", "%")
+	} else {
+		if account.Host == "" {
+			account.Host = "%"
+		}
+		d.Set("user", account.Name)
+		d.Set("host", account.Host)
+	}
+	d.SetId(auditLogAccount(d.Get("user").(string), d.Get("host").(string)))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// callAuditLogFunction runs one of the audit log filter UDFs. They don't fail
+// the statement, but return "OK" or an error message. The calls are SELECTs, so
+// they are recorded, and skipped in dry run, here.
+func callAuditLogFunction(ctx context.Context, db *sql.DB, stmtSQL string, args ...interface{}) error {
+	logStatement(ctx, stmtSQL)
+	if recordStatement(ctx, stmtSQL, args...) {
+		return nil
+	}
+
+	var result sql.NullString
+	if err := db.QueryRowContext(ctx, stmtSQL, args...).Scan(&result); err != nil {
+		return err
+	}
+	if result.String != "OK" {
+		return fmt.Errorf("%s", result.String)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAuditLogFilter_basic(t *testing.T) {
+	filterName := "tf_test_filter"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipNoAuditLogFilter(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccAuditLogFilterCheckDestroy(filterName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuditLogFilterConfig(filterName, "connection"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_audit_log_filter.test", "name", filterName),
+					resource.TestCheckResourceAttr("mysql_audit_log_user.test", "filter", filterName),
+				),
+			},
+			{
+				Config: testAccAuditLogFilterConfig(filterName, "table_access"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_audit_log_filter.test", "definition", `{"filter": {"class": {"name": "table_access"}}}`),
+				),
+			},
+			{
+				ResourceName:      "mysql_audit_log_filter.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "mysql_audit_log_user.test",
+				ImportState:       true,
+				ImportStateId:     "jdoe@example.com",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPreCheckSkipNoAuditLogFilter(t *testing.T) {
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+	if err != nil {
+		t.Fatalf("Cannot connect to DB (SkipNoAuditLogFilter): %v", err)
+		return
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM mysql.func WHERE name = 'audit_log_filter_set_filter'").Scan(&count)
+	if err != nil || count == 0 {
+		t.Skip("Skip without audit log filter functions")
+	}
+}
+
+func testAccAuditLogFilterCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM mysql.audit_log_filter WHERE NAME = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading audit log filters: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("audit log filter %s still exists after destroy", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccAuditLogFilterConfig(name string, class string) string {
+	return fmt.Sprintf(`
+resource "mysql_user" "test" {
+  user = "jdoe"
+  host = "example.com"
+}
+
+resource "mysql_audit_log_filter" "test" {
+  name       = "%s"
+  definition = jsonencode({ filter = { class = { name = "%s" } } })
+}
+
+resource "mysql_audit_log_user" "test" {
+  user   = mysql_user.test.user
+  host   = mysql_user.test.host
+  filter = mysql_audit_log_filter.test.name
+}
+`, name, class)
+}
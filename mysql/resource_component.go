@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceComponent() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateComponent,
+		ReadContext:   ReadComponent,
+		DeleteContext: DeleteComponent,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"urn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func CreateComponent(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return diag.Errorf("components require MySQL 8.0 or newer")
+	}
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "components"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	urn := d.Get("urn").(string)
+	stmtSQL := "INSTALL COMPONENT ?"
+	logStatement(ctx, stmtSQL, urn)
+
+	if _, err := db.ExecContext(ctx, stmtSQL, urn); err != nil {
+		return diag.Errorf("failed installing component: %v", err)
+	}
+
+	d.SetId(urn)
+
+	return ReadComponent(ctx, d, meta)
+}
+
+func ReadComponent(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT component_urn FROM mysql.component WHERE component_urn = ?"
+	logStatement(ctx, stmtSQL)
+
+	var urn string
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&urn)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Component (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading component: %v", err)
+	}
+
+	d.Set("urn", urn)
+
+	return nil
+}
+
+func DeleteComponent(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "UNINSTALL COMPONENT ?"
+	logStatement(ctx, stmtSQL, d.Id())
+
+	if _, err := db.ExecContext(ctx, stmtSQL, d.Id()); err != nil {
+		return diag.Errorf("failed uninstalling component: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccComponent_basic(t *testing.T) {
+	urn := "file://component_validate_password"
+	resourceName := "mysql_component.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccComponentCheckDestroy(urn),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComponentConfig(urn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "urn", urn),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccComponentCheckDestroy(urn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM mysql.component WHERE component_urn = ?", urn).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading components: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("component %s still installed after destroy", urn)
+		}
+
+		return nil
+	}
+}
+
+func testAccComponentConfig(urn string) string {
+	return fmt.Sprintf(`
+resource "mysql_component" "test" {
+  urn = "%s"
+}
+`, urn)
+}
@@ -26,23 +26,50 @@ func resourceDatabase() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: ImportDatabase,
 		},
+		CustomizeDiff: validateDatabaseCharsetCollation,
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
 			},
 
 			"default_character_set": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "utf8mb4",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "utf8mb4",
+				DiffSuppressFunc: charsetAliasSuppressFunc,
 			},
 
 			"default_collation": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "utf8mb4_general_ci",
+				DiffSuppressFunc: collationAliasSuppressFunc,
+			},
+
+			"encryption": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			"placement_policy": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "utf8mb4_general_ci",
+			},
+
+			"skip_drop": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 		},
 	}
@@ -55,7 +82,16 @@ func CreateDatabase(ctx context.Context, d *schema.ResourceData, meta interface{
 	}
 
 	stmtSQL := databaseConfigSQL("CREATE", d)
-	log.Println("Executing statement:", stmtSQL)
+	if !d.GetRawConfig().GetAttr("encryption").IsNull() {
+		hasEncryption, err := supportsDatabaseEncryption(ctx, db, meta)
+		if err != nil {
+			return diag.Errorf("failed getting encryption support: %v", err)
+		}
+		if !hasEncryption {
+			return diag.Errorf("encryption requires MySQL 8.0.16 or newer")
+		}
+	}
+	logStatement(ctx, stmtSQL)
 
 	_, err = db.ExecContext(ctx, stmtSQL)
 	if err != nil {
@@ -64,6 +100,12 @@ func CreateDatabase(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	d.SetId(d.Get("name").(string))
 
+	if policy := d.Get("placement_policy").(string); policy != "" {
+		if err := setDatabasePlacementPolicy(ctx, db, meta, d.Id(), policy); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return ReadDatabase(ctx, d, meta)
 }
 
@@ -74,13 +116,19 @@ func UpdateDatabase(ctx context.Context, d *schema.ResourceData, meta interface{
 	}
 
 	stmtSQL := databaseConfigSQL("ALTER", d)
-	log.Println("Executing statement:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 
 	_, err = db.ExecContext(ctx, stmtSQL)
 	if err != nil {
 		return diag.Errorf("failed updating DB: %v", err)
 	}
 
+	if d.HasChange("placement_policy") {
+		if err := setDatabasePlacementPolicy(ctx, db, meta, d.Id(), d.Get("placement_policy").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return ReadDatabase(ctx, d, meta)
 }
 
@@ -98,7 +146,7 @@ func ReadDatabase(ctx context.Context, d *schema.ResourceData, meta interface{})
 	name := d.Id()
 	stmtSQL := "SHOW CREATE DATABASE " + quoteIdentifier(name)
 
-	log.Println("Executing query:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 	var createSQL, _database string
 	err = db.QueryRowContext(ctx, stmtSQL).Scan(&_database, &createSQL)
 	if err != nil {
@@ -123,17 +171,12 @@ func ReadDatabase(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 		requiredVersion, _ := version.NewVersion("8.0.0")
 
-		serverVersionString, err := serverVersionString(db)
-		if err != nil {
-			return diag.Errorf("could not get error version string: %v", err)
-		}
-
 		// MySQL 8 returns more data in a row.
 		var res error
-		if !strings.Contains(serverVersionString, "MariaDB") && getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) {
-			res = db.QueryRow(stmtSQL, defaultCharset).Scan(&defaultCollation, &empty, &empty, &empty, &empty, &empty, &empty)
+		if getFlavorFromMeta(ctx, meta) != flavorMariaDB && getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) {
+			res = db.QueryRowContext(ctx, stmtSQL, defaultCharset).Scan(&defaultCollation, &empty, &empty, &empty, &empty, &empty, &empty)
 		} else {
-			res = db.QueryRow(stmtSQL, defaultCharset).Scan(&defaultCollation, &empty, &empty, &empty, &empty, &empty)
+			res = db.QueryRowContext(ctx, stmtSQL, defaultCharset).Scan(&defaultCollation, &empty, &empty, &empty, &empty, &empty)
 		}
 
 		if res != nil {
@@ -149,9 +192,62 @@ func ReadDatabase(ctx context.Context, d *schema.ResourceData, meta interface{})
 	d.Set("default_character_set", defaultCharset)
 	d.Set("default_collation", defaultCollation)
 
+	hasEncryption, err := supportsDatabaseEncryption(ctx, db, meta)
+	if err != nil {
+		return diag.Errorf("failed getting encryption support: %v", err)
+	}
+	if hasEncryption {
+		var defaultEncryption string
+		err = db.QueryRowContext(ctx, "SELECT DEFAULT_ENCRYPTION FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME = ?", name).Scan(&defaultEncryption)
+		if err != nil {
+			return diag.Errorf("failed reading database encryption: %v", err)
+		}
+		d.Set("encryption", defaultEncryption == "YES")
+	}
+
+	if getFlavorFromMeta(ctx, meta) == flavorTiDB {
+		var policy sql.NullString
+		err = db.QueryRowContext(ctx, "SELECT TIDB_PLACEMENT_POLICY_NAME FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME = ?", name).Scan(&policy)
+		if err != nil {
+			return diag.Errorf("failed reading database placement policy: %v", err)
+		}
+		d.Set("placement_policy", policy.String)
+	}
+
 	return nil
 }
 
+// setDatabasePlacementPolicy attaches a TiDB placement policy to the database.
+// An empty policy resets it to the default.
+func setDatabasePlacementPolicy(ctx context.Context, db *sql.DB, meta interface{}, name string, policy string) error {
+	if getFlavorFromMeta(ctx, meta) != flavorTiDB {
+		return fmt.Errorf("placement_policy is only supported on TiDB")
+	}
+
+	policyClause := "DEFAULT"
+	if policy != "" {
+		policyClause = quoteIdentifier(policy)
+	}
+	stmtSQL := fmt.Sprintf("ALTER DATABASE %s PLACEMENT POLICY = %s", quoteIdentifier(name), policyClause)
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return fmt.Errorf("failed setting placement policy: %v", err)
+	}
+	return nil
+}
+
+// supportsDatabaseEncryption returns whether the server supports schema
+// default encryption, which was added in MySQL 8.0.16.
+func supportsDatabaseEncryption(ctx context.Context, db *sql.DB, meta interface{}) (bool, error) {
+	if getFlavorFromMeta(ctx, meta) != flavorMySQL {
+		return false, nil
+	}
+
+	requiredVersion, _ := version.NewVersion("8.0.16")
+	return getVersionFromMeta(ctx, meta).GreaterThanOrEqual(requiredVersion), nil
+}
+
 func DeleteDatabase(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	db, err := getDatabaseFromMeta(ctx, meta)
 	if err != nil {
@@ -159,8 +255,26 @@ func DeleteDatabase(ctx context.Context, d *schema.ResourceData, meta interface{
 	}
 
 	name := d.Id()
+
+	if d.Get("skip_drop").(bool) {
+		log.Printf("[WARN] skip_drop is set, leaving database %s in place and removing it from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	if d.Get("deletion_protection").(bool) {
+		var tableCount int
+		err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ?", name).Scan(&tableCount)
+		if err != nil {
+			return diag.Errorf("failed counting tables in DB: %v", err)
+		}
+		if tableCount > 0 {
+			return diag.Errorf("refusing to drop database %s: deletion_protection is set and it still contains %d tables", name, tableCount)
+		}
+	}
+
 	stmtSQL := "DROP DATABASE " + quoteIdentifier(name)
-	log.Println("Executing statement:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 
 	_, err = db.ExecContext(ctx, stmtSQL)
 	if err != nil {
@@ -178,6 +292,7 @@ func databaseConfigSQL(verb string, d *schema.ResourceData) string {
 
 	var defaultCharsetClause string
 	var defaultCollationClause string
+	var encryptionClause string
 
 	if defaultCharset != "" {
 		defaultCharsetClause = defaultCharacterSetKeyword + quoteIdentifier(defaultCharset)
@@ -185,16 +300,113 @@ func databaseConfigSQL(verb string, d *schema.ResourceData) string {
 	if defaultCollation != "" {
 		defaultCollationClause = defaultCollateKeyword + quoteIdentifier(defaultCollation)
 	}
+	// Only emit ENCRYPTION when configured, so servers without support for it
+	// keep working.
+	if !d.GetRawConfig().GetAttr("encryption").IsNull() {
+		if d.Get("encryption").(bool) {
+			encryptionClause = "ENCRYPTION 'Y'"
+		} else {
+			encryptionClause = "ENCRYPTION 'N'"
+		}
+	}
 
 	return fmt.Sprintf(
-		"%s DATABASE %s %s %s",
+		"%s DATABASE %s %s %s %s",
 		verb,
 		quoteIdentifier(name),
 		defaultCharsetClause,
 		defaultCollationClause,
+		encryptionClause,
 	)
 }
 
+// normalizeCharset maps character set aliases to their canonical name.
+// MySQL 8.0.30+ reports utf8 as utf8mb3, older servers the other way round.
+func normalizeCharset(charset string) string {
+	charset = strings.ToLower(charset)
+	if charset == "utf8" {
+		return "utf8mb3"
+	}
+	return charset
+}
+
+func normalizeCollation(collation string) string {
+	collation = strings.ToLower(collation)
+	if strings.HasPrefix(collation, "utf8_") {
+		return "utf8mb3_" + strings.TrimPrefix(collation, "utf8_")
+	}
+	return collation
+}
+
+func charsetAliasSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeCharset(old) == normalizeCharset(new)
+}
+
+func collationAliasSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeCollation(old) == normalizeCollation(new)
+}
+
+// validateDatabaseCharsetCollation checks the configured charset and collation
+// against the server during plan, so typos don't surface only on apply.
+func validateDatabaseCharsetCollation(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("default_character_set") && !d.HasChange("default_collation") {
+		return nil
+	}
+	if !d.NewValueKnown("default_character_set") || !d.NewValueKnown("default_collation") {
+		return nil
+	}
+
+	charset := normalizeCharset(d.Get("default_character_set").(string))
+	collation := normalizeCollation(d.Get("default_collation").(string))
+	if charset == "" && collation == "" {
+		return nil
+	}
+
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return err
+	}
+
+	stmtSQL := "SELECT COLLATION_NAME, CHARACTER_SET_NAME FROM INFORMATION_SCHEMA.COLLATIONS"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		// Validation is best-effort; apply will report real problems.
+		log.Printf("[WARN] Could not read collations, skipping validation: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	collationCharsets := map[string]string{}
+	knownCharsets := map[string]bool{}
+	for rows.Next() {
+		var collationName, charsetName string
+		if err := rows.Scan(&collationName, &charsetName); err != nil {
+			return fmt.Errorf("failed scanning collations: %v", err)
+		}
+		collationCharsets[normalizeCollation(collationName)] = normalizeCharset(charsetName)
+		knownCharsets[normalizeCharset(charsetName)] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed reading collations: %v", err)
+	}
+
+	if charset != "" && !knownCharsets[charset] {
+		return fmt.Errorf("character set %s is not supported by the server", d.Get("default_character_set"))
+	}
+	if collation != "" {
+		collationCharset, ok := collationCharsets[collation]
+		if !ok {
+			return fmt.Errorf("collation %s is not supported by the server", d.Get("default_collation"))
+		}
+		if charset != "" && collationCharset != charset {
+			return fmt.Errorf("collation %s is not valid for character set %s", d.Get("default_collation"), d.Get("default_character_set"))
+		}
+	}
+
+	return nil
+}
+
 func extractIdentAfter(sql string, keyword string) string {
 	charsetIndex := strings.Index(sql, keyword)
 	if charsetIndex != -1 {
@@ -213,5 +425,8 @@ func ImportDatabase(ctx context.Context, d *schema.ResourceData, meta interface{
 		return nil, fmt.Errorf("error while importing: %v", err)
 	}
 
+	d.Set("skip_drop", false)
+	d.Set("deletion_protection", false)
+
 	return []*schema.ResourceData{d}, nil
 }
@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -81,6 +82,155 @@ func TestAccDatabase_collationChange(t *testing.T) {
 	})
 }
 
+func TestAccDatabase_invalidCollation(t *testing.T) {
+	dbName := "terraform_acceptance_test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDatabaseCheckDestroy(dbName),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDatabaseConfig_full(dbName, "utf8mb4", "latin1_bin"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("is not valid for character set"),
+			},
+			{
+				Config:      testAccDatabaseConfig_full(dbName, "utf8mb4", "utf8mb4_does_not_exist"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("is not supported by the server"),
+			},
+		},
+	})
+}
+
+func TestAccDatabase_encryption(t *testing.T) {
+	dbName := "terraform_acceptance_test"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "8.0.16")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDatabaseCheckDestroy(dbName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseConfig_encryption(dbName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDatabaseCheck_basic("mysql_database.test", dbName),
+					resource.TestCheckResourceAttr("mysql_database.test", "encryption", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabase_encryptionEnabled(t *testing.T) {
+	dbName := "terraform_acceptance_test"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "8.0.16")
+			testAccPreCheckSkipNoKeyring(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDatabaseCheckDestroy(dbName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseConfig_encryption(dbName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDatabaseCheck_basic("mysql_database.test", dbName),
+					resource.TestCheckResourceAttr("mysql_database.test", "encryption", "true"),
+				),
+			},
+			{
+				Config: testAccDatabaseConfig_encryption(dbName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDatabaseCheck_basic("mysql_database.test", dbName),
+					resource.TestCheckResourceAttr("mysql_database.test", "encryption", "false"),
+				),
+			},
+			{
+				Config: testAccDatabaseConfig_encryption(dbName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDatabaseCheck_basic("mysql_database.test", dbName),
+					resource.TestCheckResourceAttr("mysql_database.test", "encryption", "true"),
+				),
+			},
+			{
+				ResourceName:      "mysql_database.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Encrypting a database needs an active keyring plugin or component.
+func testAccPreCheckSkipNoKeyring(t *testing.T) {
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+	if err != nil {
+		t.Fatalf("Cannot connect to DB (SkipNoKeyring): %v", err)
+		return
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM performance_schema.keyring_component_status WHERE STATUS_KEY = 'Component_status' AND STATUS_VALUE = 'Active'").Scan(&count)
+	if err == nil && count > 0 {
+		return
+	}
+	err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.PLUGINS WHERE PLUGIN_NAME LIKE 'keyring%' AND PLUGIN_STATUS = 'ACTIVE'").Scan(&count)
+	if err != nil || count == 0 {
+		t.Skip("Skip without an active keyring")
+	}
+}
+
+func TestAccDatabase_deletionProtection(t *testing.T) {
+	dbName := "terraform_acceptance_test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDatabaseCheckDestroy(dbName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseConfig_deletionProtection(dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDatabaseCheck_basic("mysql_database.test", dbName),
+					prepareTable(dbName, "protected"),
+				),
+			},
+			{
+				Config:      testAccDatabaseConfig_deletionProtection(dbName),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion_protection is set"),
+			},
+			{
+				Config: testAccDatabaseConfig_deletionProtection(dbName),
+				Check: resource.ComposeTestCheckFunc(
+					dropTable(dbName, "protected"),
+				),
+			},
+		},
+	})
+}
+
+func dropTable(dbname string, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(fmt.Sprintf("DROP TABLE %s.%s", quoteIdentifier(dbname), quoteIdentifier(tableName)))
+		return err
+	}
+}
+
 func testAccDatabaseCheck_basic(rn string, name string) resource.TestCheckFunc {
 	return testAccDatabaseCheck_full(rn, name, "utf8mb4", "utf8mb4_bin")
 }
@@ -155,3 +305,23 @@ resource "mysql_database" "test" {
     default_collation = "%s"
 }`, name, charset, collation)
 }
+
+func testAccDatabaseConfig_encryption(name string, encryption bool) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+    name = "%s"
+    default_character_set = "utf8mb4"
+    default_collation = "utf8mb4_bin"
+    encryption = %t
+}`, name, encryption)
+}
+
+func testAccDatabaseConfig_deletionProtection(name string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+    name = "%s"
+    default_character_set = "utf8mb4"
+    default_collation = "utf8mb4_bin"
+    deletion_protection = true
+}`, name)
+}
@@ -0,0 +1,305 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceFirewallGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateFirewallGroup,
+		UpdateContext: UpdateFirewallGroup,
+		ReadContext:   ReadFirewallGroup,
+		DeleteContext: DeleteFirewallGroup,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "OFF",
+				ValidateFunc: validation.StringInSlice([]string{"OFF", "RECORDING", "PROTECTING", "DETECTING"}, false),
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func CreateFirewallGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	if err := setFirewallGroupMode(ctx, db, name, d.Get("mode").(string)); err != nil {
+		return diag.Errorf("failed setting firewall group mode: %v", err)
+	}
+	d.SetId(name)
+
+	if err := changeFirewallGroupMembers(ctx, db, name, setToArray(d.Get("members")), nil); err != nil {
+		return diag.Errorf("failed enlisting firewall group members: %v", err)
+	}
+
+	return ReadFirewallGroup(ctx, d, meta)
+}
+
+func UpdateFirewallGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("mode") {
+		if err := setFirewallGroupMode(ctx, db, d.Id(), d.Get("mode").(string)); err != nil {
+			return diag.Errorf("failed setting firewall group mode: %v", err)
+		}
+	}
+
+	if d.HasChange("members") {
+		oldMembersIf, newMembersIf := d.GetChange("members")
+		oldMembers := oldMembersIf.(*schema.Set)
+		newMembers := newMembersIf.(*schema.Set)
+
+		toAdd := setToArray(newMembers.Difference(oldMembers))
+		toRemove := setToArray(oldMembers.Difference(newMembers))
+		if err := changeFirewallGroupMembers(ctx, db, d.Id(), toAdd, toRemove); err != nil {
+			return diag.Errorf("failed updating firewall group members: %v", err)
+		}
+	}
+
+	return ReadFirewallGroup(ctx, d, meta)
+}
+
+func ReadFirewallGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT MODE FROM performance_schema.firewall_groups WHERE NAME = ?"
+	logStatement(ctx, stmtSQL)
+
+	var mode string
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&mode)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Firewall group (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading firewall group: %v", err)
+	}
+
+	stmtSQL = "SELECT MEMBER_ID FROM performance_schema.firewall_membership WHERE GROUP_ID = ?"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL, d.Id())
+	if err != nil {
+		return diag.Errorf("failed reading firewall group members: %v", err)
+	}
+	defer rows.Close()
+
+	members := []string{}
+	for rows.Next() {
+		var member string
+		if err := rows.Scan(&member); err != nil {
+			return diag.Errorf("failed scanning firewall group members: %v", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading firewall group members: %v", err)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("mode", mode)
+	d.Set("members", members)
+
+	return nil
+}
+
+// DeleteFirewallGroup delists all members and resets the group, which also
+// removes its allowlist. The firewall has no way to remove groups entirely.
+func DeleteFirewallGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := changeFirewallGroupMembers(ctx, db, d.Id(), nil, setToArray(d.Get("members"))); err != nil {
+		return diag.Errorf("failed delisting firewall group members: %v", err)
+	}
+	if err := setFirewallGroupMode(ctx, db, d.Id(), "RESET"); err != nil {
+		return diag.Errorf("failed resetting firewall group: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func setFirewallGroupMode(ctx context.Context, db *sql.DB, group string, mode string) error {
+	stmtSQL := "CALL mysql.sp_set_firewall_group_mode(?, ?)"
+	logStatement(ctx, stmtSQL, group, mode)
+
+	_, err := db.ExecContext(ctx, stmtSQL, group, mode)
+	return err
+}
+
+func changeFirewallGroupMembers(ctx context.Context, db *sql.DB, group string, toAdd []string, toRemove []string) error {
+	for _, member := range toRemove {
+		stmtSQL := "CALL mysql.sp_firewall_group_delist(?, ?)"
+		logStatement(ctx, stmtSQL, group, member)
+		if _, err := db.ExecContext(ctx, stmtSQL, group, member); err != nil {
+			return err
+		}
+	}
+	for _, member := range toAdd {
+		stmtSQL := "CALL mysql.sp_firewall_group_enlist(?, ?)"
+		logStatement(ctx, stmtSQL, group, member)
+		if _, err := db.ExecContext(ctx, stmtSQL, group, member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceFirewallGroupAllowlist() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdateFirewallGroupAllowlist,
+		UpdateContext: CreateOrUpdateFirewallGroupAllowlist,
+		ReadContext:   ReadFirewallGroupAllowlist,
+		DeleteContext: DeleteFirewallGroupAllowlist,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportFirewallGroupAllowlist,
+		},
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rules": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+// CreateOrUpdateFirewallGroupAllowlist replaces the stored rules of the group
+// and reloads them into the firewall cache.
+func CreateOrUpdateFirewallGroupAllowlist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group := d.Get("group").(string)
+	if err := writeFirewallGroupRules(ctx, db, group, setToArray(d.Get("rules"))); err != nil {
+		return diag.Errorf("failed writing firewall group allowlist: %v", err)
+	}
+
+	d.SetId(group)
+
+	return ReadFirewallGroupAllowlist(ctx, d, meta)
+}
+
+func ReadFirewallGroupAllowlist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT RULE FROM performance_schema.firewall_group_allowlist WHERE NAME = ?"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL, d.Id())
+	if err != nil {
+		return diag.Errorf("failed reading firewall group allowlist: %v", err)
+	}
+	defer rows.Close()
+
+	rules := []string{}
+	for rows.Next() {
+		var rule string
+		if err := rows.Scan(&rule); err != nil {
+			return diag.Errorf("failed scanning firewall group allowlist: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading firewall group allowlist: %v", err)
+	}
+
+	d.Set("group", d.Id())
+	d.Set("rules", rules)
+
+	return nil
+}
+
+func DeleteFirewallGroupAllowlist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := writeFirewallGroupRules(ctx, db, d.Id(), nil); err != nil {
+		return diag.Errorf("failed clearing firewall group allowlist: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportFirewallGroupAllowlist(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("group", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func writeFirewallGroupRules(ctx context.Context, db *sql.DB, group string, rules []string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtSQL := "DELETE FROM mysql.firewall_group_allowlist WHERE NAME = ?"
+	logStatement(ctx, stmtSQL)
+	if _, err := tx.ExecContext(ctx, stmtSQL, group); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		stmtSQL := "INSERT INTO mysql.firewall_group_allowlist (NAME, RULE) VALUES (?, ?)"
+		logStatement(ctx, stmtSQL)
+		if _, err := tx.ExecContext(ctx, stmtSQL, group, rule); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// The firewall works on its in-memory cache, so the rules need reloading.
+	stmtSQL = "CALL mysql.sp_reload_firewall_group_rules(?)"
+	logStatement(ctx, stmtSQL, group)
+	_, err = db.ExecContext(ctx, stmtSQL, group)
+	return err
+}
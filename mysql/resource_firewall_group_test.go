@@ -0,0 +1,104 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccFirewallGroup_basic(t *testing.T) {
+	groupName := "tf_test_group"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipNoFirewall(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFirewallGroupCheckDestroy(groupName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFirewallGroupConfig(groupName, "DETECTING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_firewall_group.test", "mode", "DETECTING"),
+					resource.TestCheckResourceAttr("mysql_firewall_group.test", "members.#", "1"),
+					resource.TestCheckResourceAttr("mysql_firewall_group_allowlist.test", "rules.#", "1"),
+				),
+			},
+			{
+				Config: testAccFirewallGroupConfig(groupName, "PROTECTING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_firewall_group.test", "mode", "PROTECTING"),
+				),
+			},
+			{
+				ResourceName:      "mysql_firewall_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "mysql_firewall_group_allowlist.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPreCheckSkipNoFirewall(t *testing.T) {
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+	if err != nil {
+		t.Fatalf("Cannot connect to DB (SkipNoFirewall): %v", err)
+		return
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.PLUGINS WHERE PLUGIN_NAME = 'MYSQL_FIREWALL' AND PLUGIN_STATUS = 'ACTIVE'").Scan(&count)
+	if err != nil || count == 0 {
+		t.Skip("Skip without MySQL Enterprise Firewall")
+	}
+}
+
+func testAccFirewallGroupCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM performance_schema.firewall_group_allowlist WHERE NAME = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading firewall allowlist: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("firewall group %s still has rules after destroy", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccFirewallGroupConfig(name string, mode string) string {
+	return fmt.Sprintf(`
+resource "mysql_user" "test" {
+  user = "jdoe"
+  host = "localhost"
+}
+
+resource "mysql_firewall_group" "test" {
+  name    = "%s"
+  mode    = "%s"
+  members = ["${mysql_user.test.user}@${mysql_user.test.host}"]
+}
+
+resource "mysql_firewall_group_allowlist" "test" {
+  group = mysql_firewall_group.test.name
+  rules = ["SELECT @@version_comment LIMIT ? "]
+}
+`, name, mode)
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// flushOptions are the FLUSH options that are safe to run unattended. Options
+// taking locks, like TABLES WITH READ LOCK, would be released as soon as the
+// connection returns to the pool, so they're left out.
+var flushOptions = []string{
+	"BINARY LOGS",
+	"ENGINE LOGS",
+	"ERROR LOGS",
+	"GENERAL LOGS",
+	"HOSTS",
+	"LOGS",
+	"OPTIMIZER_COSTS",
+	"PRIVILEGES",
+	"RELAY LOGS",
+	"SLOW LOGS",
+	"STATUS",
+	"TABLES",
+	"USER_RESOURCES",
+}
+
+func resourceFlush() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateFlush,
+		ReadContext:   ReadFlush,
+		DeleteContext: DeleteFlush,
+		Schema: map[string]*schema.Schema{
+			"options": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(flushOptions, false),
+				},
+			},
+			"local": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func CreateFlush(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := []string{}
+	for _, option := range d.Get("options").([]interface{}) {
+		options = append(options, option.(string))
+	}
+
+	// MySQL 8.0.23 deprecated FLUSH HOSTS in favor of truncating the host
+	// cache.
+	requiredVersion, _ := version.NewVersion("8.0.23")
+	hostCache := getFlavorFromMeta(ctx, meta) == flavorMySQL && !getVersionFromMeta(ctx, meta).LessThan(requiredVersion)
+
+	// Each option runs on its own, so an error tells which one failed.
+	for _, stmtSQL := range flushStatements(options, d.Get("local").(bool), hostCache) {
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return diag.Errorf("failed running %s: %v", stmtSQL, err)
+		}
+	}
+
+	d.SetId(strings.ToLower(strings.Join(options, ",")))
+
+	return ReadFlush(ctx, d, meta)
+}
+
+// ReadFlush doesn't read anything, as flushing leaves nothing to read.
+func ReadFlush(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// DeleteFlush only removes the resource from the state, as flushes can't be
+// undone.
+func DeleteFlush(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// flushStatements returns the statements running the options. Local flushes
+// aren't written to the binary log, so replicas don't repeat them. Truncating
+// the host cache is never written to it.
+func flushStatements(options []string, local, hostCache bool) []string {
+	statements := make([]string, 0, len(options))
+	for _, option := range options {
+		switch {
+		case option == "HOSTS" && hostCache:
+			statements = append(statements, "TRUNCATE TABLE performance_schema.host_cache")
+		case local:
+			statements = append(statements, "FLUSH NO_WRITE_TO_BINLOG "+option)
+		default:
+			statements = append(statements, "FLUSH "+option)
+		}
+	}
+	return statements
+}
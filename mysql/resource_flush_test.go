@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFlush_basic(t *testing.T) {
+	resourceName := "mysql_flush.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFlushConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "privileges,status"),
+					resource.TestCheckResourceAttr(resourceName, "triggers.run", "1"),
+				),
+			},
+			{
+				Config: testAccFlushConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.run", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlushStatements(t *testing.T) {
+	tests := []struct {
+		options   []string
+		local     bool
+		hostCache bool
+		want      []string
+	}{
+		{[]string{"PRIVILEGES"}, false, false, []string{"FLUSH PRIVILEGES"}},
+		{[]string{"BINARY LOGS", "STATUS"}, true, false, []string{"FLUSH NO_WRITE_TO_BINLOG BINARY LOGS", "FLUSH NO_WRITE_TO_BINLOG STATUS"}},
+		{[]string{"HOSTS"}, false, false, []string{"FLUSH HOSTS"}},
+		{[]string{"HOSTS"}, true, true, []string{"TRUNCATE TABLE performance_schema.host_cache"}},
+	}
+
+	for _, tt := range tests {
+		if got := flushStatements(tt.options, tt.local, tt.hostCache); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("flushStatements(%v, %v, %v) = %v, want %v", tt.options, tt.local, tt.hostCache, got, tt.want)
+		}
+	}
+}
+
+func testAccFlushConfig(run string) string {
+	return fmt.Sprintf(`
+resource "mysql_flush" "test" {
+  options = ["PRIVILEGES", "STATUS"]
+  local   = true
+
+  triggers = {
+    run = "%s"
+  }
+}
+`, run)
+}
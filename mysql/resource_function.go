@@ -0,0 +1,200 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const kRoutineFunction = "FUNCTION"
+
+func resourceFunction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateFunction,
+		UpdateContext: UpdateFunction,
+		ReadContext:   ReadFunction,
+		DeleteContext: DeleteFunction,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportFunction,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"parameters": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: whitespaceSuppressFunc,
+			},
+			"returns": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: returnTypeSuppressFunc,
+			},
+			"deterministic": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"sql_data_access": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "CONTAINS SQL",
+				ValidateFunc: validation.StringInSlice([]string{
+					"CONTAINS SQL", "NO SQL", "READS SQL DATA", "MODIFIES SQL DATA",
+				}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: trimSpaceSuppressFunc,
+			},
+			"definer": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: definerSuppressFunc,
+			},
+			"sql_security": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DEFINER",
+				ValidateFunc: validation.StringInSlice([]string{"DEFINER", "INVOKER"}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func CreateFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createRoutine(ctx, db, kRoutineFunction, d, functionCharacteristicsSQL(d)); err != nil {
+		return diag.Errorf("failed creating function: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", d.Get("database").(string), d.Get("name").(string)))
+
+	return ReadFunction(ctx, d, meta)
+}
+
+func UpdateFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// DETERMINISTIC can't be changed with ALTER FUNCTION.
+	if d.HasChanges("parameters", "returns", "deterministic", "body", "definer") {
+		if err := replaceRoutine(ctx, db, kRoutineFunction, d, functionCharacteristicsSQL(d)); err != nil {
+			return diag.Errorf("failed replacing function: %v", err)
+		}
+	} else if err := alterRoutine(ctx, db, kRoutineFunction, d, strings.ToUpper(d.Get("sql_data_access").(string))); err != nil {
+		return diag.Errorf("failed updating function: %v", err)
+	}
+
+	return ReadFunction(ctx, d, meta)
+}
+
+func ReadFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	routine, err := readRoutine(ctx, db, kRoutineFunction, d.Get("database").(string), d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("failed reading function: %v", err)
+	}
+	if routine == nil {
+		log.Printf("[WARN] Function (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("parameters", routine.Parameters)
+	d.Set("returns", routine.Returns)
+	d.Set("deterministic", routine.Deterministic)
+	d.Set("sql_data_access", routine.DataAccess)
+	d.Set("body", routine.Body)
+	d.Set("definer", routine.Definer)
+	d.Set("sql_security", routine.SQLSecurity)
+	d.Set("comment", routine.Comment)
+
+	return nil
+}
+
+func DeleteFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := dropRoutine(ctx, db, kRoutineFunction, d.Get("database").(string), d.Get("name").(string)); err != nil {
+		return diag.Errorf("failed dropping function: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := importRoutineId(d); err != nil {
+		return nil, err
+	}
+
+	id := d.Id()
+	diags := ReadFunction(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading function: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("function %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func functionCharacteristicsSQL(d *schema.ResourceData) string {
+	deterministic := "NOT DETERMINISTIC"
+	if d.Get("deterministic").(bool) {
+		deterministic = "DETERMINISTIC"
+	}
+	return fmt.Sprintf("RETURNS %s %s %s",
+		d.Get("returns").(string),
+		deterministic,
+		strings.ToUpper(d.Get("sql_data_access").(string)))
+}
+
+// returnTypeSuppressFunc ignores the character set MySQL adds to string
+// return types, which normalizeColumnType drops like the other differences
+// of spelling.
+func returnTypeSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeColumnType(old) == normalizeColumnType(new)
+}
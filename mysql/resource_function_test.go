@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFunction_basic(t *testing.T) {
+	dbName := "tf_test_function"
+	resourceName := "mysql_function.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipTiDB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRoutineCheckDestroy(dbName, "plus_one", "FUNCTION"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionConfig(dbName, "", "a + 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRoutineExists(dbName, "plus_one", "FUNCTION"),
+					resource.TestCheckResourceAttr(resourceName, "parameters", "a INT"),
+					resource.TestCheckResourceAttr(resourceName, "deterministic", "true"),
+					resource.TestCheckResourceAttr(resourceName, "sql_data_access", "NO SQL"),
+				),
+			},
+			{
+				Config: testAccFunctionConfig(dbName, "adds one", "a + 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "comment", "adds one"),
+				),
+			},
+			{
+				Config: testAccFunctionConfig(dbName, "adds one", "a + 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRoutineExists(dbName, "plus_one", "FUNCTION"),
+					resource.TestCheckResourceAttr(resourceName, "body", "RETURN a + 2;"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s.plus_one", dbName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFunctionConfig(dbName string, comment string, expression string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_function" "test" {
+  database        = mysql_database.test.name
+  name            = "plus_one"
+  parameters      = "a INT"
+  returns         = "INT"
+  deterministic   = true
+  sql_data_access = "NO SQL"
+  comment         = "%s"
+  body            = "RETURN %s;"
+}
+`, dbName, comment, expression)
+}
@@ -28,16 +28,9 @@ func resourceGlobalVariable() *schema.Resource {
 				ForceNew: true,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: func(val any, key string) (warns []string, errs []error) {
-					value := val.(string)
-					match, _ := regexp.MatchString("(^`(.*)`$|')", value)
-					if match {
-						errs = append(errs, fmt.Errorf("%q is badly formatted. %q can't contain any ' string or `<value>`, got: %s", key, key, value))
-					}
-					return
-				},
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVariableValue,
 			},
 		},
 	}
@@ -53,16 +46,9 @@ func CreateOrUpdateGlobalVariable(ctx context.Context, d *schema.ResourceData, m
 	name := d.Get("name").(string)
 	value := d.Get("value").(string)
 
-	sqlBaseQuery := fmt.Sprintf("SET GLOBAL %s = ", quoteIdentifier(name))
-
-	// Detect number or string
-	if _, err := strconv.ParseFloat(value, 64); err == nil {
-		sql = fmt.Sprintf("%s%s", sqlBaseQuery, value)
-	} else {
-		sql = fmt.Sprintf("%s'%s'", sqlBaseQuery, value)
-	}
+	sql = fmt.Sprintf("SET GLOBAL %s = %s", quoteIdentifier(name), variableValueSQL(value))
 
-	log.Printf("[DEBUG] SQL: %s", sql)
+	logStatement(ctx, sql)
 
 	_, err = db.ExecContext(ctx, sql)
 	if err != nil {
@@ -80,13 +66,13 @@ func ReadGlobalVariable(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 
-	stmt, err := db.Prepare("SHOW GLOBAL VARIABLES WHERE VARIABLE_NAME = ?")
+	stmt, err := db.PrepareContext(ctx, "SHOW GLOBAL VARIABLES WHERE VARIABLE_NAME = ?")
 	if err != nil {
 		return diag.Errorf("error during prepare statement for global variable: %s", err)
 	}
 
 	var name, value string
-	err = stmt.QueryRow(d.Id()).Scan(&name, &value)
+	err = stmt.QueryRowContext(ctx, d.Id()).Scan(&name, &value)
 
 	if err != nil && err != sql.ErrNoRows {
 		d.SetId("")
@@ -107,7 +93,7 @@ func DeleteGlobalVariable(ctx context.Context, d *schema.ResourceData, meta inte
 	name := d.Get("name").(string)
 
 	sql := fmt.Sprintf("SET GLOBAL %s = DEFAULT", quoteIdentifier(name))
-	log.Printf("[DEBUG] SQL: %s", sql)
+	logStatement(ctx, sql)
 
 	_, err = db.ExecContext(ctx, sql)
 	if err != nil {
@@ -118,3 +104,21 @@ func DeleteGlobalVariable(ctx context.Context, d *schema.ResourceData, meta inte
 
 	return nil
 }
+
+func validateVariableValue(val any, key string) (warns []string, errs []error) {
+	value := val.(string)
+	match, _ := regexp.MatchString("(^`(.*)`$|')", value)
+	if match {
+		errs = append(errs, fmt.Errorf("%q is badly formatted. %q can't contain any ' string or `<value>`, got: %s", key, key, value))
+	}
+	return
+}
+
+// variableValueSQL formats values for SET statements: numbers as is, anything
+// else as a string.
+func variableValueSQL(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return quoteLiteral(value)
+}
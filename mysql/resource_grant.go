@@ -28,6 +28,17 @@ var (
 
 var grantCreateMutex = NewKeyedMutex()
 
+// lockGrantCreate waits for the other grants of the grantee being created,
+// and returns the function to release the lock. Grants in dry run aren't
+// created, so they don't wait.
+func lockGrantCreate(ctx context.Context, userOrRole UserOrRole) func() {
+	if inDryRun(ctx) {
+		return func() {}
+	}
+	grantCreateMutex.Lock(userOrRole.IDString())
+	return func() { grantCreateMutex.Unlock(userOrRole.IDString()) }
+}
+
 type MySQLGrant interface {
 	GetId() string
 	SQLGrantStatement() string
@@ -84,6 +95,15 @@ func grantsConflict(grantA MySQLGrant, grantB MySQLGrant) bool {
 		}
 	}
 
+	procedureA, aOk := grantA.(*ProcedurePrivilegeGrant)
+	procedureB, bOk := grantB.(*ProcedurePrivilegeGrant)
+	if aOk && bOk {
+		if !strings.EqualFold(string(procedureA.ObjectT), string(procedureB.ObjectT)) ||
+			procedureA.GetCallableName() != procedureB.GetCallableName() {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -91,6 +111,10 @@ type PrivilegesPartiallyRevocable interface {
 	SQLPartialRevokePrivilegesStatement(privilegesToRevoke []string) string
 }
 
+type PrivilegesPartiallyGrantable interface {
+	SQLPartialGrantPrivilegesStatement(privilegesToGrant []string) string
+}
+
 type UserOrRole struct {
 	Name string
 	Host string
@@ -103,11 +127,29 @@ func (u UserOrRole) IDString() string {
 	return fmt.Sprintf("%s@%s", u.Name, u.Host)
 }
 
+// grantIDString returns the account of grant IDs, which bracket IPv6 hosts as
+// they separate the account and object with colons.
+func (u UserOrRole) grantIDString() string {
+	return UserOrRole{Name: u.Name, Host: bracketIPv6Host(u.Host)}.IDString()
+}
+
+// mariaDBPublic is the pseudo-role of MariaDB 10.11 that holds the grants of
+// all accounts.
+const mariaDBPublic = "PUBLIC"
+
+func (u UserOrRole) IsPublic() bool {
+	return u.Host == "" && strings.EqualFold(u.Name, mariaDBPublic)
+}
+
 func (u UserOrRole) SQLString() string {
+	// PUBLIC is a keyword; quoted it would be a role of that name.
+	if u.IsPublic() {
+		return mariaDBPublic
+	}
 	if u.Host == "" {
-		return fmt.Sprintf("'%s'", u.Name)
+		return quoteLiteral(u.Name)
 	}
-	return fmt.Sprintf("'%s'@'%s'", u.Name, u.Host)
+	return quoteLiteral(u.Name) + "@" + quoteLiteral(u.Host)
 }
 
 func (u UserOrRole) Equals(other UserOrRole) bool {
@@ -130,7 +172,7 @@ type TablePrivilegeGrant struct {
 }
 
 func (t *TablePrivilegeGrant) GetId() string {
-	return fmt.Sprintf("%s:%s:%s", t.UserOrRole.IDString(), t.GetDatabase(), t.GetTable())
+	return fmt.Sprintf("%s:%s:%s", t.UserOrRole.grantIDString(), t.GetDatabase(), t.GetTable())
 }
 
 func (t *TablePrivilegeGrant) GetUserOrRole() UserOrRole {
@@ -145,7 +187,7 @@ func (t *TablePrivilegeGrant) GetDatabase() string {
 	if t.Database == "*" {
 		return "*"
 	} else {
-		return fmt.Sprintf("`%s`", t.Database)
+		return quoteIdentifier(t.Database)
 	}
 }
 
@@ -153,7 +195,7 @@ func (t *TablePrivilegeGrant) GetTable() string {
 	if t.Table == "*" || t.Table == "" {
 		return "*"
 	} else {
-		return fmt.Sprintf("`%s`", t.Table)
+		return quoteIdentifier(t.Table)
 	}
 }
 
@@ -165,8 +207,22 @@ func (t *TablePrivilegeGrant) AppendPrivileges(privs []string) {
 	t.Privileges = append(t.Privileges, privs...)
 }
 
+// isUsage tells if the grant has no privileges, i.e. it only requires TLS.
+func (t *TablePrivilegeGrant) isUsage() bool {
+	return len(t.Privileges) == 0
+}
+
+func isUsageGrant(grant MySQLGrant) bool {
+	tableGrant, ok := grant.(*TablePrivilegeGrant)
+	return ok && tableGrant.isUsage()
+}
+
 func (t *TablePrivilegeGrant) SQLGrantStatement() string {
-	stmtSql := fmt.Sprintf("GRANT %s ON %s.%s TO %s", strings.Join(t.Privileges, ", "), t.GetDatabase(), t.GetTable(), t.UserOrRole.SQLString())
+	privileges := strings.Join(t.Privileges, ", ")
+	if t.isUsage() {
+		privileges = "USAGE"
+	}
+	stmtSql := fmt.Sprintf("GRANT %s ON %s.%s TO %s", privileges, t.GetDatabase(), t.GetTable(), t.UserOrRole.SQLString())
 	if t.TLSOption != "" && strings.ToLower(t.TLSOption) != "none" {
 		stmtSql += fmt.Sprintf(" REQUIRE %s", t.TLSOption)
 	}
@@ -176,6 +232,12 @@ func (t *TablePrivilegeGrant) SQLGrantStatement() string {
 	return stmtSql
 }
 
+func (t *TablePrivilegeGrant) SQLPartialGrantPrivilegesStatement(privilegesToGrant []string) string {
+	partial := *t
+	partial.Privileges = privilegesToGrant
+	return partial.SQLGrantStatement()
+}
+
 // containsAllPrivilege returns true if the privileges list contains an ALL PRIVILEGES grant
 // this is used because there is special case behavior for ALL PRIVILEGES grants. In particular,
 // if a user has ALL PRIVILEGES, we _cannot_ revoke ALL PRIVILEGES, GRANT OPTION because this is
@@ -191,6 +253,11 @@ func containsAllPrivilege(privileges []string) bool {
 }
 
 func (t *TablePrivilegeGrant) SQLRevokeStatement() string {
+	// Revoking USAGE does nothing, so REQUIRE-only grants lift the
+	// requirement instead.
+	if t.isUsage() {
+		return fmt.Sprintf("GRANT USAGE ON %s.%s TO %s REQUIRE NONE", t.GetDatabase(), t.GetTable(), t.UserOrRole.SQLString())
+	}
 	privs := t.Privileges
 	if t.Grant && !containsAllPrivilege(privs) {
 		privs = append(privs, "GRANT OPTION")
@@ -216,7 +283,7 @@ type ProcedurePrivilegeGrant struct {
 }
 
 func (t *ProcedurePrivilegeGrant) GetId() string {
-	return fmt.Sprintf("%s:%s:%s", t.UserOrRole.IDString(), t.GetDatabase(), t.GetCallableName())
+	return fmt.Sprintf("%s:%s:%s", t.UserOrRole.grantIDString(), t.GetDatabase(), t.GetCallableName())
 }
 
 func (t *ProcedurePrivilegeGrant) GetUserOrRole() UserOrRole {
@@ -229,13 +296,13 @@ func (t *ProcedurePrivilegeGrant) GrantOption() bool {
 
 func (t *ProcedurePrivilegeGrant) GetDatabase() string {
 	if strings.Compare(t.Database, "*") != 0 && !strings.HasSuffix(t.Database, "`") {
-		return fmt.Sprintf("`%s`", t.Database)
+		return quoteIdentifier(t.Database)
 	}
 	return t.Database
 }
 
 func (t *ProcedurePrivilegeGrant) GetCallableName() string {
-	return fmt.Sprintf("`%s`", t.CallableName)
+	return quoteIdentifier(t.CallableName)
 }
 
 func (t *ProcedurePrivilegeGrant) GetPrivileges() []string {
@@ -257,6 +324,12 @@ func (t *ProcedurePrivilegeGrant) SQLGrantStatement() string {
 	return stmtSql
 }
 
+func (t *ProcedurePrivilegeGrant) SQLPartialGrantPrivilegesStatement(privilegesToGrant []string) string {
+	partial := *t
+	partial.Privileges = privilegesToGrant
+	return partial.SQLGrantStatement()
+}
+
 func (t *ProcedurePrivilegeGrant) SQLRevokeStatement() string {
 	privs := t.Privileges
 	if t.Grant && !containsAllPrivilege(privs) {
@@ -294,7 +367,7 @@ func (t *RoleGrant) GrantOption() bool {
 }
 
 func (t *RoleGrant) SQLGrantStatement() string {
-	stmtSql := fmt.Sprintf("GRANT %s TO %s", strings.Join(t.Roles, ", "), t.UserOrRole.SQLString())
+	stmtSql := fmt.Sprintf("GRANT %s TO %s", rolesSQLList(t.Roles), t.UserOrRole.SQLString())
 	if t.TLSOption != "" && strings.ToLower(t.TLSOption) != "none" {
 		stmtSql += fmt.Sprintf(" REQUIRE %s", t.TLSOption)
 	}
@@ -305,7 +378,7 @@ func (t *RoleGrant) SQLGrantStatement() string {
 }
 
 func (t *RoleGrant) SQLRevokeStatement() string {
-	return fmt.Sprintf("REVOKE %s FROM %s", strings.Join(t.Roles, ", "), t.UserOrRole.SQLString())
+	return fmt.Sprintf("REVOKE %s FROM %s", rolesSQLList(t.Roles), t.UserOrRole.SQLString())
 }
 
 func (t *RoleGrant) GetRoles() []string {
@@ -332,6 +405,7 @@ func resourceGrant() *schema.Resource {
 				Optional:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"role"},
+				ValidateFunc:  validateUserName,
 			},
 
 			"role": {
@@ -339,6 +413,7 @@ func resourceGrant() *schema.Resource {
 				Optional:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"user", "host"},
+				ValidateFunc:  validateUserName,
 			},
 
 			"host": {
@@ -347,6 +422,7 @@ func resourceGrant() *schema.Resource {
 				ForceNew:      true,
 				Default:       "localhost",
 				ConflictsWith: []string{"role"},
+				ValidateFunc:  validateHostPattern,
 			},
 
 			"database": {
@@ -369,6 +445,13 @@ func resourceGrant() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"ignore_privileges": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"roles": {
 				Type:          schema.TypeSet,
 				Optional:      true,
@@ -386,11 +469,13 @@ func resourceGrant() *schema.Resource {
 			},
 
 			"tls_option": {
-				Type:       schema.TypeString,
-				Optional:   true,
-				ForceNew:   true,
-				Deprecated: "Please use tls_option in mysql_user.",
-				Default:    "NONE",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Deprecated:       "Please use tls_option in mysql_user.",
+				Default:          "NONE",
+				DiffSuppressFunc: tlsOptionSuppressFunc,
+				ValidateFunc:     validateTLSOption,
 			},
 		},
 	}
@@ -399,13 +484,73 @@ func resourceGrant() *schema.Resource {
 func supportsRoles(ctx context.Context, meta interface{}) (bool, error) {
 	currentVersion := getVersionFromMeta(ctx, meta)
 
+	switch getFlavorFromMeta(ctx, meta) {
+	case flavorMariaDB:
+		requiredVersion, _ := version.NewVersion("10.0.5")
+		return !currentVersion.LessThan(requiredVersion), nil
+	case flavorTiDB:
+		// TiDB has had roles since 3.0, while still claiming MySQL 5.7.
+		return true, nil
+	}
+
 	requiredVersion, _ := version.NewVersion("8.0.0")
 	hasRoles := currentVersion.GreaterThan(requiredVersion)
 	return hasRoles, nil
 }
 
-var kReProcedureWithoutDatabase = regexp.MustCompile(`(?i)^(function|procedure) ([^.]*)$`)
-var kReProcedureWithDatabase = regexp.MustCompile(`(?i)^(function|procedure) ([^.]*)\.([^.]*)$`)
+// checkPublicGrantSupport fails for grants to PUBLIC, unless the server is
+// MariaDB 10.11 or newer.
+func checkPublicGrantSupport(ctx context.Context, meta interface{}, grant MySQLGrant) error {
+	if !grant.GetUserOrRole().IsPublic() {
+		return nil
+	}
+
+	requiredVersion, _ := version.NewVersion("10.11.0")
+	if getFlavorFromMeta(ctx, meta) != flavorMariaDB || getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return fmt.Errorf("grants to PUBLIC require MariaDB 10.11 or newer")
+	}
+	return nil
+}
+
+// checkUsageGrant checks grants without privileges, which only set the TLS
+// requirement of the account with GRANT USAGE ON *.* ... REQUIRE. MySQL 8
+// sets it with ALTER USER only, which mysql_user does.
+func checkUsageGrant(ctx context.Context, meta interface{}, grant MySQLGrant) error {
+	tableGrant, ok := grant.(*TablePrivilegeGrant)
+	if !ok || !tableGrant.isUsage() {
+		return nil
+	}
+	if tableGrant.Database != "*" || tableGrant.GetTable() != "*" || normalizeTLSOption(tableGrant.TLSOption) == "NONE" {
+		return fmt.Errorf("grants without privileges and roles must be on *.* and set tls_option")
+	}
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	if getFlavorFromMeta(ctx, meta) == flavorMySQL && !getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return fmt.Errorf("grants without privileges are not supported by MySQL 8.0 and newer; set tls_option of mysql_user instead")
+	}
+	return nil
+}
+
+var kReRoutineDatabase = regexp.MustCompile(`(?i)^(function|procedure) (.*)$`)
+
+// parseRoutineDatabase parses database attributes naming routines, e.g.
+// PROCEDURE db.proc, or PROCEDURE db with the routine given as the table.
+// Databases with dots are quoted with backticks, e.g. PROCEDURE `my.db`.proc.
+// It returns nil for databases that don't name routines.
+func parseRoutineDatabase(database string) (*ProcedurePrivilegeGrant, error) {
+	m := kReRoutineDatabase.FindStringSubmatch(database)
+	if m == nil {
+		return nil, nil
+	}
+	db, routine, err := splitQualifiedName(m[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse routine %s: %w", database, err)
+	}
+	return &ProcedurePrivilegeGrant{
+		Database:     db,
+		ObjectT:      ObjectT(m[1]),
+		CallableName: routine,
+	}, nil
+}
 
 func parseResourceFromData(d *schema.ResourceData) (MySQLGrant, diag.Diagnostics) {
 
@@ -444,33 +589,19 @@ func parseResourceFromData(d *schema.ResourceData) (MySQLGrant, diag.Diagnostics
 	}
 
 	// Step 3b. If the database is a procedure or function, we have a procedure grant
-	if kReProcedureWithDatabase.MatchString(database) || kReProcedureWithoutDatabase.MatchString(database) {
-		var callableType ObjectT
-		var callableName string
-		if kReProcedureWithDatabase.MatchString(database) {
-			matches := kReProcedureWithDatabase.FindStringSubmatch(database)
-			callableType = ObjectT(matches[1])
-			database = matches[2]
-			callableName = matches[3]
-		} else {
-			matches := kReProcedureWithoutDatabase.FindStringSubmatch(database)
-			callableType = ObjectT(matches[1])
-			database = matches[2]
-			callableName = d.Get("table").(string)
+	routineGrant, err := parseRoutineDatabase(database)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if routineGrant != nil {
+		if routineGrant.CallableName == "" {
+			routineGrant.CallableName = d.Get("table").(string)
 		}
-
-		privsList := setToArray(d.Get("privileges"))
-		privileges := normalizePerms(privsList)
-
-		return &ProcedurePrivilegeGrant{
-			Database:     database,
-			ObjectT:      callableType,
-			CallableName: callableName,
-			Privileges:   privileges,
-			Grant:        grantOption,
-			UserOrRole:   userOrRole,
-			TLSOption:    tlsOption,
-		}, nil
+		routineGrant.Privileges = normalizePerms(setToArray(d.Get("privileges")))
+		routineGrant.Grant = grantOption
+		routineGrant.UserOrRole = userOrRole
+		routineGrant.TLSOption = tlsOption
+		return routineGrant, nil
 	}
 
 	// Step 3c. Otherwise, we have a table grant
@@ -507,35 +638,68 @@ func CreateGrant(ctx context.Context, d *schema.ResourceData, meta interface{})
 	if _, ok := grant.(*RoleGrant); ok && !hasRolesSupport {
 		return diag.Errorf("role grants are not supported by this version of MySQL")
 	}
+	if err := checkPublicGrantSupport(ctx, meta, grant); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkUsageGrant(ctx, meta, grant); err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer lockPrivilegeChanges(ctx, meta)()
 
 	// Acquire a lock for the user
 	// This is necessary so that the conflicting grant check is correct with respect to other grants being created
-	grantCreateMutex.Lock(grant.GetUserOrRole().IDString())
-	defer grantCreateMutex.Unlock(grant.GetUserOrRole().IDString())
+	defer lockGrantCreate(ctx, grant.GetUserOrRole())()
+
+	unlock, err := lockGrantee(ctx, db, meta, grant.GetUserOrRole())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	// Check to see if there are existing roles that might be clobbered by this grant
-	conflictingGrant, err := getMatchingGrant(ctx, db, grant)
+	conflictingGrant, err := getMatchingGrant(ctx, db, getGrantsCacheFromMeta(meta), grant, getAnsiQuotesFromMeta(ctx, meta))
 	if err != nil {
 		return diag.Errorf("failed showing grants: %v", err)
 	}
-	if conflictingGrant != nil {
+	// Every account has a global grant, which REQUIRE-only grants amend.
+	// Grants holding nothing but ignored privileges, e.g. the ones the
+	// platform added, are amended too.
+	if grantWithPrivileges, ok := conflictingGrant.(MySQLGrantWithPrivileges); ok {
+		if len(withoutIgnoredPrivileges(grantWithPrivileges.GetPrivileges(), d)) == 0 {
+			conflictingGrant = nil
+		}
+	}
+	if conflictingGrant != nil && !isUsageGrant(grant) {
 		return diag.Errorf("user/role %s already has grant %v - ", grant.GetUserOrRole(), conflictingGrant)
 	}
 
-	stmtSQL := grant.SQLGrantStatement()
+	sqlGrant, _, err := rdsCompatibleGrant(ctx, db, meta, grant)
+	if err != nil {
+		return diag.Errorf("failed expanding ALL PRIVILEGES: %v", err)
+	}
+	stmtSQL := sqlGrant.SQLGrantStatement()
 
-	log.Println("Executing statement:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 	_, err = db.ExecContext(ctx, stmtSQL)
+	invalidateGrants(ctx, meta, grant.GetUserOrRole())
 	if err != nil {
 		return diag.Errorf("Error running SQL (%s): %s", stmtSQL, err)
 	}
 
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
 	d.SetId(grant.GetId())
+	if meta.(*MySQLConfiguration).SkipGrantReadAfterCreate {
+		return nil
+	}
 	return ReadGrant(ctx, d, meta)
 }
 
 func ReadGrant(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getReadDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return diag.Errorf("failed getting database from Meta: %v", err)
 	}
@@ -545,9 +709,19 @@ func ReadGrant(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 		return diagErr
 	}
 
-	grantFromDb, err := getMatchingGrant(ctx, db, grantFromTf)
-	if err != nil {
-		return diag.Errorf("ReadGrant - getting all grants failed: %v", err)
+	var grantFromDb MySQLGrant
+	found := false
+	if tableGrant, ok := grantFromTf.(*TablePrivilegeGrant); ok && meta.(*MySQLConfiguration).InformationSchemaGrants && tableGrant.Database != "*" {
+		grantFromDb, found, err = getMatchingGrantFromInformationSchema(ctx, db, getGrantsCacheFromMeta(meta), tableGrant)
+		if err != nil {
+			return diag.Errorf("ReadGrant - reading privileges from INFORMATION_SCHEMA failed: %v", err)
+		}
+	}
+	if !found {
+		grantFromDb, err = getMatchingGrant(ctx, db, getGrantsCacheFromMeta(meta), grantFromTf, getAnsiQuotesFromMeta(ctx, meta))
+		if err != nil {
+			return diag.Errorf("ReadGrant - getting all grants failed: %v", err)
+		}
 	}
 	if grantFromDb == nil {
 		log.Printf("[WARN] GRANT not found for %s - removing from state", grantFromTf.GetUserOrRole())
@@ -555,8 +729,32 @@ func ReadGrant(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 		return nil
 	}
 
+	if _, all, err := rdsCompatibleGrant(ctx, db, meta, grantFromTf); err != nil {
+		return diag.Errorf("failed expanding ALL PRIVILEGES: %v", err)
+	} else if grantWithPrivileges, ok := grantFromDb.(MySQLGrantWithPrivileges); ok && all != nil {
+		grantFromDb = withPrivileges(grantFromDb, collapseExpandedPrivileges(grantWithPrivileges.GetPrivileges(), all))
+	}
+	if grantWithPrivileges, ok := grantFromDb.(MySQLGrantWithPrivileges); ok {
+		grantFromDb = withPrivileges(grantFromDb, withoutIgnoredPrivileges(grantWithPrivileges.GetPrivileges(), d))
+	}
+
+	// The TLS requirement belongs to the account, and only the grants that
+	// set it compare it, so mysql_user can manage it for the others. MySQL 8
+	// can't set it with GRANT anymore.
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	managesTLS := normalizeTLSOption(d.Get("tls_option").(string)) != "NONE" &&
+		(getFlavorFromMeta(ctx, meta) != flavorMySQL || getVersionFromMeta(ctx, meta).LessThan(requiredVersion))
+
 	setDataFromGrant(grantFromDb, d)
 
+	if userOrRole := grantFromDb.GetUserOrRole(); managesTLS && userOrRole.Host != "" {
+		tlsOption, err := readAccountTLSOption(ctx, db, meta, userOrRole)
+		if err != nil {
+			return diag.Errorf("failed reading TLS requirement of %s: %v", userOrRole.IDString(), err)
+		}
+		d.Set("tls_option", tlsOption)
+	}
+
 	return nil
 }
 
@@ -566,6 +764,8 @@ func UpdateGrant(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
+	defer lockPrivilegeChanges(ctx, meta)()
+
 	if err != nil {
 		return diag.Errorf("failed getting user or role: %v", err)
 	}
@@ -576,54 +776,99 @@ func UpdateGrant(ctx context.Context, d *schema.ResourceData, meta interface{})
 			return diagErr
 		}
 
-		err = updatePrivileges(ctx, db, d, grant)
+		unlock, err := lockGrantee(ctx, db, meta, grant.GetUserOrRole())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer unlock()
+
+		err = updatePrivileges(ctx, db, meta, d, grant)
+		invalidateGrants(ctx, meta, grant.GetUserOrRole())
 		if err != nil {
 			return diag.Errorf("failed updating privileges: %v", err)
 		}
+
+		if err := flushPrivileges(ctx, db, meta); err != nil {
+			return diag.Errorf("failed flushing privileges: %v", err)
+		}
 	}
 
 	return nil
 }
 
-func updatePrivileges(ctx context.Context, db *sql.DB, d *schema.ResourceData, grant MySQLGrant) error {
+func updatePrivileges(ctx context.Context, db *sql.DB, meta interface{}, d *schema.ResourceData, grant MySQLGrant) error {
 	oldPrivsIf, newPrivsIf := d.GetChange("privileges")
 	oldPrivs := oldPrivsIf.(*schema.Set)
 	newPrivs := newPrivsIf.(*schema.Set)
-	grantIfs := newPrivs.Difference(oldPrivs).List()
-	revokeIfs := oldPrivs.Difference(newPrivs).List()
 
-	// Normalize the privileges to revoke
-	privsToRevoke := []string{}
-	for _, revokeIf := range revokeIfs {
-		privsToRevoke = append(privsToRevoke, revokeIf.(string))
+	// With rds_compatible, ALL PRIVILEGES was granted as its privileges, so
+	// changes are made to those.
+	if meta.(*MySQLConfiguration).RDSCompatible && (containsAllPrivilege(normalizePerms(setToArray(oldPrivs))) || containsAllPrivilege(normalizePerms(setToArray(newPrivs)))) {
+		all, err := rdsAllPrivileges(ctx, db, grant)
+		if err != nil {
+			return fmt.Errorf("failed expanding ALL PRIVILEGES: %w", err)
+		}
+		expandSet := func(privs *schema.Set) *schema.Set {
+			expanded := []interface{}{}
+			for _, privilege := range expandAllPrivileges(normalizePerms(setToArray(privs)), all) {
+				expanded = append(expanded, privilege)
+			}
+			return schema.NewSet(schema.HashString, expanded)
+		}
+		oldPrivs, newPrivs = expandSet(oldPrivs), expandSet(newPrivs)
+		if grantWithPrivileges, ok := grant.(MySQLGrantWithPrivileges); ok {
+			grant = withPrivileges(grant, expandAllPrivileges(grantWithPrivileges.GetPrivileges(), all))
+		}
+	}
+
+	statements, err := privilegeChangeStatements(grant, oldPrivs, newPrivs)
+	if err != nil {
+		return err
+	}
+
+	for _, sqlCommand := range statements {
+		logStatement(ctx, sqlCommand)
+
+		if _, err := db.ExecContext(ctx, sqlCommand); err != nil {
+			return err
+		}
 	}
-	privsToRevoke = normalizePerms(privsToRevoke)
+
+	return nil
+}
+
+// privilegeChangeStatements returns at most one REVOKE of the removed
+// privileges and one GRANT of the added ones.
+func privilegeChangeStatements(grant MySQLGrant, oldPrivs, newPrivs *schema.Set) ([]string, error) {
+	privsToRevoke := normalizePerms(setToArray(oldPrivs.Difference(newPrivs)))
+	privsToGrant := normalizePerms(setToArray(newPrivs.Difference(oldPrivs)))
+	statements := []string{}
 
 	// Do a partial revoke of anything that has been removed
 	if len(privsToRevoke) > 0 {
 		partialRevoker, ok := grant.(PrivilegesPartiallyRevocable)
 		if !ok {
-			return fmt.Errorf("grant does not support partial privilege revokes")
+			return nil, fmt.Errorf("grant does not support partial privilege revokes")
 		}
-		sqlCommand := partialRevoker.SQLPartialRevokePrivilegesStatement(privsToRevoke)
-		log.Printf("[DEBUG] SQL: %s", sqlCommand)
+		statements = append(statements, partialRevoker.SQLPartialRevokePrivilegesStatement(privsToRevoke))
 
-		if _, err := db.ExecContext(ctx, sqlCommand); err != nil {
-			return err
+		// The revoke takes GRANT OPTION too, so it has to be granted again
+		// along with the privileges that are kept.
+		if grant.GrantOption() && !containsAllPrivilege(privsToRevoke) {
+			return append(statements, grant.SQLGrantStatement()), nil
 		}
 	}
 
-	// Do a full grant if anything has been added
-	if len(grantIfs) > 0 {
-		sqlCommand := grant.SQLGrantStatement()
-		log.Printf("[DEBUG] SQL: %s", sqlCommand)
-
-		if _, err := db.ExecContext(ctx, sqlCommand); err != nil {
-			return err
+	// Grant only what has been added
+	if len(privsToGrant) > 0 {
+		partialGranter, ok := grant.(PrivilegesPartiallyGrantable)
+		if !ok {
+			return append(statements, grant.SQLGrantStatement()), nil
 		}
+		statements = append(statements, partialGranter.SQLPartialGrantPrivilegesStatement(privsToGrant))
 	}
 
-	return nil
+	return statements, nil
 }
 
 func DeleteGrant(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -632,6 +877,8 @@ func DeleteGrant(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
+	defer lockPrivilegeChanges(ctx, meta)()
+
 	// Parse the grant from ResourceData
 	grant, diagErr := parseResourceFromData(d)
 	if err != nil {
@@ -639,21 +886,59 @@ func DeleteGrant(ctx context.Context, d *schema.ResourceData, meta interface{})
 	}
 
 	// Acquire a lock for the user
-	grantCreateMutex.Lock(grant.GetUserOrRole().IDString())
-	defer grantCreateMutex.Unlock(grant.GetUserOrRole().IDString())
+	defer lockGrantCreate(ctx, grant.GetUserOrRole())()
+
+	unlock, err := lockGrantee(ctx, db, meta, grant.GetUserOrRole())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	sqlStatement := grant.SQLRevokeStatement()
-	log.Printf("[DEBUG] SQL: %s", sqlStatement)
+	logStatement(ctx, sqlStatement)
 	_, err = db.ExecContext(ctx, sqlStatement)
+	invalidateGrants(ctx, meta, grant.GetUserOrRole())
 	if err != nil {
 		if !isNonExistingGrant(err) {
 			return diag.Errorf("error revoking %s: %s", sqlStatement, err)
 		}
 	}
 
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
 	return nil
 }
 
+// readAccountTLSOption returns the TLS requirement of the account, which is
+// shown by SHOW CREATE USER, or by SHOW GRANTS before MySQL 5.7.
+func readAccountTLSOption(ctx context.Context, db *sql.DB, meta interface{}, userOrRole UserOrRole) (string, error) {
+	stmtSQL := "SHOW CREATE USER ?@?"
+	requiredVersion, _ := version.NewVersion("5.7.0")
+	if !getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) {
+		stmtSQL = "SHOW GRANTS FOR ?@?"
+	}
+
+	logStatement(ctx, stmtSQL, userOrRole.Name, userOrRole.Host)
+	rows, err := db.QueryContext(ctx, stmtSQL, userOrRole.Name, userOrRole.Host)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return "", err
+		}
+		if tlsOption := parseTLSOption(row); tlsOption != "NONE" {
+			return tlsOption, nil
+		}
+	}
+	return "NONE", rows.Err()
+}
+
 func isNonExistingGrant(err error) bool {
 	if driverErr, ok := err.(*mysql.MySQLError); ok {
 		// 1141 = ER_NONEXISTING_GRANT
@@ -667,42 +952,83 @@ func isNonExistingGrant(err error) bool {
 	return false
 }
 
+// importRolePrefix starts the import IDs of grants to roles, which have no
+// host, e.g. role:developers@database@table.
+const importRolePrefix = "role:"
+
 func ImportGrant(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	userHostDatabaseTable := strings.Split(d.Id(), "@")
+	id := d.Id()
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(id)), "GRANT ") {
+		return importRawGrant(ctx, meta, strings.TrimSpace(id))
+	}
+	userHostDatabaseTable := strings.Split(id, "@")
+	if roleDatabaseTable, ok := strings.CutPrefix(id, importRolePrefix); ok {
+		// Treat it like role@@database@table.
+		parts := strings.Split(roleDatabaseTable, "@")
+		userHostDatabaseTable = append([]string{parts[0], ""}, parts[1:]...)
+	}
 
 	if len(userHostDatabaseTable) != 4 && len(userHostDatabaseTable) != 5 {
-		return nil, fmt.Errorf("wrong ID format %s - expected user@host@database@table (and optionally ending @ to signify grant option) where some parts can be empty)", d.Id())
+		return nil, fmt.Errorf("wrong ID format %s - expected user@host@database@table, role@@database@table or role:role@database@table (and optionally ending @ to signify grant option) where some parts can be empty)", id)
 	}
 
 	user := userHostDatabaseTable[0]
-	host := userHostDatabaseTable[1]
+	host := unbracketHost(userHostDatabaseTable[1])
 	database := userHostDatabaseTable[2]
 	table := userHostDatabaseTable[3]
 	grantOption := len(userHostDatabaseTable) == 5
+	// Grants to roles are the ones without a host.
+	isRole := host == ""
 	userOrRole := UserOrRole{
 		Name: user,
 		Host: host,
 	}
 
-	desiredGrant := &TablePrivilegeGrant{
+	// Routines are given like in the database attribute, e.g. PROCEDURE db.proc,
+	// or as PROCEDURE db with the routine as the table.
+	var desiredGrant MySQLGrant = &TablePrivilegeGrant{
 		Database:   database,
 		Table:      table,
 		Grant:      grantOption,
 		UserOrRole: userOrRole,
 	}
+	routineGrant, err := parseRoutineDatabase(database)
+	if err != nil {
+		return nil, err
+	}
+	isRoutine := routineGrant != nil
+	if isRoutine {
+		routineGrant.ObjectT = ObjectT(strings.ToUpper(string(routineGrant.ObjectT)))
+		routineGrant.Grant = grantOption
+		routineGrant.UserOrRole = userOrRole
+		if routineGrant.CallableName == "" {
+			routineGrant.CallableName = table
+		} else if table == "" {
+			table = "*"
+		}
+		desiredGrant = routineGrant
+	}
 
 	db, err := getDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return nil, fmt.Errorf("Got error while getting database from meta: %w", err)
 	}
 
-	grants, err := showUserGrants(ctx, db, userOrRole)
+	grants, err := showUserGrants(ctx, db, getGrantsCacheFromMeta(meta), userOrRole, getAnsiQuotesFromMeta(ctx, meta))
 	if err != nil {
 		return nil, fmt.Errorf("Failed to showUserGrants in import: %w", err)
 	}
 	for _, foundGrant := range grants {
 		if grantsConflict(desiredGrant, foundGrant) {
 			res := resourceGrant().Data(nil)
+			if isRole {
+				res.Set("role", user)
+			}
+			if isRoutine {
+				// Keep the format of the ID, as the grant doesn't tell it.
+				res.Set("database", database)
+				res.Set("table", table)
+			}
 			setDataFromGrant(foundGrant, res)
 			return []*schema.ResourceData{res}, nil
 		}
@@ -711,6 +1037,78 @@ func ImportGrant(ctx context.Context, d *schema.ResourceData, meta interface{})
 	return nil, fmt.Errorf("Failed to find the grant to import: %v -- found %v", userHostDatabaseTable, grants)
 }
 
+// importRawGrant imports the grant of a row of SHOW GRANTS, e.g.
+// GRANT SELECT ON `db`.* TO `jdoe`@`%`. The privileges are read from the
+// server, while roles are limited to the ones of the row.
+func importRawGrant(ctx context.Context, meta interface{}, stmt string) ([]*schema.ResourceData, error) {
+	desiredGrant, err := parseGrantFromRow(stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing grant to import: %w", err)
+	}
+	if desiredGrant == nil {
+		return nil, fmt.Errorf("grant %s can't be imported", stmt)
+	}
+
+	// Grantees without a host are roles, while parseGrantFromRow defaults
+	// their host to %.
+	isRole := false
+	if i := strings.LastIndex(stmt, " TO "); i >= 0 {
+		account, _, err := readAccount(stmt[i+len(" TO "):])
+		isRole = err == nil && account.Host == ""
+	}
+	if isRole {
+		switch g := desiredGrant.(type) {
+		case *TablePrivilegeGrant:
+			g.UserOrRole.Host = ""
+		case *ProcedurePrivilegeGrant:
+			g.UserOrRole.Host = ""
+		case *RoleGrant:
+			g.UserOrRole.Host = ""
+		}
+	}
+	userOrRole := desiredGrant.GetUserOrRole()
+
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return nil, fmt.Errorf("Got error while getting database from meta: %w", err)
+	}
+
+	grants, err := showUserGrants(ctx, db, getGrantsCacheFromMeta(meta), userOrRole, getAnsiQuotesFromMeta(ctx, meta))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to showUserGrants in import: %w", err)
+	}
+	for _, foundGrant := range grants {
+		if !grantsConflict(desiredGrant, foundGrant) {
+			continue
+		}
+		res := resourceGrant().Data(nil)
+		if isRole {
+			res.Set("role", userOrRole.Name)
+		}
+		switch g := foundGrant.(type) {
+		case *RoleGrant:
+			roles := coveredRoles(desiredGrant.(*RoleGrant).Roles, g.Roles)
+			if len(roles) == 0 {
+				continue
+			}
+			limited := *g
+			limited.Roles = roles
+			foundGrant = &limited
+		case *ProcedurePrivilegeGrant:
+			database := g.Database
+			if strings.Contains(database, ".") || strings.HasPrefix(database, "`") {
+				database = quoteIdentifier(database)
+			}
+			res.Set("database", fmt.Sprintf("%s %s", g.ObjectT, database))
+			res.Set("table", g.CallableName)
+		}
+		setDataFromGrant(foundGrant, res)
+		return []*schema.ResourceData{res}, nil
+	}
+
+	return nil, fmt.Errorf("Failed to find the grant to import: %s -- found %v", stmt, grants)
+}
+
 // setDataFromGrant copies the values from MySQLGrant to the schema.ResourceData
 // This function is used when importing a new Grant, or when syncing remote state to Terraform state
 // It is responsible for pulling any non-identifying properties (e.g. grant, tls_option) into the Terraform state
@@ -795,8 +1193,8 @@ func combineGrants(grantA MySQLGrant, grantB MySQLGrant) (MySQLGrant, error) {
 	return nil, fmt.Errorf("Unable to combine MySQLGrant %s of type %T with %s of type %T", grantA, grantA, grantB, grantB)
 }
 
-func getMatchingGrant(ctx context.Context, db *sql.DB, desiredGrant MySQLGrant) (MySQLGrant, error) {
-	allGrants, err := showUserGrants(ctx, db, desiredGrant.GetUserOrRole())
+func getMatchingGrant(ctx context.Context, db *sql.DB, cache *grantsCache, desiredGrant MySQLGrant, ansiQuotes bool) (MySQLGrant, error) {
+	allGrants, err := showUserGrants(ctx, db, cache, desiredGrant.GetUserOrRole(), ansiQuotes)
 	var result MySQLGrant
 	if err != nil {
 		return nil, fmt.Errorf("showGrant - getting all grants failed: %w", err)
@@ -809,6 +1207,18 @@ func getMatchingGrant(ctx context.Context, db *sql.DB, desiredGrant MySQLGrant)
 			continue
 		}
 
+		// REQUIRE-only grants match the global grant of the account, whatever
+		// privileges it has, as MySQL lists USAGE only without any. Other
+		// grants don't match USAGE.
+		if isUsageGrant(desiredGrant) {
+			usage := *dbGrant.(*TablePrivilegeGrant)
+			usage.Privileges = []string{}
+			return &usage, nil
+		}
+		if isUsageGrant(dbGrant) {
+			continue
+		}
+
 		// For some reason, MySQL separates privileges into multiple lines
 		// So to normalize them, we need to combine them into a single MySQLGrant
 		if result != nil {
@@ -820,46 +1230,100 @@ func getMatchingGrant(ctx context.Context, db *sql.DB, desiredGrant MySQLGrant)
 			result = dbGrant
 		}
 	}
+
+	// All roles of a user are listed together, but each grant only covers
+	// the roles it's given, so others don't count as drift.
+	if desiredRoleGrant, ok := desiredGrant.(*RoleGrant); ok && len(desiredRoleGrant.Roles) > 0 && result != nil {
+		roleGrant := result.(*RoleGrant)
+		roleGrant.Roles = coveredRoles(desiredRoleGrant.Roles, roleGrant.Roles)
+		if len(roleGrant.Roles) == 0 {
+			return nil, nil
+		}
+	}
 	return result, nil
 }
 
-var (
-	kUserOrRoleRegex = regexp.MustCompile("['`]?([^'`]+)['`]?(?:@['`]?([^'`]+)['`]?)?")
-)
+// coveredRoles returns the granted roles that are among the desired ones.
+func coveredRoles(desired []string, granted []string) []string {
+	roles := []string{}
+	for _, role := range granted {
+		if containsRole(desired, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// readAccount reads the account at the start of s, e.g. `app-user`@`10.0.%`
+// or 'jdoe'@'%', and returns the rest of s. The host is empty if s names
+// a role without one.
+func readAccount(s string) (UserOrRole, string, error) {
+	name, rest, err := readQuotedName(strings.TrimSpace(s), "@, \t\n")
+	if err != nil {
+		return UserOrRole{}, "", err
+	}
+	account := UserOrRole{Name: name}
+	if strings.HasPrefix(rest, "@") {
+		account.Host, rest, err = readQuotedName(rest[1:], ", \t\n")
+		if err != nil {
+			return UserOrRole{}, "", err
+		}
+	}
+	return account, rest, nil
+}
 
 func parseUserOrRoleFromRow(userOrRoleStr string) (*UserOrRole, error) {
-	userHostMatches := kUserOrRoleRegex.FindStringSubmatch(userOrRoleStr)
-	if len(userHostMatches) == 3 {
-		return &UserOrRole{
-			Name: userHostMatches[1],
-			Host: userHostMatches[2],
-		}, nil
-	} else if len(userHostMatches) == 2 {
-		return &UserOrRole{
-			Name: userHostMatches[1],
-			Host: "%",
-		}, nil
-	} else {
+	userOrRole, _, err := readAccount(userOrRoleStr)
+	if err != nil || userOrRole.Name == "" && userOrRole.Host == "" {
 		return nil, fmt.Errorf("failed to parse user or role portion of grant statement: %s", userOrRoleStr)
 	}
+	if userOrRole.Host == "" {
+		userOrRole.Host = "%"
+	}
+	return &userOrRole, nil
 }
 
-var (
-	kDatabaseAndObjectRegex = regexp.MustCompile("['`]?([^'`]+)['`]?\\.['`]?([^'`]+)['`]?")
-)
+// parseRoleList parses the roles of a role grant, e.g. `dev`@`%`,`ops`.
+func parseRoleList(rolesStr string) ([]string, error) {
+	roles := []string{}
+	rest := rolesStr
+	for {
+		role, r, err := readAccount(rest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse roles portion of grant statement: %s", rolesStr)
+		}
+		if role.Host == "%" {
+			role.Host = ""
+		}
+		roles = append(roles, role.IDString())
+
+		rest = strings.TrimSpace(r)
+		if !strings.HasPrefix(rest, ",") {
+			break
+		}
+		rest = rest[1:]
+	}
+	return roles, nil
+}
 
+// parseDatabaseQualifiedObject parses objects of SHOW GRANTS rows, e.g.
+// `my.schema`.`events` or *.*.
 func parseDatabaseQualifiedObject(objectRef string) (string, string, error) {
-	if matches := kDatabaseAndObjectRegex.FindStringSubmatch(objectRef); len(matches) == 3 {
-		return matches[1], matches[2], nil
+	database, rest, err := readQuotedName(strings.TrimSpace(objectRef), ".")
+	if err == nil && strings.HasPrefix(rest, ".") {
+		object, rest, err := readQuotedName(rest[1:], " ")
+		if err == nil && strings.TrimSpace(rest) == "" {
+			return database, object, nil
+		}
 	}
 	return "", "", fmt.Errorf("failed to parse database and table portion of grant statement: %s", objectRef)
 }
 
 var (
-	kRequireRegex = regexp.MustCompile(`.*REQUIRE\s+(.*)`)
-
 	kGrantRegex = regexp.MustCompile(`\bGRANT OPTION\b|\bADMIN OPTION\b`)
 
+	proxyGrantRegex     = regexp.MustCompile(`^GRANT\s+PROXY\s+ON\s`)
+	resourceLimitsRegex = regexp.MustCompile(`(?i)(\s+WITH(?:\s+GRANT\s+OPTION)?)((?:\s+MAX_[A-Z_]+\s+[0-9.]+)+)\s*$`)
 	procedureGrantRegex = regexp.MustCompile(`GRANT\s+(.+)\s+ON\s+(FUNCTION|PROCEDURE)\s+(.+)\s+TO\s+(.+)`)
 	tableGrantRegex     = regexp.MustCompile(`GRANT\s+(.+)\s+ON\s+(.+)\s+TO\s+(.+)`)
 	roleGrantRegex      = regexp.MustCompile(`GRANT\s+(.+)\s+TO\s+(.+)`)
@@ -873,13 +1337,26 @@ func parseGrantFromRow(grantStr string) (MySQLGrant, error) {
 		return nil, nil
 	}
 
-	// Parse Require Statement
-	tlsOption := "NONE"
-	if requireMatches := kRequireRegex.FindStringSubmatch(grantStr); len(requireMatches) == 2 {
-		tlsOption = requireMatches[1]
+	// MariaDB lists the default role of users as well.
+	if strings.HasPrefix(grantStr, "SET DEFAULT ROLE") {
+		return nil, nil
+	}
+
+	// PROXY grants name an account instead of an object, e.g. the ones that
+	// Percona's auth_pam group mapping relies on. mysql_proxy_user manages
+	// them.
+	if proxyGrantRegex.MatchString(grantStr) {
+		log.Printf("[DEBUG] Ignoring proxy grant: %s", grantStr)
+		return nil, nil
 	}
 
-	if procedureMatches := procedureGrantRegex.FindStringSubmatch(grantStr); len(procedureMatches) == 5 {
+	grantStr = stripResourceLimits(grantStr)
+	tlsOption := parseTLSOption(grantStr)
+
+	// Keywords are only looked for outside of quotes, since names and auth
+	// strings can contain them, e.g. `x TO y`@`%`.
+	code := queryCode(grantStr, true)
+	if procedureMatches := codeSubmatches(procedureGrantRegex, grantStr, code); len(procedureMatches) == 5 {
 		privsStr := procedureMatches[1]
 		privileges := extractPermTypes(privsStr)
 		privileges = normalizePerms(privileges)
@@ -904,22 +1381,17 @@ func parseGrantFromRow(grantStr string) (MySQLGrant, error) {
 			ObjectT:      ObjectT(procedureMatches[2]),
 			CallableName: callable,
 			Privileges:   privileges,
-			Grant:        kGrantRegex.MatchString(grantStr),
+			Grant:        kGrantRegex.MatchString(code),
 			UserOrRole:   *userOrRole,
 			TLSOption:    tlsOption,
 		}
 		log.Printf("[DEBUG] Got: %s, parsed grant is %s: %v", grantStr, reflect.TypeOf(grant), grant)
 		return grant, nil
-	} else if tableMatches := tableGrantRegex.FindStringSubmatch(grantStr); len(tableMatches) == 4 {
+	} else if tableMatches := codeSubmatches(tableGrantRegex, grantStr, code); len(tableMatches) == 4 {
 		privsStr := tableMatches[1]
 		privileges := extractPermTypes(privsStr)
 		privileges = normalizePerms(privileges)
 
-		// After normalizePerms, we may have empty privileges. If so, skip this grant.
-		if len(privileges) == 0 {
-			return nil, nil
-		}
-
 		userOrRole, err := parseUserOrRoleFromRow(tableMatches[3])
 		if err != nil {
 			return nil, fmt.Errorf("Failed to parseUserOrRole for table grant: %w", err)
@@ -930,22 +1402,27 @@ func parseGrantFromRow(grantStr string) (MySQLGrant, error) {
 			return nil, fmt.Errorf("Failed to parseDatabaseQualifiedObject for table grant: %w", err)
 		}
 
+		// After normalizePerms, we may have empty privileges. If so, skip this
+		// grant, unless it's USAGE ON *.*, which REQUIRE-only grants match.
+		if len(privileges) == 0 && (database != "*" || table != "*") {
+			return nil, nil
+		}
+
 		grant := &TablePrivilegeGrant{
 			Database:   database,
 			Table:      table,
 			Privileges: privileges,
-			Grant:      kGrantRegex.MatchString(grantStr),
+			Grant:      kGrantRegex.MatchString(code),
 			UserOrRole: *userOrRole,
 			TLSOption:  tlsOption,
 		}
 		log.Printf("[DEBUG] Got: %s, parsed grant is %s: %v", grantStr, reflect.TypeOf(grant), grant)
 		return grant, nil
-	} else if roleMatches := roleGrantRegex.FindStringSubmatch(grantStr); len(roleMatches) == 3 {
-		rolesStart := strings.Split(roleMatches[1], ",")
-		roles := make([]string, len(rolesStart))
-
-		for i, role := range rolesStart {
-			roles[i] = strings.Trim(role, "`@%\" ")
+	} else if roleMatches := codeSubmatches(roleGrantRegex, grantStr, code); len(roleMatches) == 3 {
+		// TiDB quotes roles with single quotes.
+		roles, err := parseRoleList(roleMatches[1])
+		if err != nil {
+			return nil, err
 		}
 
 		userOrRole, err := parseUserOrRoleFromRow(roleMatches[2])
@@ -955,7 +1432,7 @@ func parseGrantFromRow(grantStr string) (MySQLGrant, error) {
 
 		grant := &RoleGrant{
 			Roles:      roles,
-			Grant:      kGrantRegex.MatchString(grantStr),
+			Grant:      kGrantRegex.MatchString(code),
 			UserOrRole: *userOrRole,
 			TLSOption:  tlsOption,
 		}
@@ -967,30 +1444,35 @@ func parseGrantFromRow(grantStr string) (MySQLGrant, error) {
 	}
 }
 
-func showUserGrants(ctx context.Context, db *sql.DB, userOrRole UserOrRole) ([]MySQLGrant, error) {
-	grants := []MySQLGrant{}
-
-	sqlStatement := fmt.Sprintf("SHOW GRANTS FOR %s", userOrRole.SQLString())
-	log.Printf("[DEBUG] SQL: %s", sqlStatement)
-	rows, err := db.QueryContext(ctx, sqlStatement)
-
-	if isNonExistingGrant(err) {
-		return []MySQLGrant{}, nil
+// stripResourceLimits removes the MAX_QUERIES_PER_HOUR and other limits that
+// servers before MySQL 5.7 and MariaDB append to the global grant, e.g. WITH
+// GRANT OPTION MAX_USER_CONNECTIONS 10. mysql_user_resource_limits manages
+// them.
+func stripResourceLimits(grantStr string) string {
+	m := resourceLimitsRegex.FindStringSubmatchIndex(grantStr)
+	if m == nil {
+		return grantStr
 	}
+	log.Printf("[DEBUG] Ignoring resource limits of grant: %s", grantStr[m[4]:m[5]])
+	with := grantStr[m[2]:m[3]]
+	if strings.EqualFold(strings.TrimSpace(with), "WITH") {
+		with = ""
+	}
+	return grantStr[:m[0]] + with
+}
 
+func showUserGrants(ctx context.Context, db *sql.DB, cache *grantsCache, userOrRole UserOrRole, ansiQuotes bool) ([]MySQLGrant, error) {
+	grants := []MySQLGrant{}
+
+	rawGrants, err := showUserGrantRows(ctx, db, cache, userOrRole)
 	if err != nil {
-		return nil, fmt.Errorf("showUserGrants - getting grants failed: %w", err)
+		return nil, err
 	}
 
-	defer rows.Close()
-	for rows.Next() {
-		var rawGrant string
-
-		err := rows.Scan(&rawGrant)
-		if err != nil {
-			return nil, fmt.Errorf("showUserGrants - reading row failed: %w", err)
+	for _, rawGrant := range rawGrants {
+		if ansiQuotes {
+			rawGrant = backtickAnsiIdentifiers(rawGrant)
 		}
-
 		parsedGrant, err := parseGrantFromRow(rawGrant)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to parseGrantFromRow: %w", err)
@@ -1013,6 +1495,47 @@ func showUserGrants(ctx context.Context, db *sql.DB, userOrRole UserOrRole) ([]M
 	return grants, nil
 }
 
+// showUserGrantRows returns the rows of SHOW GRANTS, from the cache if the
+// grants of the grantee were read already.
+func showUserGrantRows(ctx context.Context, db *sql.DB, cache *grantsCache, userOrRole UserOrRole) ([]string, error) {
+	rawGrants, generation, ok := cache.get(db, userOrRole)
+	if ok {
+		log.Printf("[DEBUG] Using cached grants of %s", userOrRole.SQLString())
+		return rawGrants, nil
+	}
+
+	sqlStatement := fmt.Sprintf("SHOW GRANTS FOR %s", userOrRole.SQLString())
+	logStatement(ctx, sqlStatement)
+	rows, err := db.QueryContext(ctx, sqlStatement)
+
+	if isNonExistingGrant(err) {
+		cache.set(db, userOrRole, generation, []string{})
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("showUserGrants - getting grants failed: %w", err)
+	}
+
+	defer rows.Close()
+	rawGrants = []string{}
+	for rows.Next() {
+		var rawGrant string
+
+		err := rows.Scan(&rawGrant)
+		if err != nil {
+			return nil, fmt.Errorf("showUserGrants - reading row failed: %w", err)
+		}
+		rawGrants = append(rawGrants, rawGrant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("showUserGrants - reading rows failed: %w", err)
+	}
+
+	cache.set(db, userOrRole, generation, rawGrants)
+	return rawGrants, nil
+}
+
 func removeUselessPerms(grants []string) []string {
 	ret := []string{}
 	for _, grant := range grants {
@@ -1023,33 +1546,37 @@ func removeUselessPerms(grants []string) []string {
 	return ret
 }
 
+// extractPermTypes splits the privileges of a grant, e.g.
+// SELECT (`my col`, `order`), INSERT. Commas within parentheses and quotes
+// don't split them.
 func extractPermTypes(g string) []string {
 	grants := []string{}
 
 	inParentheses := false
+	var quote rune
 	currentWord := []rune{}
 	for _, b := range g {
-		switch b {
-		case ',':
-			if inParentheses {
-				currentWord = append(currentWord, b)
-			} else {
-				grants = append(grants, string(currentWord))
-				currentWord = []rune{}
+		switch {
+		case quote != 0:
+			// Doubled quotes end and start the quote again.
+			if b == quote {
+				quote = 0
 			}
-			break
-		case '(':
+			currentWord = append(currentWord, b)
+		case b == '`' || b == '"':
+			quote = b
+			currentWord = append(currentWord, b)
+		case b == ',' && !inParentheses:
+			grants = append(grants, string(currentWord))
+			currentWord = []rune{}
+		case b == '(':
 			inParentheses = true
 			currentWord = append(currentWord, b)
-			break
-		case ')':
+		case b == ')':
 			inParentheses = false
 			currentWord = append(currentWord, b)
-			break
+		case unicode.IsSpace(b) && len(currentWord) == 0:
 		default:
-			if unicode.IsSpace(b) && len(currentWord) == 0 {
-				break
-			}
 			currentWord = append(currentWord, b)
 		}
 	}
@@ -1058,36 +1585,73 @@ func extractPermTypes(g string) []string {
 }
 
 func normalizeColumnOrder(perm string) string {
-	re := regexp.MustCompile(`^([^(]*)\((.*)\)$`)
 	// We may get inputs like
-	// 	SELECT(b,a,c)   -> SELECT(a,b,c)
-	// 	DELETE          -> DELETE
-	//  SELECT (a,b,c)  -> SELECT(a,b,c)
+	// 	SELECT(b,a,c)       -> SELECT(`a`, `b`, `c`)
+	// 	DELETE              -> DELETE
+	//  SELECT (`my col`,a) -> SELECT(`a`, `my col`)
 	// if it's without parentheses, return it right away.
-	// Else split what is inside, sort it, concat together and return the result.
-	m := re.FindStringSubmatch(perm)
-	if m == nil || len(m) < 3 {
+	// Else split what is inside, sort it, quote and concat together and return the result.
+	open := strings.Index(perm, "(")
+	if open < 0 || !strings.HasSuffix(perm, ")") {
 		return perm
 	}
+	columns, err := parseColumnList(perm[open+1 : len(perm)-1])
+	if err != nil {
+		return perm
+	}
+
+	sort.Strings(columns)
+	for i := range columns {
+		columns[i] = quoteIdentifier(columns[i])
+	}
+	precursor := strings.TrimSpace(perm[:open])
+	return fmt.Sprintf("%s(%s)", precursor, strings.Join(columns, ", "))
+}
+
+// parseColumnList parses the columns of column privileges, e.g.
+// `my col`, `order`, id.
+func parseColumnList(s string) ([]string, error) {
+	columns := []string{}
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		column, r, err := readQuotedName(rest, ", \t\n")
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+
+		rest = strings.TrimSpace(r)
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, ",") {
+			return nil, fmt.Errorf("expected a comma before %s", rest)
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+	return columns, nil
+}
 
-	parts := strings.Split(m[2], ",")
-	for i := range parts {
-		parts[i] = strings.Trim(parts[i], "` ")
+// collapsePrivilegeName collapses the whitespace within the name of a
+// privilege, e.g. BINLOG   MONITOR, leaving its columns as they are. Names
+// aren't checked against a list, so privileges of plugins and vendors like
+// Aurora's LOAD FROM S3 are kept as they are otherwise.
+func collapsePrivilegeName(perm string) string {
+	name, columns := perm, ""
+	if open := strings.Index(perm, "("); open >= 0 {
+		name, columns = perm[:open], perm[open:]
 	}
-	sort.Strings(parts)
-	precursor := strings.Trim(m[1], " ")
-	partsTogether := strings.Join(parts, ", ")
-	return fmt.Sprintf("%s(%s)", precursor, partsTogether)
+	return strings.Join(strings.Fields(name), " ") + columns
 }
 
-var kReAllPrivileges = regexp.MustCompile(`ALL ?(PRIVILEGES)?`)
+var kReAllPrivileges = regexp.MustCompile(`(?i)^ALL ?(PRIVILEGES)?$`)
 
 func normalizePerms(perms []string) []string {
 	ret := []string{}
 	for _, perm := range perms {
 		// Remove leading and trailing backticks and spaces
-		permNorm := strings.Trim(perm, "` ")
-		permUcase := strings.ToUpper(permNorm)
+		permNorm := strings.Trim(strings.TrimSpace(perm), "` ")
+		permUcase := collapsePrivilegeName(strings.ToUpper(permNorm))
 
 		// Normalize ALL and ALLPRIVILEGES to ALL PRIVILEGES
 		if kReAllPrivileges.MatchString(permUcase) {
@@ -1107,6 +1671,25 @@ func normalizePerms(perms []string) []string {
 	return ret
 }
 
+// withoutIgnoredPrivileges removes the privileges listed in ignore_privileges,
+// unless they're configured in privileges as well.
+func withoutIgnoredPrivileges(privileges []string, d *schema.ResourceData) []string {
+	return filterIgnoredPrivileges(privileges, normalizePerms(setToArray(d.Get("ignore_privileges"))), normalizePerms(setToArray(d.Get("privileges"))))
+}
+
+func filterIgnoredPrivileges(privileges, ignored, configured []string) []string {
+	if len(ignored) == 0 {
+		return privileges
+	}
+	ret := []string{}
+	for _, privilege := range privileges {
+		if !containsString(ignored, privilege) || containsString(configured, privilege) {
+			ret = append(ret, privilege)
+		}
+	}
+	return ret
+}
+
 func setToArray(s interface{}) []string {
 	set, ok := s.(*schema.Set)
 	if !ok {
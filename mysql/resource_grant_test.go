@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -48,6 +50,13 @@ func TestAccGrant(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateId:     fmt.Sprintf("%v@%v@%v@%v", userName, "example.com", dbName, "*"),
 			},
+			{
+				Config:            testAccGrantConfigBasic(dbName),
+				ResourceName:      "mysql_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("GRANT SELECT ON `%s`.* TO '%s'@'example.com'", dbName, userName),
+			},
 		},
 	})
 }
@@ -98,6 +107,58 @@ func TestAccRevokePrivRefresh(t *testing.T) {
 	})
 }
 
+func TestAccGrant_ignorePrivileges(t *testing.T) {
+	dbName := fmt.Sprintf("tf-test-%d", rand.Intn(100))
+	config := fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_user" "test" {
+  user     = "jdoe-%s"
+  host     = "example.com"
+}
+
+resource "mysql_grant" "test" {
+  user              = "${mysql_user.test.user}"
+  host              = "${mysql_user.test.host}"
+  database          = "${mysql_database.test.name}"
+  privileges        = ["UPDATE", "SELECT"]
+  ignore_privileges = ["insert", "SELECT"]
+}
+`, dbName, dbName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckSkipRds(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGrantCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccPrivilege("mysql_grant.test", "SELECT", true, false),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					grantUserPrivs(dbName, "INSERT"),
+				),
+			},
+			{
+				// INSERT is ignored and left alone, while SELECT is configured.
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccPrivilege("mysql_grant.test", "INSERT", true, false),
+					testAccPrivilege("mysql_grant.test", "SELECT", true, false),
+					resource.TestCheckTypeSetElemAttr("mysql_grant.test", "privileges.*", "UPDATE"),
+					resource.TestCheckResourceAttr("mysql_grant.test", "privileges.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccBroken(t *testing.T) {
 	dbName := fmt.Sprintf("tf-test-%d", rand.Intn(100))
 	resource.Test(t, resource.TestCase{
@@ -355,6 +416,30 @@ func TestAccGrant_role(t *testing.T) {
 					resource.TestCheckResourceAttr("mysql_grant.test", "role", roleName),
 				),
 			},
+			{
+				Config:                  testAccGrantConfigRole(dbName, roleName),
+				ResourceName:            "mysql_grant.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"host"},
+				ImportStateId:           fmt.Sprintf("%v@@%v@%v", roleName, dbName, "*"),
+			},
+			{
+				Config:                  testAccGrantConfigRole(dbName, roleName),
+				ResourceName:            "mysql_grant.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"host"},
+				ImportStateId:           fmt.Sprintf("role:%v@%v@%v", roleName, dbName, "*"),
+			},
+			{
+				Config:                  testAccGrantConfigRole(dbName, roleName),
+				ResourceName:            "mysql_grant.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"host"},
+				ImportStateId:           fmt.Sprintf("GRANT SELECT ON `%s`.* TO `%s`", dbName, roleName),
+			},
 		},
 	})
 }
@@ -383,6 +468,48 @@ func TestAccGrant_roleToUser(t *testing.T) {
 	})
 }
 
+func TestAccGrant_mariaDBPublic(t *testing.T) {
+	dbName := fmt.Sprintf("tf-test-%d", rand.Intn(100))
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSkipNotMariaDB(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "10.11.0")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGrantCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrantConfigPublic(dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_grant.test", "role", "PUBLIC"),
+					testAccPrivilege("mysql_grant.test", "SELECT", true, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGrant_requireOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSkipNotMariaDB(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGrantCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrantConfigRequireOnly,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_grant.test", "privileges.#", "0"),
+					resource.TestCheckResourceAttr("mysql_grant.test", "tls_option", "SSL"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccGrant_complexRoleGrants(t *testing.T) {
 	dbName := fmt.Sprintf("tf-test-%d", rand.Intn(100))
 	resource.Test(t, resource.TestCase{
@@ -458,7 +585,7 @@ func testAccPrivilege(rn string, privilege string, expectExists bool, expectGran
 			}
 		}
 
-		grants, err := showUserGrants(context.Background(), db, userOrRole)
+		grants, err := showUserGrants(context.Background(), db, nil, userOrRole, getAnsiQuotesFromMeta(context.Background(), testAccProvider.Meta()))
 		if err != nil {
 			return err
 		}
@@ -499,6 +626,25 @@ func testAccPrivilege(rn string, privilege string, expectExists bool, expectGran
 	}
 }
 
+const testAccGrantConfigRequireOnly = `
+resource "mysql_user" "test" {
+  user = "jdoe-require"
+  host = "example.com"
+
+  lifecycle {
+    ignore_changes = [tls_option]
+  }
+}
+
+resource "mysql_grant" "test" {
+  user       = mysql_user.test.user
+  host       = mysql_user.test.host
+  database   = "*"
+  privileges = []
+  tls_option = "SSL"
+}
+`
+
 func testAccGrantCheckDestroy(s *terraform.State) error {
 	ctx := context.Background()
 	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
@@ -757,6 +903,20 @@ resource "mysql_grant" "test" {
 `, dbName, roleName)
 }
 
+func testAccGrantConfigPublic(dbName string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_grant" "test" {
+  role       = "PUBLIC"
+  database   = "${mysql_database.test.name}"
+  privileges = ["SELECT"]
+}
+`, dbName)
+}
+
 func testAccGrantConfigRoleWithGrantOption(dbName string, roleName string) string {
 	return fmt.Sprintf(`
 resource "mysql_database" "test" {
@@ -926,6 +1086,20 @@ func TestAccGrantOnProcedure(t *testing.T) {
 					resource.TestCheckResourceAttr("mysql_grant.test_procedure", "table", procedureName),
 				),
 			},
+			{
+				Config:            testAccGrantConfigProcedureWithTable(procedureName, dbName, hostName),
+				ResourceName:      "mysql_grant.test_procedure",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%v@%v@PROCEDURE %v@%v", userName, hostName, dbName, procedureName),
+			},
+			{
+				Config:            testAccGrantConfigProcedureWithTable(procedureName, dbName, hostName),
+				ResourceName:      "mysql_grant.test_procedure",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("GRANT EXECUTE ON PROCEDURE `%s`.`%s` TO `%s`@`%s`", dbName, procedureName, userName, hostName),
+			},
 			{
 				// Remove the grant
 				Config: testAccGrantConfigNoGrant(dbName),
@@ -941,6 +1115,13 @@ func TestAccGrantOnProcedure(t *testing.T) {
 					resource.TestCheckResourceAttr("mysql_grant.test_procedure", "table", "*"),
 				),
 			},
+			{
+				Config:            testAccGrantConfigProcedureWithDatabase(procedureName, dbName, hostName),
+				ResourceName:      "mysql_grant.test_procedure",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%v@%v@PROCEDURE %v.%v@", userName, hostName, dbName, procedureName),
+			},
 		},
 	})
 }
@@ -1066,6 +1247,23 @@ func revokeUserPrivs(dbname string, privs string) resource.TestCheckFunc {
 	}
 }
 
+func grantUserPrivs(dbname string, privs string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		grantSql := fmt.Sprintf("GRANT %s ON `%s`.* TO `jdoe-%s`@`example.com`;", privs, dbname, dbname)
+		log.Printf("[DEBUG] SQL: %s", grantSql)
+		if _, err := db.Exec(grantSql); err != nil {
+			return fmt.Errorf("error granting privileges: %s", err)
+		}
+		return nil
+	}
+}
+
 func TestAllowDuplicateUsersDifferentTables(t *testing.T) {
 	dbName := fmt.Sprintf("tf-test-%d", rand.Intn(100))
 
@@ -1179,3 +1377,477 @@ func TestDisallowDuplicateUsersSameTable(t *testing.T) {
 		},
 	})
 }
+
+func TestParseGrantFromRow(t *testing.T) {
+	for _, stmt := range []string{
+		"GRANT PROXY ON ''@'' TO `dev`@`%`",
+		"GRANT PROXY ON `developers`@`%` TO ``@`` WITH GRANT OPTION",
+		"SET DEFAULT ROLE `r1` FOR `u`@`%`",
+	} {
+		grant, err := parseGrantFromRow(stmt)
+		if err != nil || grant != nil {
+			t.Errorf("parseGrantFromRow(%s) = %v, %v; expected it to be ignored", stmt, grant, err)
+		}
+	}
+
+	roleGrant, err := parseGrantFromRow("GRANT `dev`@`%`,`ops`@`localhost` TO `jdoe`@`%` WITH ADMIN OPTION")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if roles := roleGrant.(*RoleGrant).Roles; strings.Join(roles, ",") != "dev,ops@localhost" {
+		t.Errorf("unexpected roles %v", roles)
+	}
+
+	for stmt, expected := range map[string]UserOrRole{
+		"GRANT SELECT ON `app`.* TO `app-user`@`10.0.%`":                {Name: "app-user", Host: "10.0.%"},
+		"GRANT SELECT ON `app`.* TO `o'brien`@`%` WITH GRANT OPTION":    {Name: "o'brien", Host: "%"},
+		"GRANT SELECT ON `app`.* TO 'o''brien'@'localhost'":             {Name: "o'brien", Host: "localhost"},
+		"GRANT SELECT ON `app`.* TO `we``ird@x`@`%`":                    {Name: "we`ird@x", Host: "%"},
+		"GRANT SELECT ON `app`.* TO `jdoe`@`192.168.0.0/255.255.255.0`": {Name: "jdoe", Host: "192.168.0.0/255.255.255.0"},
+		"GRANT SELECT ON `app`.* TO 'jdoe'@'10.0.0.0/8' REQUIRE SSL":    {Name: "jdoe", Host: "10.0.0.0/8"},
+		"GRANT SELECT ON `app`.* TO `jdoe`@`2001:db8::1`":               {Name: "jdoe", Host: "2001:db8::1"},
+		"GRANT EXECUTE ON PROCEDURE `app`.`p` TO `a b`@`%`":             {Name: "a b", Host: "%"},
+		"GRANT `dev`@`%` TO `it's`@`%`":                                 {Name: "it's", Host: "%"},
+	} {
+		grant, err := parseGrantFromRow(stmt)
+		if err != nil {
+			t.Fatalf("parseGrantFromRow(%s) failed: %v", stmt, err)
+		}
+		if account := grant.GetUserOrRole(); account != expected {
+			t.Errorf("parseGrantFromRow(%s) parsed %v; expected %v", stmt, account, expected)
+		}
+	}
+
+	roleGrant, err = parseGrantFromRow("GRANT 'read,only'@'%','o''ps' TO 'jdoe'@'%'")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if roles := roleGrant.(*RoleGrant).Roles; !reflect.DeepEqual(roles, []string{"read,only", "o'ps"}) {
+		t.Errorf("unexpected roles %v", roles)
+	}
+
+	tableGrant, err := parseGrantFromRow("GRANT SELECT ON `my.schema`.`my.table` TO `jdoe`@`%`")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if g := tableGrant.(*TablePrivilegeGrant); g.Database != "my.schema" || g.Table != "my.table" {
+		t.Errorf("unexpected object %s.%s", g.Database, g.Table)
+	}
+
+	procedureGrant, err := parseGrantFromRow("GRANT EXECUTE ON PROCEDURE `my.schema`.`proc` TO `jdoe`@`%`")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if g := procedureGrant.(*ProcedurePrivilegeGrant); g.Database != "my.schema" || g.CallableName != "proc" {
+		t.Errorf("unexpected routine %s.%s", g.Database, g.CallableName)
+	}
+
+	grant, err := parseGrantFromRow("GRANT BACKUP_ADMIN,AUDIT_ADMIN ON *.* TO `backup`@`localhost`")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	privileges := grant.(MySQLGrantWithPrivileges).GetPrivileges()
+	if strings.Join(privileges, ",") != "AUDIT_ADMIN,BACKUP_ADMIN" {
+		t.Errorf("unexpected privileges %v", privileges)
+	}
+}
+
+func TestParseGrantFromRowKeywordsInNames(t *testing.T) {
+	for stmt, expected := range map[string]MySQLGrant{
+		"GRANT SELECT ON `app`.* TO `x TO y`@`%`": &TablePrivilegeGrant{
+			Database: "app", Table: "*", Privileges: []string{"SELECT"},
+			UserOrRole: UserOrRole{Name: "x TO y", Host: "%"}, TLSOption: "NONE",
+		},
+		"GRANT SELECT ON `a ON b`.`c TO d` TO `jdoe`@`%`": &TablePrivilegeGrant{
+			Database: "a ON b", Table: "c TO d", Privileges: []string{"SELECT"},
+			UserOrRole: UserOrRole{Name: "jdoe", Host: "%"}, TLSOption: "NONE",
+		},
+		"GRANT SELECT ON `app`.* TO 'x ON y'@'%' REQUIRE SSL": &TablePrivilegeGrant{
+			Database: "app", Table: "*", Privileges: []string{"SELECT"},
+			UserOrRole: UserOrRole{Name: "x ON y", Host: "%"}, TLSOption: "SSL",
+		},
+		"GRANT SELECT ON `app`.* TO `WITH GRANT OPTION`@`% REQUIRE SSL`": &TablePrivilegeGrant{
+			Database: "app", Table: "*", Privileges: []string{"SELECT"},
+			UserOrRole: UserOrRole{Name: "WITH GRANT OPTION", Host: "% REQUIRE SSL"}, TLSOption: "NONE",
+		},
+		"GRANT EXECUTE ON PROCEDURE `app`.`p TO q` TO `x ON PROCEDURE y`@`%`": &ProcedurePrivilegeGrant{
+			Database: "app", ObjectT: kProcedure, CallableName: "p TO q", Privileges: []string{"EXECUTE"},
+			UserOrRole: UserOrRole{Name: "x ON PROCEDURE y", Host: "%"}, TLSOption: "NONE",
+		},
+		"GRANT `a TO b`@`%` TO `c TO d`@`%`": &RoleGrant{
+			Roles:      []string{"a TO b"},
+			UserOrRole: UserOrRole{Name: "c TO d", Host: "%"}, TLSOption: "NONE",
+		},
+		// Percona Server 5.7 lists the auth_pam mapping in the grant.
+		"GRANT USAGE ON *.* TO 'dev'@'%' IDENTIFIED WITH 'auth_pam' AS 'mysqld, developers TO dev, admins ON ops'": &TablePrivilegeGrant{
+			Database: "*", Table: "*", Privileges: []string{},
+			UserOrRole: UserOrRole{Name: "dev", Host: "%"}, TLSOption: "NONE",
+		},
+	} {
+		grant, err := parseGrantFromRow(stmt)
+		if err != nil {
+			t.Fatalf("parseGrantFromRow(%s) failed: %v", stmt, err)
+		}
+		if !reflect.DeepEqual(grant, expected) {
+			t.Errorf("parseGrantFromRow(%s) = %v, expected %v", stmt, grant, expected)
+		}
+	}
+}
+
+func TestGrantSQLQuoting(t *testing.T) {
+	grant := &TablePrivilegeGrant{
+		Database:   "my`db",
+		Table:      "*",
+		Privileges: []string{"SELECT"},
+		UserOrRole: UserOrRole{Name: "o'brien", Host: "%"},
+	}
+	expected := "GRANT SELECT ON `my``db`.* TO 'o''brien'@'%'"
+	if stmt := grant.SQLGrantStatement(); stmt != expected {
+		t.Errorf("expected %s, got %s", expected, stmt)
+	}
+
+	roleGrant := &RoleGrant{
+		Roles:      []string{"dev", "ops@%"},
+		UserOrRole: UserOrRole{Name: "jdoe", Host: "example.com"},
+	}
+	expected = "GRANT 'dev', 'ops' TO 'jdoe'@'example.com'"
+	if stmt := roleGrant.SQLGrantStatement(); stmt != expected {
+		t.Errorf("expected %s, got %s", expected, stmt)
+	}
+}
+
+func TestParseGrantFromRowDoubleQuotes(t *testing.T) {
+	grant, err := parseGrantFromRow(`GRANT SELECT ("my col"), INSERT ON "my.db"."t" TO "jdoe"@"10.0.%" WITH GRANT OPTION`)
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	tableGrant := grant.(*TablePrivilegeGrant)
+	expected := &TablePrivilegeGrant{
+		Database:   "my.db",
+		Table:      "t",
+		Privileges: []string{"INSERT", "SELECT(`MY COL`)"},
+		Grant:      true,
+		UserOrRole: UserOrRole{Name: "jdoe", Host: "10.0.%"},
+		TLSOption:  "NONE",
+	}
+	if !reflect.DeepEqual(tableGrant, expected) {
+		t.Errorf("expected %v, got %v", expected, tableGrant)
+	}
+
+	grant, err = parseGrantFromRow(`GRANT EXECUTE ON PROCEDURE "app"."p" TO "x""y"@"%"`)
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if g := grant.(*ProcedurePrivilegeGrant); g.Database != "app" || g.CallableName != "p" || g.UserOrRole != (UserOrRole{Name: `x"y`, Host: "%"}) {
+		t.Errorf("unexpected procedure grant %v", g)
+	}
+
+	grant, err = parseGrantFromRow(`GRANT "dev"@"%","ops"@"localhost" TO "jdoe"@"%"`)
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if roles := grant.(*RoleGrant).Roles; !reflect.DeepEqual(roles, []string{"dev", "ops@localhost"}) {
+		t.Errorf("unexpected roles %v", roles)
+	}
+}
+
+func TestNormalizeColumnPrivileges(t *testing.T) {
+	for _, tc := range []struct {
+		privileges string
+		expected   []string
+	}{
+		{"SELECT(b,a)", []string{"SELECT(`A`, `B`)"}},
+		{"SELECT (`my col`, `order`), INSERT", []string{"INSERT", "SELECT(`MY COL`, `ORDER`)"}},
+		{"SELECT (`a,b`, `c)`), UPDATE (`x``y`)", []string{"SELECT(`A,B`, `C)`)", "UPDATE(`X``Y`)"}},
+		{"SELECT (`allowed`)", []string{"SELECT(`ALLOWED`)"}},
+		{"ALL", []string{"ALL PRIVILEGES"}},
+	} {
+		if privileges := normalizePerms(extractPermTypes(tc.privileges)); !reflect.DeepEqual(privileges, tc.expected) {
+			t.Errorf("normalizing %s: expected %q, got %q", tc.privileges, tc.expected, privileges)
+		}
+	}
+
+	grant, err := parseGrantFromRow("GRANT SELECT (`my col`, `order`), INSERT (`a,b`) ON `app`.`t` TO `jdoe`@`%`")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	expected := []string{"INSERT(`A,B`)", "SELECT(`MY COL`, `ORDER`)"}
+	if privileges := grant.(MySQLGrantWithPrivileges).GetPrivileges(); !reflect.DeepEqual(privileges, expected) {
+		t.Errorf("expected %q, got %q", expected, privileges)
+	}
+}
+
+func TestParseVendorPrivileges(t *testing.T) {
+	for stmt, expected := range map[string][]string{
+		// MariaDB 10.5+
+		"GRANT BINLOG MONITOR, SLAVE MONITOR, REPLICATION MASTER ADMIN ON *.* TO `repl`@`%`": {"BINLOG MONITOR", "REPLICATION MASTER ADMIN", "SLAVE MONITOR"},
+		"GRANT SELECT, DELETE HISTORY ON `app`.* TO `app`@`%`":                               {"DELETE HISTORY", "SELECT"},
+		// Aurora MySQL 5.7
+		"GRANT LOAD FROM S3, SELECT INTO S3, INVOKE LAMBDA ON *.* TO 'etl'@'%'": {"INVOKE LAMBDA", "LOAD FROM S3", "SELECT INTO S3"},
+		// Aurora MySQL 3 and Percona Server 8.0
+		"GRANT AWS_LOAD_S3_ACCESS,AWS_SELECT_S3_ACCESS ON *.* TO `etl`@`%`":                              {"AWS_LOAD_S3_ACCESS", "AWS_SELECT_S3_ACCESS"},
+		"GRANT BACKUP_ADMIN,SYSTEM_VARIABLES_ADMIN ON *.* TO `xtrabackup`@`localhost` WITH GRANT OPTION": {"BACKUP_ADMIN", "SYSTEM_VARIABLES_ADMIN"},
+	} {
+		grant, err := parseGrantFromRow(stmt)
+		if err != nil {
+			t.Fatalf("parseGrantFromRow(%s) failed: %v", stmt, err)
+		}
+		if privileges := grant.(MySQLGrantWithPrivileges).GetPrivileges(); !reflect.DeepEqual(privileges, expected) {
+			t.Errorf("parseGrantFromRow(%s): expected %q, got %q", stmt, expected, privileges)
+		}
+	}
+
+	// Configured privileges normalize to what SHOW GRANTS returns.
+	expected := []string{"INVOKE LAMBDA", "LOAD FROM S3", "SELECT(`ID`)"}
+	if privileges := normalizePerms([]string{"load from s3", " Invoke  Lambda", "select  (id)"}); !reflect.DeepEqual(privileges, expected) {
+		t.Errorf("expected %q, got %q", expected, privileges)
+	}
+}
+
+func TestFilterIgnoredPrivileges(t *testing.T) {
+	for _, tc := range []struct {
+		privileges, ignored, configured, expected []string
+	}{
+		{[]string{"INSERT", "SELECT"}, nil, []string{"SELECT"}, []string{"INSERT", "SELECT"}},
+		{[]string{"INSERT", "RELOAD", "SELECT"}, []string{"RELOAD"}, []string{"SELECT"}, []string{"INSERT", "SELECT"}},
+		{[]string{"RELOAD", "SELECT"}, []string{"RELOAD", "SELECT"}, []string{"SELECT"}, []string{"SELECT"}},
+		{[]string{"LOAD FROM S3"}, []string{"LOAD FROM S3"}, nil, []string{}},
+	} {
+		if actual := filterIgnoredPrivileges(tc.privileges, tc.ignored, tc.configured); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("filterIgnoredPrivileges(%q, %q, %q) = %q, expected %q", tc.privileges, tc.ignored, tc.configured, actual, tc.expected)
+		}
+	}
+}
+
+func TestUsageGrant(t *testing.T) {
+	grant, err := parseGrantFromRow("GRANT USAGE ON *.* TO `jdoe`@`%` REQUIRE SSL")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	if !isUsageGrant(grant) || grant.(*TablePrivilegeGrant).TLSOption != "SSL" {
+		t.Errorf("expected a USAGE grant requiring SSL, got %v", grant)
+	}
+	if grant, err := parseGrantFromRow("GRANT USAGE ON `app`.* TO `jdoe`@`%`"); grant != nil || err != nil {
+		t.Errorf("expected USAGE on a database to be ignored, got %v, %v", grant, err)
+	}
+
+	usage := &TablePrivilegeGrant{
+		Database:   "*",
+		Table:      "*",
+		Privileges: []string{},
+		UserOrRole: UserOrRole{Name: "jdoe", Host: "%"},
+		TLSOption:  "X509",
+	}
+	if stmt := usage.SQLGrantStatement(); stmt != "GRANT USAGE ON *.* TO 'jdoe'@'%' REQUIRE X509" {
+		t.Errorf("unexpected grant statement %s", stmt)
+	}
+	if stmt := usage.SQLRevokeStatement(); stmt != "GRANT USAGE ON *.* TO 'jdoe'@'%' REQUIRE NONE" {
+		t.Errorf("unexpected revoke statement %s", stmt)
+	}
+}
+
+func TestStripResourceLimits(t *testing.T) {
+	for stmt, expected := range map[string]string{
+		"GRANT USAGE ON *.* TO 'jdoe'@'%' WITH MAX_QUERIES_PER_HOUR 10 MAX_USER_CONNECTIONS 2":   "GRANT USAGE ON *.* TO 'jdoe'@'%'",
+		"GRANT SELECT ON *.* TO `jdoe`@`%` REQUIRE SSL WITH GRANT OPTION MAX_UPDATES_PER_HOUR 5": "GRANT SELECT ON *.* TO `jdoe`@`%` REQUIRE SSL WITH GRANT OPTION",
+		"GRANT USAGE ON *.* TO `jdoe`@`%` WITH MAX_STATEMENT_TIME 1.500000":                      "GRANT USAGE ON *.* TO `jdoe`@`%`",
+		"GRANT SELECT ON `db`.* TO `jdoe`@`%` WITH GRANT OPTION":                                 "GRANT SELECT ON `db`.* TO `jdoe`@`%` WITH GRANT OPTION",
+	} {
+		if actual := stripResourceLimits(stmt); actual != expected {
+			t.Errorf("stripResourceLimits(%s) = %s, expected %s", stmt, actual, expected)
+		}
+	}
+
+	grant, err := parseGrantFromRow("GRANT PROCESS ON *.* TO 'jdoe'@'%' WITH GRANT OPTION MAX_QUERIES_PER_HOUR 10 MAX_CONNECTIONS_PER_HOUR 5")
+	if err != nil {
+		t.Fatalf("parseGrantFromRow failed: %v", err)
+	}
+	tableGrant := grant.(*TablePrivilegeGrant)
+	if !reflect.DeepEqual(tableGrant.Privileges, []string{"PROCESS"}) || !tableGrant.Grant || tableGrant.UserOrRole != (UserOrRole{Name: "jdoe", Host: "%"}) {
+		t.Errorf("unexpected grant %v", tableGrant)
+	}
+}
+
+func TestParseRoutineDatabase(t *testing.T) {
+	for _, tc := range []struct {
+		database               string
+		objectT, name, routine string
+	}{
+		{"PROCEDURE app.proc", "PROCEDURE", "app", "proc"},
+		{"function app", "function", "app", ""},
+		{"PROCEDURE `my.schema`.proc", "PROCEDURE", "my.schema", "proc"},
+		{"PROCEDURE `my.schema`.`my.proc`", "PROCEDURE", "my.schema", "my.proc"},
+		{"FUNCTION `my.schema`", "FUNCTION", "my.schema", ""},
+		{"PROCEDURE `we``ird`.proc", "PROCEDURE", "we`ird", "proc"},
+	} {
+		grant, err := parseRoutineDatabase(tc.database)
+		if err != nil {
+			t.Fatalf("parseRoutineDatabase(%s) failed: %v", tc.database, err)
+		}
+		if grant == nil || string(grant.ObjectT) != tc.objectT || grant.Database != tc.name || grant.CallableName != tc.routine {
+			t.Errorf("parseRoutineDatabase(%s) = %v; expected %s %s.%s", tc.database, grant, tc.objectT, tc.name, tc.routine)
+		}
+	}
+
+	for _, database := range []string{"app", "my.schema", "procedures"} {
+		if grant, err := parseRoutineDatabase(database); grant != nil || err != nil {
+			t.Errorf("parseRoutineDatabase(%s) = %v, %v; expected no routine", database, grant, err)
+		}
+	}
+	for _, database := range []string{"PROCEDURE my.schema.proc", "PROCEDURE `my.schema", "PROCEDURE `a`b"} {
+		if _, err := parseRoutineDatabase(database); err == nil {
+			t.Errorf("parseRoutineDatabase(%s) expected an error", database)
+		}
+	}
+}
+
+func TestGrantIdIPv6(t *testing.T) {
+	user := UserOrRole{Name: "jdoe", Host: "2001:db8::1"}
+	tableGrant := &TablePrivilegeGrant{Database: "app", Table: "*", UserOrRole: user}
+	if id := tableGrant.GetId(); id != "jdoe@[2001:db8::1]:`app`:*" {
+		t.Errorf("unexpected ID %s", id)
+	}
+	procedureGrant := &ProcedurePrivilegeGrant{Database: "app", ObjectT: kProcedure, CallableName: "p", UserOrRole: user}
+	if id := procedureGrant.GetId(); id != "jdoe@[2001:db8::1]:`app`:`p`" {
+		t.Errorf("unexpected ID %s", id)
+	}
+	if host := unbracketHost("[2001:db8::1]"); host != "2001:db8::1" {
+		t.Errorf("unexpected host %s", host)
+	}
+	if host := unbracketHost("localhost"); host != "localhost" {
+		t.Errorf("unexpected host %s", host)
+	}
+}
+
+func TestGrantsConflict(t *testing.T) {
+	user := UserOrRole{Name: "jdoe", Host: "%"}
+	procedure := func(objectT ObjectT, name string) *ProcedurePrivilegeGrant {
+		return &ProcedurePrivilegeGrant{Database: "db", ObjectT: objectT, CallableName: name, Privileges: []string{"EXECUTE"}, UserOrRole: user}
+	}
+	table := func(database, name string) *TablePrivilegeGrant {
+		return &TablePrivilegeGrant{Database: database, Table: name, Privileges: []string{"SELECT"}, UserOrRole: user}
+	}
+
+	for _, tc := range []struct {
+		a, b     MySQLGrant
+		conflict bool
+	}{
+		{table("db", "*"), table("db", "*"), true},
+		{table("db", "*"), table("db", "t"), false},
+		{table("db", "*"), table("other", "*"), false},
+		{procedure(kProcedure, "p"), procedure(kProcedure, "p"), true},
+		{procedure(kProcedure, "p"), procedure(kProcedure, "q"), false},
+		{procedure(kProcedure, "p"), procedure(kFunction, "p"), false},
+		{table("db", "*"), procedure(kProcedure, "p"), false},
+	} {
+		if conflict := grantsConflict(tc.a, tc.b); conflict != tc.conflict {
+			t.Errorf("grantsConflict(%v, %v) = %t, expected %t", tc.a, tc.b, conflict, tc.conflict)
+		}
+	}
+
+	roles := coveredRoles([]string{"dev", "ops@localhost"}, []string{"dev", "admin", "ops@localhost"})
+	if strings.Join(roles, ",") != "dev,ops@localhost" {
+		t.Errorf("unexpected covered roles %v", roles)
+	}
+	if roles := coveredRoles([]string{"dev"}, []string{"admin"}); len(roles) != 0 {
+		t.Errorf("expected no covered roles, got %v", roles)
+	}
+}
+
+func TestPrivilegeChangeStatements(t *testing.T) {
+	for _, tc := range []struct {
+		old, new   []interface{}
+		grant      bool
+		statements []string
+	}{
+		{
+			[]interface{}{"SELECT", "UPDATE"}, []interface{}{"SELECT", "INSERT", "DELETE"}, false,
+			[]string{
+				"REVOKE UPDATE ON `db`.* FROM 'jdoe'@'%'",
+				"GRANT DELETE, INSERT ON `db`.* TO 'jdoe'@'%'",
+			},
+		},
+		{
+			[]interface{}{"SELECT"}, []interface{}{"SELECT", "INSERT"}, true,
+			[]string{"GRANT INSERT ON `db`.* TO 'jdoe'@'%' WITH GRANT OPTION"},
+		},
+		{
+			[]interface{}{"SELECT", "UPDATE"}, []interface{}{"SELECT"}, true,
+			[]string{
+				"REVOKE UPDATE, GRANT OPTION ON `db`.* FROM 'jdoe'@'%'",
+				"GRANT SELECT ON `db`.* TO 'jdoe'@'%' WITH GRANT OPTION",
+			},
+		},
+	} {
+		newPrivs := schema.NewSet(schema.HashString, tc.new)
+		grant := &TablePrivilegeGrant{
+			Database:   "db",
+			Table:      "*",
+			Privileges: normalizePerms(setToArray(newPrivs)),
+			Grant:      tc.grant,
+			UserOrRole: UserOrRole{Name: "jdoe", Host: "%"},
+		}
+		statements, err := privilegeChangeStatements(grant, schema.NewSet(schema.HashString, tc.old), newPrivs)
+		if err != nil {
+			t.Fatalf("privilegeChangeStatements failed: %v", err)
+		}
+		if !reflect.DeepEqual(statements, tc.statements) {
+			t.Errorf("expected %q, got %q", tc.statements, statements)
+		}
+	}
+}
+
+// Rows of SHOW GRANTS on Percona Server.
+func TestParseGrantFromRowPercona(t *testing.T) {
+	for stmt, expected := range map[string]*TablePrivilegeGrant{
+		// 8.0 with the data masking component and backup locks.
+		"GRANT BACKUP_ADMIN,MASKING_DICTIONARIES_ADMIN ON *.* TO `backup`@`localhost`": {
+			Database:   "*",
+			Table:      "*",
+			Privileges: []string{"BACKUP_ADMIN", "MASKING_DICTIONARIES_ADMIN"},
+			UserOrRole: UserOrRole{Name: "backup", Host: "localhost"},
+			TLSOption:  "NONE",
+		},
+		"GRANT RELOAD, PROCESS, LOCK TABLES, REPLICATION CLIENT ON *.* TO `xtrabackup`@`localhost`": {
+			Database:   "*",
+			Table:      "*",
+			Privileges: []string{"LOCK TABLES", "PROCESS", "RELOAD", "REPLICATION CLIENT"},
+			UserOrRole: UserOrRole{Name: "xtrabackup", Host: "localhost"},
+			TLSOption:  "NONE",
+		},
+		// 5.7 lists auth_pam users with their auth string.
+		"GRANT USAGE ON *.* TO 'dev'@'%' IDENTIFIED WITH 'auth_pam' AS 'mysqld, developers=dev_user'": {
+			Database:   "*",
+			Table:      "*",
+			Privileges: []string{},
+			UserOrRole: UserOrRole{Name: "dev", Host: "%"},
+			TLSOption:  "NONE",
+		},
+		"GRANT SELECT, INSERT ON `app`.* TO 'dev_user'@'%'": {
+			Database:   "app",
+			Table:      "*",
+			Privileges: []string{"INSERT", "SELECT"},
+			UserOrRole: UserOrRole{Name: "dev_user", Host: "%"},
+			TLSOption:  "NONE",
+		},
+	} {
+		grant, err := parseGrantFromRow(stmt)
+		if err != nil {
+			t.Fatalf("parseGrantFromRow(%s) failed: %v", stmt, err)
+		}
+		if !reflect.DeepEqual(grant, expected) {
+			t.Errorf("parseGrantFromRow(%s) = %v; expected %v", stmt, grant, expected)
+		}
+	}
+
+	// The PAM group mapping proxies the group users to the anonymous user.
+	for _, stmt := range []string{
+		"GRANT PROXY ON 'dev_user'@'%' TO ''@''",
+		"GRANT PROXY ON ``@`` TO `root`@`localhost` WITH GRANT OPTION",
+	} {
+		grant, err := parseGrantFromRow(stmt)
+		if err != nil || grant != nil {
+			t.Errorf("parseGrantFromRow(%s) = %v, %v; expected it to be ignored", stmt, grant, err)
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const groupReplicationVariablePrefix = "group_replication_"
+
+func resourceGroupReplication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateGroupReplication,
+		UpdateContext: UpdateGroupReplication,
+		ReadContext:   ReadGroupReplication,
+		DeleteContext: DeleteGroupReplication,
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"local_address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_seeds": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"single_primary_mode": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"variables": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateGroupReplicationVariables,
+			},
+			"bootstrap": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"recovery_user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"recovery_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"started": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"member_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"member_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"member_role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateGroupReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkGroupReplicationSupported(ctx, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setGroupReplicationVariables(ctx, db, groupReplicationVariables(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("group_name").(string))
+
+	if d.Get("started").(bool) {
+		if err := startGroupReplication(ctx, db, d, d.Get("bootstrap").(bool)); err != nil {
+			return diag.Errorf("failed starting group replication: %v", err)
+		}
+	}
+
+	return ReadGroupReplication(ctx, d, meta)
+}
+
+func UpdateGroupReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, _, state, err := showGroupReplicationMember(ctx, db)
+	if err != nil {
+		return diag.Errorf("failed reading group replication member: %v", err)
+	}
+	reconfigure := d.HasChangesExcept("started", "bootstrap", "recovery_user", "recovery_password")
+	wasStarted := groupReplicationRunning(state)
+
+	// Most group replication variables can't change while the member runs.
+	if reconfigure && wasStarted {
+		if err := stopGroupReplication(ctx, db); err != nil {
+			return diag.Errorf("failed stopping group replication: %v", err)
+		}
+		wasStarted = false
+	}
+
+	if reconfigure {
+		oldVariablesIf, _ := d.GetChange("variables")
+		removed := []string{}
+		for name := range oldVariablesIf.(map[string]interface{}) {
+			if _, ok := d.Get("variables").(map[string]interface{})[name]; !ok {
+				removed = append(removed, name)
+			}
+		}
+		sort.Strings(removed)
+		if err := resetGroupReplicationVariables(ctx, db, removed); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setGroupReplicationVariables(ctx, db, groupReplicationVariables(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	started := d.Get("started").(bool)
+	if started && !wasStarted {
+		// Only the first start bootstraps the group; bootstrapping a group
+		// that already runs elsewhere would split it.
+		if err := startGroupReplication(ctx, db, d, false); err != nil {
+			return diag.Errorf("failed starting group replication: %v", err)
+		}
+	} else if !started && wasStarted {
+		if err := stopGroupReplication(ctx, db); err != nil {
+			return diag.Errorf("failed stopping group replication: %v", err)
+		}
+	}
+
+	return ReadGroupReplication(ctx, d, meta)
+}
+
+func ReadGroupReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	variables, err := showGroupReplicationVariables(ctx, db)
+	if err != nil {
+		return diag.Errorf("failed reading group replication variables: %v", err)
+	}
+	if !strings.EqualFold(variables["group_replication_group_name"], d.Id()) {
+		log.Printf("[WARN] Group replication (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("group_name", d.Id())
+	d.Set("local_address", variables["group_replication_local_address"])
+	seeds := []string{}
+	if value := variables["group_replication_group_seeds"]; value != "" {
+		seeds = strings.Split(value, ",")
+	}
+	d.Set("group_seeds", seeds)
+	d.Set("single_primary_mode", variables["group_replication_single_primary_mode"] == "ON")
+
+	configured := map[string]interface{}{}
+	for name, value := range d.Get("variables").(map[string]interface{}) {
+		current, ok := variables[name]
+		if !ok {
+			continue
+		}
+		// Boolean variables read back as ON and OFF, whichever way they were
+		// set.
+		if normalizeGroupReplicationValue(value.(string)) == current {
+			current = value.(string)
+		}
+		configured[name] = current
+	}
+	d.Set("variables", configured)
+
+	memberID, role, state, err := showGroupReplicationMember(ctx, db)
+	if err != nil {
+		return diag.Errorf("failed reading group replication member: %v", err)
+	}
+	d.Set("member_id", memberID)
+	d.Set("member_state", state)
+	d.Set("member_role", role)
+	d.Set("started", groupReplicationRunning(state))
+
+	return nil
+}
+
+func DeleteGroupReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := stopGroupReplication(ctx, db); err != nil {
+		return diag.Errorf("failed stopping group replication: %v", err)
+	}
+
+	names := []string{}
+	for name := range groupReplicationVariables(d) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if err := resetGroupReplicationVariables(ctx, db, names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func checkGroupReplicationSupported(ctx context.Context, meta interface{}) error {
+	if getFlavorFromMeta(ctx, meta) != flavorMySQL {
+		return fmt.Errorf("group replication is not supported on %s", getFlavorFromMeta(ctx, meta))
+	}
+	// The variables are persisted, so the member keeps them across restarts.
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return fmt.Errorf("group replication requires MySQL 8.0 or newer")
+	}
+	return nil
+}
+
+// groupReplicationVariables returns the variables configured by the resource,
+// by their full names.
+func groupReplicationVariables(d *schema.ResourceData) map[string]string {
+	seeds := []string{}
+	for _, seed := range d.Get("group_seeds").([]interface{}) {
+		seeds = append(seeds, seed.(string))
+	}
+	singlePrimaryMode := "OFF"
+	if d.Get("single_primary_mode").(bool) {
+		singlePrimaryMode = "ON"
+	}
+
+	variables := map[string]string{
+		"group_replication_group_name":          d.Get("group_name").(string),
+		"group_replication_local_address":       d.Get("local_address").(string),
+		"group_replication_group_seeds":         strings.Join(seeds, ","),
+		"group_replication_single_primary_mode": singlePrimaryMode,
+	}
+	for name, value := range d.Get("variables").(map[string]interface{}) {
+		variables[name] = value.(string)
+	}
+	return variables
+}
+
+// groupReplicationStatements returns the statements persisting the
+// variables. single_primary_mode is turned off before the other variables, as
+// e.g. enforce_update_everywhere_checks can only be turned on without it.
+func groupReplicationStatements(variables map[string]string) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		first := func(name string) bool {
+			return name == "group_replication_single_primary_mode" && variables[name] == "OFF"
+		}
+		if first(names[i]) != first(names[j]) {
+			return first(names[i])
+		}
+		return names[i] < names[j]
+	})
+
+	statements := make([]string, 0, len(names))
+	for _, name := range names {
+		statements = append(statements, fmt.Sprintf("SET PERSIST %s = %s", quoteIdentifier(name), variableValueSQL(variables[name])))
+	}
+	return statements
+}
+
+func setGroupReplicationVariables(ctx context.Context, db *sql.DB, variables map[string]string) error {
+	for _, stmtSQL := range groupReplicationStatements(variables) {
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return fmt.Errorf("failed setting group replication variable: %w", err)
+		}
+	}
+	return nil
+}
+
+func resetGroupReplicationVariables(ctx context.Context, db *sql.DB, names []string) error {
+	for _, name := range names {
+		stmtSQL := fmt.Sprintf("RESET PERSIST IF EXISTS %s", quoteIdentifier(name))
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return fmt.Errorf("failed resetting group replication variable: %w", err)
+		}
+	}
+	return nil
+}
+
+func showGroupReplicationVariables(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	stmtSQL := "SELECT VARIABLE_NAME, VARIABLE_VALUE FROM performance_schema.global_variables WHERE VARIABLE_NAME LIKE 'group\\_replication\\_%'"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variables := map[string]string{}
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		variables[strings.ToLower(name)] = value
+	}
+	return variables, rows.Err()
+}
+
+// showGroupReplicationMember returns the ID, role and state of the connected
+// member. Members that never started are OFFLINE.
+func showGroupReplicationMember(ctx context.Context, db *sql.DB) (string, string, string, error) {
+	var memberID, role, state string
+	stmtSQL := "SELECT MEMBER_ID, MEMBER_ROLE, MEMBER_STATE FROM performance_schema.replication_group_members WHERE MEMBER_ID = @@GLOBAL.server_uuid"
+	logStatement(ctx, stmtSQL)
+	err := db.QueryRowContext(ctx, stmtSQL).Scan(&memberID, &role, &state)
+	if err != nil && err != sql.ErrNoRows {
+		return "", "", "", err
+	}
+	if state == "" {
+		state = "OFFLINE"
+	}
+	return memberID, role, state, nil
+}
+
+// groupReplicationRunning tells whether the member in the state has to be
+// stopped before it's reconfigured.
+func groupReplicationRunning(state string) bool {
+	return state != "OFFLINE" && state != "ERROR"
+}
+
+// startGroupReplication starts the member, bootstrapping the group first if
+// asked to.
+func startGroupReplication(ctx context.Context, db *sql.DB, d *schema.ResourceData, bootstrap bool) error {
+	if bootstrap {
+		stmtSQL := "SET GLOBAL group_replication_bootstrap_group = ON"
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return err
+		}
+		defer func() {
+			stmtSQL := "SET GLOBAL group_replication_bootstrap_group = OFF"
+			logStatement(ctx, stmtSQL)
+			if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+				log.Printf("[WARN] Failed turning off group_replication_bootstrap_group: %v", err)
+			}
+		}()
+	}
+
+	stmtSQL := "START GROUP_REPLICATION"
+	loggedSQL := stmtSQL
+	if user := d.Get("recovery_user").(string); user != "" {
+		stmtSQL += " USER = " + quoteLiteral(user)
+		loggedSQL = stmtSQL
+		if password := d.Get("recovery_password").(string); password != "" {
+			stmtSQL += ", PASSWORD = " + quoteLiteral(password)
+			loggedSQL += ", PASSWORD = <sensitive>"
+		}
+	}
+	logStatement(ctx, loggedSQL)
+
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+func stopGroupReplication(ctx context.Context, db *sql.DB) error {
+	stmtSQL := "STOP GROUP_REPLICATION"
+	logStatement(ctx, stmtSQL)
+
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+// normalizeGroupReplicationValue returns the value as the server reads it
+// back.
+func normalizeGroupReplicationValue(value string) string {
+	switch strings.ToUpper(value) {
+	case "1", "ON", "TRUE":
+		return "ON"
+	case "0", "OFF", "FALSE":
+		return "OFF"
+	}
+	return value
+}
+
+func validateGroupReplicationVariables(val any, key string) (warns []string, errs []error) {
+	for name := range val.(map[string]interface{}) {
+		if !strings.HasPrefix(name, groupReplicationVariablePrefix) {
+			errs = append(errs, fmt.Errorf("%q contains %q, which isn't a group replication variable", key, name))
+		}
+		switch name {
+		case "group_replication_group_name", "group_replication_local_address", "group_replication_group_seeds",
+			"group_replication_single_primary_mode", "group_replication_bootstrap_group":
+			errs = append(errs, fmt.Errorf("%q contains %q, which is managed by another argument", key, name))
+		}
+	}
+	return
+}
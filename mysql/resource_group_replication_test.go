@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGroupReplicationStatements(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceGroupReplication().Schema, map[string]interface{}{
+		"group_name":          "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		"local_address":       "db1:33061",
+		"group_seeds":         []interface{}{"db1:33061", "db2:33061"},
+		"single_primary_mode": false,
+		"variables": map[string]interface{}{
+			"group_replication_enforce_update_everywhere_checks": "ON",
+			"group_replication_member_weight":                    "70",
+		},
+	})
+
+	got := groupReplicationStatements(groupReplicationVariables(d))
+	want := []string{
+		"SET PERSIST `group_replication_single_primary_mode` = 'OFF'",
+		"SET PERSIST `group_replication_enforce_update_everywhere_checks` = 'ON'",
+		"SET PERSIST `group_replication_group_name` = 'aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee'",
+		"SET PERSIST `group_replication_group_seeds` = 'db1:33061,db2:33061'",
+		"SET PERSIST `group_replication_local_address` = 'db1:33061'",
+		"SET PERSIST `group_replication_member_weight` = 70",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupReplicationStatements() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateGroupReplicationVariables(t *testing.T) {
+	tests := []struct {
+		variables map[string]interface{}
+		errs      int
+	}{
+		{map[string]interface{}{"group_replication_member_weight": "70"}, 0},
+		{map[string]interface{}{"max_connections": "100"}, 1},
+		{map[string]interface{}{"group_replication_group_name": "x"}, 1},
+		{map[string]interface{}{"group_replication_bootstrap_group": "ON"}, 1},
+	}
+
+	for _, tt := range tests {
+		if _, errs := validateGroupReplicationVariables(tt.variables, "variables"); len(errs) != tt.errs {
+			t.Errorf("validateGroupReplicationVariables(%v) = %v, want %d errors", tt.variables, errs, tt.errs)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceHistogram() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdateHistogram,
+		UpdateContext: CreateOrUpdateHistogram,
+		ReadContext:   ReadHistogram,
+		DeleteContext: DeleteHistogram,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportHistogram,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"table": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"column": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"buckets": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 1024),
+			},
+			"histogram_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateOrUpdateHistogram(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkHistogramsSupported(ctx, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	table := d.Get("table").(string)
+	column := d.Get("column").(string)
+	stmtSQL := fmt.Sprintf("UPDATE HISTOGRAM ON %s WITH %d BUCKETS", quoteIdentifier(column), d.Get("buckets").(int))
+
+	// Updating a histogram replaces it, so it's also how buckets change.
+	if err := analyzeTable(ctx, db, database, table, stmtSQL); err != nil {
+		return diag.Errorf("failed updating histogram: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", database, table, column))
+
+	return ReadHistogram(ctx, d, meta)
+}
+
+func ReadHistogram(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	table := d.Get("table").(string)
+	column := d.Get("column").(string)
+	stmtSQL := `SELECT JSON_EXTRACT(HISTOGRAM, '$."number-of-buckets-specified"'),
+		JSON_UNQUOTE(JSON_EXTRACT(HISTOGRAM, '$."histogram-type"')),
+		JSON_UNQUOTE(JSON_EXTRACT(HISTOGRAM, '$."last-updated"'))
+		FROM INFORMATION_SCHEMA.COLUMN_STATISTICS WHERE SCHEMA_NAME = ? AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	logStatement(ctx, stmtSQL, database, table, column)
+
+	var buckets int
+	var histogramType, lastUpdated string
+	err = db.QueryRowContext(ctx, stmtSQL, database, table, column).Scan(&buckets, &histogramType, &lastUpdated)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Histogram (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading histogram: %v", err)
+	}
+
+	d.Set("buckets", buckets)
+	d.Set("histogram_type", histogramType)
+	d.Set("last_updated", lastUpdated)
+
+	return nil
+}
+
+func DeleteHistogram(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Dropping the table or column drops the histogram with it.
+	stmtSQL := "DROP HISTOGRAM ON " + quoteIdentifier(d.Get("column").(string))
+	err = analyzeTable(ctx, db, d.Get("database").(string), d.Get("table").(string), stmtSQL)
+	if err != nil && !strings.Contains(err.Error(), "No histogram statistics") && !strings.Contains(err.Error(), "doesn't exist") {
+		return diag.Errorf("failed dropping histogram: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportHistogram(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	parts := strings.SplitN(id, ".", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("wrong ID format %s (expected database.table.column)", id)
+	}
+
+	d.Set("database", parts[0])
+	d.Set("table", parts[1])
+	d.Set("column", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func checkHistogramsSupported(ctx context.Context, meta interface{}) error {
+	if getFlavorFromMeta(ctx, meta) != flavorMySQL {
+		return fmt.Errorf("histograms are not supported on %s", getFlavorFromMeta(ctx, meta))
+	}
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return fmt.Errorf("histograms require MySQL 8.0 or newer")
+	}
+	return nil
+}
+
+// analyzeTable runs ANALYZE TABLE with the clause. It reports failures in its
+// result rather than as errors, so those are turned into errors here.
+func analyzeTable(ctx context.Context, db *sql.DB, database, table, clause string) error {
+	stmtSQL := fmt.Sprintf("ANALYZE TABLE %s.%s %s", quoteIdentifier(database), quoteIdentifier(table), clause)
+	logStatement(ctx, stmtSQL)
+
+	rows, err := queryStatusRows(ctx, db, stmtSQL)
+	if err != nil {
+		return err
+	}
+	return analyzeTableError(rows)
+}
+
+// analyzeTableError returns the first error in the result of ANALYZE TABLE.
+func analyzeTableError(rows []map[string]string) error {
+	for _, row := range rows {
+		if strings.EqualFold(row["Msg_type"], "error") {
+			return fmt.Errorf("%s", row["Msg_text"])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHistogram_basic(t *testing.T) {
+	dbName := "tf_test_histogram"
+	resourceName := "mysql_histogram.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDatabaseCheckDestroy(dbName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHistogramConfig(dbName, 16),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", dbName+".orders.status"),
+					resource.TestCheckResourceAttr(resourceName, "buckets", "16"),
+					resource.TestCheckResourceAttrSet(resourceName, "histogram_type"),
+				),
+			},
+			{
+				Config: testAccHistogramConfig(dbName, 32),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "buckets", "32"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAnalyzeTableError(t *testing.T) {
+	rows := []map[string]string{
+		{"Table": "app.orders", "Op": "histogram", "Msg_type": "status", "Msg_text": "Histogram statistics created for column 'status'."},
+	}
+	if err := analyzeTableError(rows); err != nil {
+		t.Errorf("analyzeTableError() = %v, want nil", err)
+	}
+
+	rows = append(rows, map[string]string{"Table": "app.orders", "Op": "histogram", "Msg_type": "Error", "Msg_text": "The column 'missing' does not exist."})
+	if err := analyzeTableError(rows); err == nil || err.Error() != "The column 'missing' does not exist." {
+		t.Errorf("analyzeTableError() = %v, want the error row", err)
+	}
+}
+
+func testAccHistogramConfig(dbName string, buckets int) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_sql" "orders" {
+  name       = "orders"
+  create_sql = "CREATE TABLE ${mysql_database.test.name}.orders (id INT PRIMARY KEY, status VARCHAR(16))"
+  delete_sql = "DROP TABLE ${mysql_database.test.name}.orders"
+}
+
+resource "mysql_histogram" "test" {
+  database = mysql_database.test.name
+  table    = "orders"
+  column   = "status"
+  buckets  = %d
+
+  depends_on = [mysql_sql.orders]
+}
+`, dbName, buckets)
+}
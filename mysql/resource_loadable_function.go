@@ -0,0 +1,134 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// loadableFunctionReturnTypes maps mysql.func.ret to the types of CREATE
+// FUNCTION ... RETURNS.
+var loadableFunctionReturnTypes = map[int]string{
+	0: "STRING",
+	1: "REAL",
+	2: "INTEGER",
+	4: "DECIMAL",
+}
+
+func resourceLoadableFunction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateLoadableFunction,
+		ReadContext:   ReadLoadableFunction,
+		DeleteContext: DeleteLoadableFunction,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"returns": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"STRING", "INTEGER", "REAL", "DECIMAL"}, false),
+			},
+			"soname": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"aggregate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func CreateLoadableFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "loadable functions"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	stmtSQL := "CREATE "
+	if d.Get("aggregate").(bool) {
+		stmtSQL += "AGGREGATE "
+	}
+	stmtSQL += fmt.Sprintf("FUNCTION %s RETURNS %s SONAME ?", quoteIdentifier(name), d.Get("returns").(string))
+	logStatement(ctx, stmtSQL, d.Get("soname").(string))
+
+	if _, err := db.ExecContext(ctx, stmtSQL, d.Get("soname").(string)); err != nil {
+		return diag.Errorf("failed creating loadable function: %v", err)
+	}
+
+	d.SetId(name)
+
+	return ReadLoadableFunction(ctx, d, meta)
+}
+
+func ReadLoadableFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT ret, dl, type FROM mysql.func WHERE name = ?"
+	logStatement(ctx, stmtSQL, d.Id())
+
+	var ret int
+	var soname, functionType string
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&ret, &soname, &functionType)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Loadable function (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading loadable function: %v", err)
+	}
+
+	returns, ok := loadableFunctionReturnTypes[ret]
+	if !ok {
+		return diag.Errorf("loadable function %s has unknown return type %d", d.Id(), ret)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("returns", returns)
+	d.Set("soname", soname)
+	d.Set("aggregate", functionType == "aggregate")
+
+	return nil
+}
+
+func DeleteLoadableFunction(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "DROP FUNCTION IF EXISTS " + quoteIdentifier(d.Id())
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed deleting loadable function: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLoadableFunction_basic(t *testing.T) {
+	functionName := "version_tokens_show"
+	resourceName := "mysql_loadable_function.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccLoadableFunctionCheckDestroy(functionName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLoadableFunctionConfig(functionName, "version_token.so"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", functionName),
+					resource.TestCheckResourceAttr(resourceName, "returns", "STRING"),
+					resource.TestCheckResourceAttr(resourceName, "soname", "version_token.so"),
+					resource.TestCheckResourceAttr(resourceName, "aggregate", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLoadableFunctionCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM mysql.func WHERE name = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading loadable functions: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("loadable function %s still exists after destroy", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccLoadableFunctionConfig(name string, soname string) string {
+	return fmt.Sprintf(`
+resource "mysql_loadable_function" "test" {
+  name    = "%s"
+  returns = "STRING"
+  soname  = "%s"
+}
+`, name, soname)
+}
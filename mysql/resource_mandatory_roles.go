@@ -0,0 +1,244 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Serializes read-modify-write cycles of mandatory_roles within one provider
+// process, so resources contributing different roles don't lose updates.
+var mandatoryRolesMutex sync.Mutex
+
+func resourceMandatoryRoles() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateMandatoryRoles,
+		UpdateContext: UpdateMandatoryRoles,
+		ReadContext:   ReadMandatoryRoles,
+		DeleteContext: DeleteMandatoryRoles,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportMandatoryRoles,
+		},
+		Schema: map[string]*schema.Schema{
+			"roles": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"activate_all_roles_on_login": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateMandatoryRoles(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roles := setToArray(d.Get("roles"))
+	if err := changeMandatoryRoles(ctx, db, roles, nil); err != nil {
+		return diag.Errorf("failed adding mandatory roles: %v", err)
+	}
+
+	if err := setActivateAllRolesOnLogin(ctx, db, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(mandatoryRolesId(roles))
+
+	return ReadMandatoryRoles(ctx, d, meta)
+}
+
+func UpdateMandatoryRoles(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("roles") {
+		oldRolesIf, newRolesIf := d.GetChange("roles")
+		oldRoles := oldRolesIf.(*schema.Set)
+		newRoles := newRolesIf.(*schema.Set)
+
+		toAdd := setToArray(newRoles.Difference(oldRoles))
+		toRemove := setToArray(oldRoles.Difference(newRoles))
+		if err := changeMandatoryRoles(ctx, db, toAdd, toRemove); err != nil {
+			return diag.Errorf("failed updating mandatory roles: %v", err)
+		}
+
+		d.SetId(mandatoryRolesId(setToArray(newRoles)))
+	}
+
+	if d.HasChange("activate_all_roles_on_login") {
+		if err := setActivateAllRolesOnLogin(ctx, db, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadMandatoryRoles(ctx, d, meta)
+}
+
+func ReadMandatoryRoles(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := readMandatoryRoles(ctx, db)
+	if err != nil {
+		return diag.Errorf("failed reading mandatory roles: %v", err)
+	}
+
+	// We only own the roles we added; others may be managed elsewhere.
+	managed := setToArray(d.Get("roles"))
+	present := []string{}
+	for _, role := range managed {
+		if containsRole(current, role) {
+			present = append(present, role)
+		}
+	}
+	d.Set("roles", present)
+
+	var activateAll bool
+	err = db.QueryRowContext(ctx, "SELECT @@GLOBAL.activate_all_roles_on_login").Scan(&activateAll)
+	if err != nil {
+		return diag.Errorf("failed reading activate_all_roles_on_login: %v", err)
+	}
+	d.Set("activate_all_roles_on_login", activateAll)
+
+	return nil
+}
+
+func DeleteMandatoryRoles(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := changeMandatoryRoles(ctx, db, nil, setToArray(d.Get("roles"))); err != nil {
+		return diag.Errorf("failed removing mandatory roles: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportMandatoryRoles(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	roles := strings.Split(d.Id(), ",")
+	d.Set("roles", roles)
+	d.SetId(mandatoryRolesId(roles))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func mandatoryRolesId(roles []string) string {
+	sorted := append([]string{}, roles...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// setActivateAllRolesOnLogin only touches the variable when it's configured,
+// as only one configuration should own it.
+func setActivateAllRolesOnLogin(ctx context.Context, db *sql.DB, d *schema.ResourceData) error {
+	if d.GetRawConfig().GetAttr("activate_all_roles_on_login").IsNull() {
+		return nil
+	}
+
+	value := "OFF"
+	if d.Get("activate_all_roles_on_login").(bool) {
+		value = "ON"
+	}
+	stmtSQL := fmt.Sprintf("SET GLOBAL activate_all_roles_on_login = %s", value)
+	logStatement(ctx, stmtSQL)
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return fmt.Errorf("failed setting activate_all_roles_on_login: %v", err)
+	}
+	return nil
+}
+
+// changeMandatoryRoles merges the given roles into the server's current
+// mandatory_roles instead of overwriting them.
+func changeMandatoryRoles(ctx context.Context, db *sql.DB, toAdd []string, toRemove []string) error {
+	mandatoryRolesMutex.Lock()
+	defer mandatoryRolesMutex.Unlock()
+
+	current, err := readMandatoryRoles(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	result := []string{}
+	for _, role := range current {
+		if !containsRole(toRemove, role) {
+			result = append(result, role)
+		}
+	}
+	for _, role := range toAdd {
+		if !containsRole(result, role) {
+			result = append(result, role)
+		}
+	}
+
+	quoted := make([]string, 0, len(result))
+	for _, role := range result {
+		quoted = append(quoted, parseRoleSpec(role).SQLString())
+	}
+
+	stmtSQL := "SET GLOBAL mandatory_roles = ?"
+	logStatement(ctx, stmtSQL, strings.Join(quoted, ","))
+	_, err = db.ExecContext(ctx, stmtSQL, strings.Join(quoted, ","))
+	return err
+}
+
+func readMandatoryRoles(ctx context.Context, db *sql.DB) ([]string, error) {
+	var value string
+	err := db.QueryRowContext(ctx, "SELECT @@GLOBAL.mandatory_roles").Scan(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []string{}
+	for _, spec := range strings.Split(value, ",") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		roles = append(roles, parseRoleSpec(spec).IDString())
+	}
+	return roles, nil
+}
+
+// parseRoleSpec parses role specifications as used in mandatory_roles, e.g.
+// "r1", "r2@localhost" or "`r3`@`%`". Roles with host "%" have it removed.
+func parseRoleSpec(spec string) UserOrRole {
+	nameHost := strings.SplitN(strings.TrimSpace(spec), "@", 2)
+	role := UserOrRole{Name: strings.Trim(nameHost[0], "`'\" ")}
+	if len(nameHost) == 2 {
+		role.Host = strings.Trim(nameHost[1], "`'\" ")
+	}
+	if role.Host == "%" {
+		role.Host = ""
+	}
+	return role
+}
+
+func containsRole(roles []string, role string) bool {
+	wanted := parseRoleSpec(role)
+	for _, r := range roles {
+		if parseRoleSpec(r) == wanted {
+			return true
+		}
+	}
+	return false
+}
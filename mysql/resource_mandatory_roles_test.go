@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMandatoryRoles_basic(t *testing.T) {
+	resourceName := "mysql_mandatory_roles.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccMandatoryRolesCheckDestroy("tf-test-mandatory"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMandatoryRolesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccMandatoryRoleExists("tf-test-mandatory"),
+					testAccMandatoryRoleExists("tf-test-other"),
+					resource.TestCheckResourceAttr(resourceName, "roles.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMandatoryRoleExists(role string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		roles, err := readMandatoryRoles(ctx, db)
+		if err != nil {
+			return err
+		}
+		if !containsRole(roles, role) {
+			return fmt.Errorf("role %s is not mandatory, mandatory roles are %v", role, roles)
+		}
+		return nil
+	}
+}
+
+func testAccMandatoryRolesCheckDestroy(role string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		roles, err := readMandatoryRoles(ctx, db)
+		if err != nil {
+			return err
+		}
+		if containsRole(roles, role) {
+			return fmt.Errorf("role %s is still mandatory", role)
+		}
+		return nil
+	}
+}
+
+// The second resource contributes another role; both have to be kept.
+const testAccMandatoryRolesConfig_basic = `
+resource "mysql_role" "test" {
+  name = "tf-test-mandatory"
+}
+
+resource "mysql_role" "other" {
+  name = "tf-test-other"
+}
+
+resource "mysql_mandatory_roles" "test" {
+  roles = [mysql_role.test.name]
+}
+
+resource "mysql_mandatory_roles" "other" {
+  roles = [mysql_role.other.name]
+}
+`
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMasterKeyRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateMasterKeyRotation,
+		UpdateContext: UpdateMasterKeyRotation,
+		ReadContext:   ReadMasterKeyRotation,
+		DeleteContext: DeleteMasterKeyRotation,
+		Schema: map[string]*schema.Schema{
+			"innodb": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+			"binlog": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"rotation": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+		},
+	}
+}
+
+func CreateMasterKeyRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	keys := masterKeys(d)
+	if len(keys) == 0 {
+		return diag.Errorf("at least one of innodb and binlog must be set")
+	}
+	if err := checkMasterKeyRotationSupported(ctx, meta, keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := rotateMasterKeys(ctx, db, keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strings.ToLower(strings.Join(keys, ",")))
+
+	return ReadMasterKeyRotation(ctx, d, meta)
+}
+
+func UpdateMasterKeyRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Any change of the counter rotates the keys again, so a rollback of the
+	// configuration rotates them too.
+	if d.HasChange("rotation") {
+		if err := rotateMasterKeys(ctx, db, masterKeys(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadMasterKeyRotation(ctx, d, meta)
+}
+
+// ReadMasterKeyRotation doesn't read anything, as the server doesn't tell when
+// its keys were rotated.
+func ReadMasterKeyRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// DeleteMasterKeyRotation only removes the resource from the state, as
+// rotations can't be undone.
+func DeleteMasterKeyRotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// masterKeys returns the keys to rotate, as named by ALTER INSTANCE.
+func masterKeys(d *schema.ResourceData) []string {
+	keys := []string{}
+	if d.Get("innodb").(bool) {
+		keys = append(keys, "INNODB")
+	}
+	if d.Get("binlog").(bool) {
+		keys = append(keys, "BINLOG")
+	}
+	return keys
+}
+
+func checkMasterKeyRotationSupported(ctx context.Context, meta interface{}, keys []string) error {
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "master key rotations"); err != nil {
+		return err
+	}
+	if !containsString(keys, "BINLOG") {
+		return nil
+	}
+	requiredVersion, _ := version.NewVersion("8.0.16")
+	if getFlavorFromMeta(ctx, meta) != flavorMySQL || getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return fmt.Errorf("binary log master key rotation requires MySQL 8.0.16 or newer")
+	}
+	return nil
+}
+
+func rotateMasterKeys(ctx context.Context, db *sql.DB, keys []string) error {
+	for _, key := range keys {
+		stmtSQL := fmt.Sprintf("ALTER INSTANCE ROTATE %s MASTER KEY", key)
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return fmt.Errorf("failed rotating %s master key: %w", strings.ToLower(key), err)
+		}
+	}
+	return nil
+}
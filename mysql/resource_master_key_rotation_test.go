@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMasterKeys(t *testing.T) {
+	tests := []struct {
+		raw  map[string]interface{}
+		want []string
+	}{
+		{map[string]interface{}{}, []string{"INNODB"}},
+		{map[string]interface{}{"binlog": true}, []string{"INNODB", "BINLOG"}},
+		{map[string]interface{}{"innodb": false, "binlog": true}, []string{"BINLOG"}},
+		{map[string]interface{}{"innodb": false}, []string{}},
+	}
+
+	for _, tt := range tests {
+		d := schema.TestResourceDataRaw(t, resourceMasterKeyRotation().Schema, tt.raw)
+		if got := masterKeys(d); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("masterKeys(%v) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// partitionNameLayouts are the date layouts of partition names, after the
+// prefix.
+var partitionNameLayouts = map[string]string{
+	"DAY":   "20060102",
+	"WEEK":  "20060102",
+	"MONTH": "200601",
+}
+
+func resourcePartitionWindow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdatePartitionWindow,
+		UpdateContext: CreateOrUpdatePartitionWindow,
+		ReadContext:   ReadPartitionWindow,
+		DeleteContext: DeletePartitionWindow,
+		CustomizeDiff: partitionWindowCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"table": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DAY",
+				ValidateFunc: validation.StringInSlice([]string{"DAY", "WEEK", "MONTH"}, false),
+			},
+			"retention": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"precreate": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "p",
+			},
+			"function": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "TO_DAYS",
+				ValidateFunc: validation.StringInSlice([]string{"TO_DAYS", "UNIX_TIMESTAMP"}, false),
+			},
+			"partitions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// partitionWindow is the configuration of a rolling window of partitions.
+type partitionWindow struct {
+	interval  string
+	retention int
+	precreate int
+	prefix    string
+}
+
+// partitionWindowCustomizeDiff plans the partitions the window should have
+// now, so an apply rolls the window forward once the current partition
+// changes.
+func partitionWindowCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	window := newPartitionWindow(d)
+	current := []string{}
+	for _, name := range d.Get("partitions").([]interface{}) {
+		current = append(current, name.(string))
+	}
+	// New resources may manage tables that already have some of the
+	// partitions, unless the table is yet to be created.
+	if d.Id() == "" {
+		db, err := getDatabaseFromMeta(ctx, meta)
+		if err != nil {
+			return err
+		}
+		_, existing, err := showRangePartitions(ctx, db, d.Get("database").(string), d.Get("table").(string))
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		for _, partition := range existing {
+			if _, ok := window.start(partition.name); ok {
+				current = append(current, partition.name)
+			}
+		}
+	}
+
+	planned := window.plan(current, time.Now())
+	if d.Id() == "" || !stringSlicesEqual(current, planned) {
+		return d.SetNew("partitions", planned)
+	}
+	return nil
+}
+
+func CreateOrUpdatePartitionWindow(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	table := d.Get("table").(string)
+	window := newPartitionWindow(d)
+
+	method, existing, err := showRangePartitions(ctx, db, database, table)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The planned partitions are applied as they are, so the result matches
+	// the plan even if the window moved on in between.
+	planned := map[string]bool{}
+	var first time.Time
+	for _, name := range d.Get("partitions").([]interface{}) {
+		planned[name.(string)] = true
+		if start, ok := window.start(name.(string)); ok && (first.IsZero() || start.Before(first)) {
+			first = start
+		}
+	}
+
+	// Only expired partitions are dropped, never ones ahead of the window.
+	toDrop := []string{}
+	present := map[string]bool{}
+	for _, partition := range existing {
+		present[partition.name] = true
+		if start, ok := window.start(partition.name); ok && start.Before(first) {
+			toDrop = append(toDrop, partition.name)
+		}
+	}
+	toAdd := []string{}
+	for name := range planned {
+		if !present[name] {
+			toAdd = append(toAdd, name)
+		}
+	}
+	// The names sort like their dates.
+	sort.Strings(toAdd)
+
+	tableSQL := fmt.Sprintf("%s.%s", quoteIdentifier(database), quoteIdentifier(table))
+	if len(toDrop) > 0 {
+		quoted := make([]string, 0, len(toDrop))
+		for _, name := range toDrop {
+			quoted = append(quoted, quoteIdentifier(name))
+		}
+		stmtSQL := fmt.Sprintf("ALTER TABLE %s DROP PARTITION %s", tableSQL, strings.Join(quoted, ", "))
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return diag.Errorf("failed dropping expired partitions: %v", err)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		definitions := make([]string, 0, len(toAdd))
+		for _, name := range toAdd {
+			start, _ := window.start(name)
+			definitions = append(definitions, fmt.Sprintf("PARTITION %s VALUES LESS THAN (%s)",
+				quoteIdentifier(name), partitionBoundSQL(method, d.Get("function").(string), window.add(start, 1))))
+		}
+
+		// New partitions can only be added after the last one, so a MAXVALUE
+		// partition is split instead.
+		var stmtSQL string
+		if last := len(existing) - 1; last >= 0 && existing[last].description == "MAXVALUE" {
+			maxValue := existing[last].name
+			stmtSQL = fmt.Sprintf("ALTER TABLE %s REORGANIZE PARTITION %s INTO (%s, PARTITION %s VALUES LESS THAN (MAXVALUE))",
+				tableSQL, quoteIdentifier(maxValue), strings.Join(definitions, ", "), quoteIdentifier(maxValue))
+		} else {
+			stmtSQL = fmt.Sprintf("ALTER TABLE %s ADD PARTITION (%s)", tableSQL, strings.Join(definitions, ", "))
+		}
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return diag.Errorf("failed adding partitions: %v", err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", database, table))
+
+	return ReadPartitionWindow(ctx, d, meta)
+}
+
+func ReadPartitionWindow(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, existing, err := showRangePartitions(ctx, db, d.Get("database").(string), d.Get("table").(string))
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Partitioned table (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	window := newPartitionWindow(d)
+	partitions := []string{}
+	for _, partition := range existing {
+		if _, ok := window.start(partition.name); ok {
+			partitions = append(partitions, partition.name)
+		}
+	}
+	d.Set("partitions", partitions)
+
+	return nil
+}
+
+// DeletePartitionWindow keeps the partitions and their data, so the table
+// stays as it is.
+func DeletePartitionWindow(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// newPartitionWindow reads the window of a resource or its diff.
+func newPartitionWindow(d interface{ Get(string) interface{} }) partitionWindow {
+	return partitionWindow{
+		interval:  d.Get("interval").(string),
+		retention: d.Get("retention").(int),
+		precreate: d.Get("precreate").(int),
+		prefix:    d.Get("prefix").(string),
+	}
+}
+
+type rangePartition struct {
+	name        string
+	description string
+}
+
+// showRangePartitions returns the partitioning method and the partitions of
+// the table in order, or sql.ErrNoRows if there's no such table.
+func showRangePartitions(ctx context.Context, db *sql.DB, database, table string) (string, []rangePartition, error) {
+	stmtSQL := `SELECT PARTITION_METHOD, PARTITION_NAME, PARTITION_DESCRIPTION FROM INFORMATION_SCHEMA.PARTITIONS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY PARTITION_ORDINAL_POSITION`
+	logStatement(ctx, stmtSQL, database, table)
+
+	rows, err := db.QueryContext(ctx, stmtSQL, database, table)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed reading partitions: %w", err)
+	}
+	defer rows.Close()
+
+	var method string
+	partitions := []rangePartition{}
+	found := false
+	for rows.Next() {
+		var partitionMethod, name, description sql.NullString
+		if err := rows.Scan(&partitionMethod, &name, &description); err != nil {
+			return "", nil, fmt.Errorf("failed scanning MySQL rows: %w", err)
+		}
+		found = true
+		method = partitionMethod.String
+		if name.Valid {
+			partitions = append(partitions, rangePartition{name: name.String, description: description.String})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, fmt.Errorf("failed reading partitions: %w", err)
+	}
+	if !found {
+		return "", nil, sql.ErrNoRows
+	}
+	if method != "RANGE" && method != "RANGE COLUMNS" {
+		return "", nil, fmt.Errorf("table %s.%s must be partitioned by RANGE or RANGE COLUMNS, not %q", database, table, method)
+	}
+	return method, partitions, nil
+}
+
+// partitionBoundSQL returns the upper bound of a partition ending at end.
+func partitionBoundSQL(method, function string, end time.Time) string {
+	value := quoteLiteral(end.Format("2006-01-02"))
+	if method == "RANGE COLUMNS" {
+		return value
+	}
+	return fmt.Sprintf("%s(%s)", function, value)
+}
+
+// plan returns the partitions the window has after rolling it forward, given
+// the managed partitions it has now. Missing past partitions aren't added, as
+// RANGE partitions can only be added after existing ones.
+func (w partitionWindow) plan(current []string, now time.Time) []string {
+	first := w.add(w.truncate(now), -(w.retention - 1))
+	last := w.add(w.truncate(now), w.precreate)
+
+	var latest time.Time
+	names := []string{}
+	for _, name := range current {
+		start, ok := w.start(name)
+		if !ok {
+			continue
+		}
+		if start.After(latest) {
+			latest = start
+		}
+		if !start.Before(first) {
+			names = append(names, name)
+		}
+	}
+
+	for start := first; !start.After(last); start = w.add(start, 1) {
+		if len(current) == 0 || start.After(latest) {
+			names = append(names, w.name(start))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// start returns the start of the partition with the name, if the window
+// manages it.
+func (w partitionWindow) start(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, w.prefix) {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(partitionNameLayouts[w.interval], strings.TrimPrefix(name, w.prefix))
+	if err != nil || !w.truncate(start).Equal(start) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+func (w partitionWindow) name(start time.Time) string {
+	return w.prefix + start.Format(partitionNameLayouts[w.interval])
+}
+
+// truncate returns the start of the interval t is in: midnight, Monday or
+// the first of the month, in UTC.
+func (w partitionWindow) truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch w.interval {
+	case "WEEK":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "MONTH":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func (w partitionWindow) add(t time.Time, n int) time.Time {
+	switch w.interval {
+	case "WEEK":
+		return t.AddDate(0, 0, 7*n)
+	case "MONTH":
+		return t.AddDate(0, n, 0)
+	}
+	return t.AddDate(0, 0, n)
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
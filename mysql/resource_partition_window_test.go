@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPartitionWindow_basic(t *testing.T) {
+	dbName := "tf_test_partition_window"
+	resourceName := "mysql_partition_window.test"
+	today := time.Now().UTC().Format("20060102")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDatabaseCheckDestroy(dbName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPartitionWindowConfig(dbName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "partitions.#", "5"),
+					resource.TestCheckResourceAttr(resourceName, "partitions.1", "p"+today),
+				),
+			},
+			{
+				Config: testAccPartitionWindowConfig(dbName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "partitions.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "partitions.0", "p"+today),
+				),
+			},
+		},
+	})
+}
+
+func TestPartitionWindowPlan(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)
+	tests := []struct {
+		window  partitionWindow
+		current []string
+		want    []string
+	}{
+		{
+			partitionWindow{interval: "DAY", retention: 2, precreate: 2, prefix: "p"},
+			nil,
+			[]string{"p20240314", "p20240315", "p20240316", "p20240317"},
+		},
+		{
+			partitionWindow{interval: "DAY", retention: 2, precreate: 2, prefix: "p"},
+			[]string{"p20240310", "p20240314", "p20240315"},
+			[]string{"p20240314", "p20240315", "p20240316", "p20240317"},
+		},
+		{
+			// Past partitions that are missing aren't added.
+			partitionWindow{interval: "DAY", retention: 3, precreate: 1, prefix: "p"},
+			[]string{"p20240315"},
+			[]string{"p20240315", "p20240316"},
+		},
+		{
+			partitionWindow{interval: "WEEK", retention: 1, precreate: 1, prefix: "w"},
+			nil,
+			[]string{"w20240311", "w20240318"},
+		},
+		{
+			partitionWindow{interval: "MONTH", retention: 2, precreate: 1, prefix: "p"},
+			[]string{"p202401", "p202402", "p202403"},
+			[]string{"p202402", "p202403", "p202404"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.window.plan(tt.current, now); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("plan(%v) with %+v = %v, want %v", tt.current, tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestPartitionWindowStart(t *testing.T) {
+	window := partitionWindow{interval: "DAY", prefix: "p"}
+	for name, want := range map[string]bool{
+		"p20240315":   true,
+		"pmax":        false,
+		"p2024031":    false,
+		"x20240315":   false,
+		"p20240315_x": false,
+	} {
+		if _, got := window.start(name); got != want {
+			t.Errorf("start(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	if got, want := partitionBoundSQL("RANGE", "TO_DAYS", time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)), "TO_DAYS('2024-03-16')"; got != want {
+		t.Errorf("partitionBoundSQL() = %q, want %q", got, want)
+	}
+	if got, want := partitionBoundSQL("RANGE COLUMNS", "TO_DAYS", time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)), "'2024-03-16'"; got != want {
+		t.Errorf("partitionBoundSQL() = %q, want %q", got, want)
+	}
+}
+
+func testAccPartitionWindowConfig(dbName string, retention int) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_sql" "events" {
+  name       = "events"
+  create_sql = "CREATE TABLE ${mysql_database.test.name}.events (created DATE NOT NULL) PARTITION BY RANGE COLUMNS (created) (PARTITION pold VALUES LESS THAN ('2000-01-01'), PARTITION pmax VALUES LESS THAN (MAXVALUE))"
+  delete_sql = "DROP TABLE ${mysql_database.test.name}.events"
+}
+
+resource "mysql_partition_window" "test" {
+  database  = mysql_database.test.name
+  table     = "events"
+  retention = %d
+  precreate = 3
+
+  depends_on = [mysql_sql.events]
+}
+`, dbName, retention)
+}
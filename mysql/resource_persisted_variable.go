@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePersistedVariable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdatePersistedVariable,
+		ReadContext:   ReadPersistedVariable,
+		UpdateContext: CreateOrUpdatePersistedVariable,
+		DeleteContext: DeletePersistedVariable,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportPersistedVariable,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVariableValue,
+			},
+			"persist_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func CreateOrUpdatePersistedVariable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return diag.Errorf("persisted variables require MySQL 8.0 or newer")
+	}
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "persisted variables"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	value := d.Get("value").(string)
+
+	scope := "PERSIST"
+	if d.Get("persist_only").(bool) {
+		scope = "PERSIST_ONLY"
+	}
+
+	sql := fmt.Sprintf("SET %s %s = %s", scope, quoteIdentifier(name), variableValueSQL(value))
+	logStatement(ctx, sql)
+
+	_, err = db.ExecContext(ctx, sql)
+	if err != nil {
+		return diag.Errorf("error persisting value: %s", err)
+	}
+
+	d.SetId(name)
+
+	return ReadPersistedVariable(ctx, d, meta)
+}
+
+func ReadPersistedVariable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var value string
+	err = db.QueryRowContext(ctx, "SELECT VARIABLE_VALUE FROM performance_schema.persisted_variables WHERE VARIABLE_NAME = ?", d.Id()).Scan(&value)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Persisted variable (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error reading persisted variables: %s", err)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("value", value)
+
+	return nil
+}
+
+func DeletePersistedVariable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sql := fmt.Sprintf("RESET PERSIST IF EXISTS %s", quoteIdentifier(d.Id()))
+	logStatement(ctx, sql)
+
+	_, err = db.ExecContext(ctx, sql)
+	if err != nil {
+		return diag.Errorf("error resetting persisted value: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportPersistedVariable(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("persist_only", false)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPersistedVariable_basic(t *testing.T) {
+	varName := "max_connect_errors"
+	resourceName := "mysql_persisted_variable.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPersistedVariableCheckDestroy(varName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPersistedVariableConfig(varName, "1000", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPersistedVariableExists(varName, "1000"),
+					resource.TestCheckResourceAttr(resourceName, "value", "1000"),
+				),
+			},
+			{
+				Config: testAccPersistedVariableConfig(varName, "2000", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPersistedVariableExists(varName, "2000"),
+					resource.TestCheckResourceAttr(resourceName, "persist_only", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"persist_only"},
+			},
+		},
+	})
+}
+
+func testAccPersistedVariableExists(varName string, varExpected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var value string
+		err = db.QueryRow("SELECT VARIABLE_VALUE FROM performance_schema.persisted_variables WHERE VARIABLE_NAME = ?", varName).Scan(&value)
+		if err != nil {
+			return fmt.Errorf("error reading persisted variable %s: %s", varName, err)
+		}
+		if value != varExpected {
+			return fmt.Errorf("persisted variable %s is %s, expected %s", varName, value, varExpected)
+		}
+
+		return nil
+	}
+}
+
+func testAccPersistedVariableCheckDestroy(varName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM performance_schema.persisted_variables WHERE VARIABLE_NAME = ?", varName).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading persisted variables: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("persisted variable %s still exists after destroy", varName)
+		}
+
+		return nil
+	}
+}
+
+func testAccPersistedVariableConfig(varName string, varValue string, persistOnly bool) string {
+	return fmt.Sprintf(`
+resource "mysql_persisted_variable" "test" {
+  name         = "%s"
+  value        = "%s"
+  persist_only = %t
+}
+`, varName, varValue, persistOnly)
+}
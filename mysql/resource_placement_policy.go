@@ -0,0 +1,209 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// placementPolicyStringOptions maps attributes to TiDB placement options.
+var placementPolicyStringOptions = []struct {
+	attribute string
+	option    string
+}{
+	{"primary_region", "PRIMARY_REGION"},
+	{"schedule", "SCHEDULE"},
+	{"constraints", "CONSTRAINTS"},
+	{"leader_constraints", "LEADER_CONSTRAINTS"},
+	{"follower_constraints", "FOLLOWER_CONSTRAINTS"},
+	{"learner_constraints", "LEARNER_CONSTRAINTS"},
+}
+
+func resourcePlacementPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreatePlacementPolicy,
+		UpdateContext: UpdatePlacementPolicy,
+		ReadContext:   ReadPlacementPolicy,
+		DeleteContext: DeletePlacementPolicy,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"primary_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"regions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"schedule": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"followers": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"learners": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"constraints": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"leader_constraints": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"follower_constraints": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"learner_constraints": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func CreatePlacementPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "placement policies")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	options, args := placementPolicyOptionsSQL(d)
+	stmtSQL := fmt.Sprintf("CREATE PLACEMENT POLICY %s %s", quoteIdentifier(name), options)
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL, args...); err != nil {
+		return diag.Errorf("failed creating placement policy: %v", err)
+	}
+
+	d.SetId(name)
+
+	return ReadPlacementPolicy(ctx, d, meta)
+}
+
+func UpdatePlacementPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "placement policies")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// ALTER PLACEMENT POLICY replaces all options, so all of them are sent.
+	options, args := placementPolicyOptionsSQL(d)
+	stmtSQL := fmt.Sprintf("ALTER PLACEMENT POLICY %s %s", quoteIdentifier(d.Id()), options)
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL, args...); err != nil {
+		return diag.Errorf("failed updating placement policy: %v", err)
+	}
+
+	return ReadPlacementPolicy(ctx, d, meta)
+}
+
+func ReadPlacementPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "placement policies")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := `SELECT PRIMARY_REGION, REGIONS, SCHEDULE, FOLLOWERS, LEARNERS,
+		CONSTRAINTS, LEADER_CONSTRAINTS, FOLLOWER_CONSTRAINTS, LEARNER_CONSTRAINTS
+		FROM INFORMATION_SCHEMA.PLACEMENT_POLICIES WHERE POLICY_NAME = ?`
+	logStatement(ctx, stmtSQL)
+
+	var primaryRegion, regions, schedule sql.NullString
+	var constraints, leaderConstraints, followerConstraints, learnerConstraints sql.NullString
+	var followers, learners sql.NullInt64
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(
+		&primaryRegion, &regions, &schedule, &followers, &learners,
+		&constraints, &leaderConstraints, &followerConstraints, &learnerConstraints,
+	)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Placement policy (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading placement policy: %v", err)
+	}
+
+	regionList := []string{}
+	for _, region := range strings.Split(regions.String, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regionList = append(regionList, region)
+		}
+	}
+
+	d.Set("name", d.Id())
+	d.Set("primary_region", primaryRegion.String)
+	d.Set("regions", regionList)
+	d.Set("schedule", schedule.String)
+	d.Set("followers", followers.Int64)
+	d.Set("learners", learners.Int64)
+	d.Set("constraints", constraints.String)
+	d.Set("leader_constraints", leaderConstraints.String)
+	d.Set("follower_constraints", followerConstraints.String)
+	d.Set("learner_constraints", learnerConstraints.String)
+
+	return nil
+}
+
+func DeletePlacementPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "placement policies")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "DROP PLACEMENT POLICY IF EXISTS " + quoteIdentifier(d.Id())
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed deleting placement policy: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func placementPolicyOptionsSQL(d *schema.ResourceData) (string, []interface{}) {
+	var options []string
+	var args []interface{}
+	for _, opt := range placementPolicyStringOptions {
+		if value := d.Get(opt.attribute).(string); value != "" {
+			options = append(options, opt.option+"=?")
+			args = append(args, value)
+		}
+	}
+	if regions := setToArray(d.Get("regions")); len(regions) > 0 {
+		sort.Strings(regions)
+		options = append(options, "REGIONS=?")
+		args = append(args, strings.Join(regions, ","))
+	}
+	if followers := d.Get("followers").(int); followers > 0 {
+		options = append(options, fmt.Sprintf("FOLLOWERS=%d", followers))
+	}
+	if learners := d.Get("learners").(int); learners > 0 {
+		options = append(options, fmt.Sprintf("LEARNERS=%d", learners))
+	}
+	return strings.Join(options, " "), args
+}
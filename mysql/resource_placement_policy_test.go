@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPlacementPolicy_basic(t *testing.T) {
+	policyName := "tf_test_policy"
+	dbName := "tf_test_placement"
+	resourceName := "mysql_placement_policy.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipNotTiDB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPlacementPolicyCheckDestroy(policyName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPlacementPolicyConfig(policyName, dbName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", policyName),
+					resource.TestCheckResourceAttr(resourceName, "followers", "2"),
+					resource.TestCheckResourceAttr("mysql_database.test", "placement_policy", policyName),
+				),
+			},
+			{
+				Config: testAccPlacementPolicyConfig(policyName, dbName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "followers", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPlacementPolicyCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.PLACEMENT_POLICIES WHERE POLICY_NAME = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading placement policies: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("placement policy %s still exists after destroy", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccPlacementPolicyConfig(policyName string, dbName string, followers int) string {
+	return fmt.Sprintf(`
+resource "mysql_placement_policy" "test" {
+  name      = "%s"
+  followers = %d
+}
+
+resource "mysql_database" "test" {
+  name             = "%s"
+  placement_policy = mysql_placement_policy.test.name
+}
+`, policyName, followers, dbName)
+}
@@ -0,0 +1,179 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePlugin() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreatePlugin,
+		ReadContext:   ReadPlugin,
+		UpdateContext: UpdatePlugin,
+		DeleteContext: DeletePlugin,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportPlugin,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"soname": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreatePlugin(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	// Plugins are installed unless enabled is explicitly false.
+	if d.GetRawConfig().GetAttr("enabled").IsNull() {
+		d.Set("enabled", true)
+	}
+	if d.Get("enabled").(bool) {
+		if err := installPlugin(ctx, db, name, d.Get("soname").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(name)
+
+	return ReadPlugin(ctx, d, meta)
+}
+
+func UpdatePlugin(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("enabled") {
+		oldStatus, _ := d.GetChange("status")
+		installed := oldStatus.(string) != ""
+		if d.Get("enabled").(bool) {
+			if installed {
+				return diag.Errorf("plugin %s is installed but %s; it can only be activated by restarting the server", d.Id(), oldStatus)
+			}
+			if err := installPlugin(ctx, db, d.Id(), d.Get("soname").(string)); err != nil {
+				return diag.FromErr(err)
+			}
+		} else if installed {
+			if err := uninstallPlugin(ctx, db, d.Id()); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return ReadPlugin(ctx, d, meta)
+}
+
+func ReadPlugin(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT PLUGIN_STATUS, PLUGIN_LIBRARY FROM INFORMATION_SCHEMA.PLUGINS WHERE PLUGIN_NAME = ?"
+	logStatement(ctx, stmtSQL)
+
+	var status string
+	var library sql.NullString
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&status, &library)
+	if err == sql.ErrNoRows && !d.Get("enabled").(bool) {
+		// Disabled plugins are kept uninstalled.
+		d.Set("name", d.Id())
+		d.Set("status", "")
+		return nil
+	}
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Plugin (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading plugin: %v", err)
+	}
+
+	d.Set("name", d.Id())
+	// Built-in plugins have no library.
+	d.Set("soname", library.String)
+	d.Set("status", status)
+	d.Set("enabled", status == "ACTIVE")
+
+	return nil
+}
+
+func DeletePlugin(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("status").(string) != "" {
+		if err := uninstallPlugin(ctx, db, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportPlugin(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Only installed plugins can be imported.
+	name := d.Id()
+	d.Set("enabled", true)
+	diags := ReadPlugin(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading plugin: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("plugin %s is not installed", name)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func installPlugin(ctx context.Context, db *sql.DB, name string, soname string) error {
+	stmtSQL := fmt.Sprintf("INSTALL PLUGIN %s SONAME ?", quoteIdentifier(name))
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL, soname); err != nil {
+		return fmt.Errorf("failed installing plugin: %v", err)
+	}
+	return nil
+}
+
+func uninstallPlugin(ctx context.Context, db *sql.DB, name string) error {
+	stmtSQL := "UNINSTALL PLUGIN " + quoteIdentifier(name)
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return fmt.Errorf("failed uninstalling plugin: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPlugin_basic(t *testing.T) {
+	pluginName := "connection_control"
+	resourceName := "mysql_plugin.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPluginCheckDestroy(pluginName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPluginConfig(pluginName, "connection_control.so"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPluginConfig_enabled(pluginName, "connection_control.so", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "status", ""),
+					testAccPluginCheckDestroy(pluginName),
+				),
+			},
+			{
+				Config: testAccPluginConfig_enabled(pluginName, "connection_control.so", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPluginCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.PLUGINS WHERE PLUGIN_NAME = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading plugins: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("plugin %s still installed after destroy", name)
+		}
+
+		return nil
+	}
+}
+
+func testAccPluginConfig(name string, soname string) string {
+	return fmt.Sprintf(`
+resource "mysql_plugin" "test" {
+  name   = "%s"
+  soname = "%s"
+}
+`, name, soname)
+}
+
+func testAccPluginConfig_enabled(name string, soname string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "mysql_plugin" "test" {
+  name    = "%s"
+  soname  = "%s"
+  enabled = %t
+}
+`, name, soname, enabled)
+}
@@ -0,0 +1,221 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// builtinProxyUserVariables make the built-in authentication plugins map
+// proxy users onto proxied accounts, as external plugins like PAM and LDAP do
+// on their own.
+var builtinProxyUserVariables = []string{
+	"check_proxy_users",
+	"mysql_native_password_proxy_users",
+	"sha256_password_proxy_users",
+}
+
+func resourceProxyUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateProxyUser,
+		UpdateContext: UpdateProxyUser,
+		ReadContext:   ReadProxyUser,
+		DeleteContext: DeleteProxyUser,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportProxyUser,
+		},
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserName,
+			},
+			"host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "%",
+				ValidateFunc: validateHostPattern,
+			},
+			"proxied_user": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserName,
+			},
+			"proxied_host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "%",
+				ValidateFunc: validateHostPattern,
+			},
+			"grant_option": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"builtin_proxy_users": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func CreateProxyUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "proxy users"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("builtin_proxy_users").(bool) {
+		if err := enableBuiltinProxyUsers(ctx, db, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	proxy, proxied := proxyUserAccounts(d)
+	stmtSQL := fmt.Sprintf("GRANT PROXY ON %s TO %s", proxied.SQLString(), proxy.SQLString())
+	if d.Get("grant_option").(bool) {
+		stmtSQL += " WITH GRANT OPTION"
+	}
+	logStatement(ctx, stmtSQL)
+
+	_, err = db.ExecContext(ctx, stmtSQL)
+	invalidateGrants(ctx, meta, proxy)
+	if err != nil {
+		return diag.Errorf("failed granting proxy: %v", err)
+	}
+
+	d.SetId(proxyUserId(proxy, proxied))
+
+	return ReadProxyUser(ctx, d, meta)
+}
+
+func UpdateProxyUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The variables are left on when builtin_proxy_users is turned off, as
+	// other mappings may rely on them.
+	if d.HasChange("builtin_proxy_users") && d.Get("builtin_proxy_users").(bool) {
+		if err := enableBuiltinProxyUsers(ctx, db, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadProxyUser(ctx, d, meta)
+}
+
+func ReadProxyUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	proxy, proxied := proxyUserAccounts(d)
+	stmtSQL := "SELECT With_grant FROM mysql.proxies_priv WHERE User = ? AND Host = ? AND Proxied_user = ? AND Proxied_host = ?"
+	logStatement(ctx, stmtSQL, proxy.Name, proxy.Host, proxied.Name, proxied.Host)
+
+	var withGrant bool
+	err = db.QueryRowContext(ctx, stmtSQL, proxy.Name, proxy.Host, proxied.Name, proxied.Host).Scan(&withGrant)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Proxy user (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading proxy user: %v", err)
+	}
+
+	d.Set("grant_option", withGrant)
+
+	return nil
+}
+
+func DeleteProxyUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	proxy, proxied := proxyUserAccounts(d)
+	stmtSQL := fmt.Sprintf("REVOKE PROXY ON %s FROM %s", proxied.SQLString(), proxy.SQLString())
+	logStatement(ctx, stmtSQL)
+
+	_, err = db.ExecContext(ctx, stmtSQL)
+	invalidateGrants(ctx, meta, proxy)
+	if err != nil && !isNonExistingGrant(err) {
+		return diag.Errorf("failed revoking proxy: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// ImportProxyUser takes IDs like jdoe@%->app@%.
+func ImportProxyUser(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	accounts := strings.SplitN(d.Id(), "->", 2)
+	if len(accounts) != 2 || !strings.Contains(accounts[0], "@") || !strings.Contains(accounts[1], "@") {
+		return nil, fmt.Errorf("wrong ID format %s (expected USER@HOST->PROXIED_USER@PROXIED_HOST)", d.Id())
+	}
+
+	proxy := parseAccountKey(accounts[0])
+	proxied := parseAccountKey(accounts[1])
+	d.Set("user", proxy.Name)
+	d.Set("host", proxy.Host)
+	d.Set("proxied_user", proxied.Name)
+	d.Set("proxied_host", proxied.Host)
+	d.Set("builtin_proxy_users", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func proxyUserAccounts(d *schema.ResourceData) (UserOrRole, UserOrRole) {
+	proxy := UserOrRole{Name: d.Get("user").(string), Host: d.Get("host").(string)}
+	proxied := UserOrRole{Name: d.Get("proxied_user").(string), Host: d.Get("proxied_host").(string)}
+	return proxy, proxied
+}
+
+func proxyUserId(proxy, proxied UserOrRole) string {
+	return fmt.Sprintf("%s@%s->%s@%s", proxy.Name, proxy.Host, proxied.Name, proxied.Host)
+}
+
+// enableBuiltinProxyUsers turns on proxying for the built-in plugins. They're
+// persisted where the server can, so they survive restarts.
+func enableBuiltinProxyUsers(ctx context.Context, db *sql.DB, meta interface{}) error {
+	scope := "GLOBAL"
+	requiredVersion, _ := version.NewVersion("8.0.0")
+	if getFlavorFromMeta(ctx, meta) == flavorMySQL && !getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		scope = "PERSIST"
+	}
+
+	for _, name := range builtinProxyUserVariables {
+		stmtSQL := fmt.Sprintf("SET %s %s = ON", scope, name)
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return fmt.Errorf("failed enabling %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccProxyUser_basic(t *testing.T) {
+	resourceName := "mysql_proxy_user.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccProxyUserCheckDestroy("jdoe-proxy", "jdoe-proxied"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProxyUserConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user", "jdoe-proxy"),
+					resource.TestCheckResourceAttr(resourceName, "proxied_user", "jdoe-proxied"),
+					resource.TestCheckResourceAttr(resourceName, "grant_option", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "jdoe-proxy@%->jdoe-proxied@%",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccProxyUserCheckDestroy(user, proxiedUser string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM mysql.proxies_priv WHERE User = ? AND Proxied_user = ?", user, proxiedUser).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading proxy users: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("proxy user %s still exists after destroy", user)
+		}
+		return nil
+	}
+}
+
+const testAccProxyUserConfig_basic = `
+resource "mysql_user" "proxy" {
+  user = "jdoe-proxy"
+  host = "%"
+}
+
+resource "mysql_user" "proxied" {
+  user = "jdoe-proxied"
+  host = "%"
+}
+
+resource "mysql_proxy_user" "test" {
+  user         = mysql_user.proxy.user
+  proxied_user = mysql_user.proxied.user
+}
+`
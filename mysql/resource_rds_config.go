@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -36,18 +35,24 @@ func resourceRDSConfig() *schema.Resource {
 				Default:     0,
 				Description: "Sets the number of seconds to delay replication from source database instance to the read replica",
 			},
+			"replication_source_delay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Sets the number of seconds to delay replication from the source database instance to this read replica",
+			},
 		},
 	}
 }
 
 func CreateRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getRdsDatabaseFromMeta(ctx, meta, "RDS configurations")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	for _, stmtSQL := range RDSConfigSQL(d) {
-		log.Println("Executing statement:", stmtSQL)
+		logStatement(ctx, stmtSQL)
 
 		_, err = db.ExecContext(ctx, stmtSQL)
 		if err != nil {
@@ -61,13 +66,13 @@ func CreateRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface
 }
 
 func UpdateRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getRdsDatabaseFromMeta(ctx, meta, "RDS configurations")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	for _, stmtSQL := range RDSConfigSQL(d) {
-		log.Println("Executing statement:", stmtSQL)
+		logStatement(ctx, stmtSQL)
 
 		_, err = db.ExecContext(ctx, stmtSQL)
 		if err != nil {
@@ -79,18 +84,19 @@ func UpdateRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface
 }
 
 func ReadRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getRdsDatabaseFromMeta(ctx, meta, "RDS configurations")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	stmtSQL := "call mysql.rds_show_configuration"
 
-	log.Println("Executing query:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 	rows, err := db.QueryContext(ctx, stmtSQL)
 	if err != nil {
 		return diag.Errorf("Error reading RDS config from DB: %v", err)
 	}
+	defer rows.Close()
 
 	results := make(map[string]string)
 	for rows.Next() {
@@ -105,6 +111,9 @@ func ReadRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface{}
 			results[name] = value.String
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading RDS config: %v", err)
+	}
 
 	if len(results["binlog retention hours"]) == 0 || results["binlog retention hours"] == "NULL" {
 		results["binlog retention hours"] = "0"
@@ -124,21 +133,31 @@ func ReadRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface{}
 		return diag.Errorf("failed reading target delay in RDS config: %v", err)
 	}
 
+	if len(results["source delay"]) == 0 {
+		results["source delay"] = "0"
+	}
+
+	replicationSourceDelay, err := strconv.Atoi(results["source delay"])
+	if err != nil {
+		return diag.Errorf("failed reading source delay in RDS config: %v", err)
+	}
+
 	d.Set("replication_target_delay", replicationTargetDelay)
+	d.Set("replication_source_delay", replicationSourceDelay)
 	d.Set("binlog_retention_hours", binlogRetentionPeriod)
 
 	return nil
 }
 
 func DeleteRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getRdsDatabaseFromMeta(ctx, meta, "RDS configurations")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	stmtsSQL := []string{"call mysql.rds_set_configuration('binlog retention hours', NULL)", "call mysql.rds_set_configuration('target delay', 0)"}
+	stmtsSQL := []string{"call mysql.rds_set_configuration('binlog retention hours', NULL)", "call mysql.rds_set_configuration('target delay', 0)", "call mysql.rds_set_configuration('source delay', 0)"}
 	for _, stmtSQL := range stmtsSQL {
-		log.Println("Executing statement:", stmtSQL)
+		logStatement(ctx, stmtSQL)
 
 		_, err = db.ExecContext(ctx, stmtSQL)
 		if err != nil {
@@ -150,19 +169,36 @@ func DeleteRDSConfig(ctx context.Context, d *schema.ResourceData, meta interface
 	return nil
 }
 
+// rdsConfigKeys maps attributes to the names used by mysql.rds_set_configuration.
+var rdsConfigKeys = []struct {
+	attribute string
+	name      string
+}{
+	{"binlog_retention_hours", "binlog retention hours"},
+	{"replication_target_delay", "target delay"},
+	{"replication_source_delay", "source delay"},
+}
+
+// RDSConfigSQL returns the statements setting the configured keys on create
+// and the changed keys on update, so keys managed outside of Terraform are
+// left alone.
 func RDSConfigSQL(d *schema.ResourceData) []string {
 	result := []string{}
-	if d.Get("binlog_retention_hours") != nil {
-		retention_period := strconv.Itoa(d.Get("binlog_retention_hours").(int))
-		if retention_period == "0" {
-			retention_period = "NULL"
+	for _, key := range rdsConfigKeys {
+		if d.IsNewResource() {
+			if d.GetRawConfig().GetAttr(key.attribute).IsNull() {
+				continue
+			}
+		} else if !d.HasChange(key.attribute) {
+			continue
 		}
-		result = append(result, (fmt.Sprintf("call mysql.rds_set_configuration('binlog retention hours', %s)", retention_period)))
-	}
 
-	if d.Get("replication_target_delay") != nil {
-		target_delay := d.Get("replication_target_delay")
-		result = append(result, (fmt.Sprintf("call mysql.rds_set_configuration('target delay', %v)", target_delay)))
+		value := strconv.Itoa(d.Get(key.attribute).(int))
+		// Binary logs are retained for the shortest time possible with NULL.
+		if key.attribute == "binlog_retention_hours" && value == "0" {
+			value = "NULL"
+		}
+		result = append(result, fmt.Sprintf("call mysql.rds_set_configuration(%s, %s)", quoteLiteral(key.name), value))
 	}
 
 	return result
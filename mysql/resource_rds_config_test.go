@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -26,6 +28,7 @@ func TestAccResourceRDS(t *testing.T) {
 					testAccRDSConfigExists(fmt.Sprintf("mysql_rds_config.%s", rName)),
 					resource.TestCheckResourceAttr(fmt.Sprintf("mysql_rds_config.%s", rName), "binlog_retention_hours", fmt.Sprintf("%d", binlog)),
 					resource.TestCheckResourceAttr(fmt.Sprintf("mysql_rds_config.%s", rName), "replication_target_delay", fmt.Sprintf("%d", targetDelay)),
+					resource.TestCheckResourceAttr(fmt.Sprintf("mysql_rds_config.%s", rName), "replication_source_delay", "0"),
 				),
 			},
 		},
@@ -210,3 +213,16 @@ func testAccRDSCheck_full(rn string, binlogUpdated, targetDelayUpdated int) reso
 		return nil
 	}
 }
+
+func TestRDSConfigSQL(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceRDSConfig().Schema, map[string]interface{}{
+		"replication_target_delay": 3200,
+	})
+	d.SetId(mysqlRdsConfigId)
+
+	got := RDSConfigSQL(d)
+	want := []string{"call mysql.rds_set_configuration('target delay', 3200)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RDSConfigSQL() = %v, want %v", got, want)
+	}
+}
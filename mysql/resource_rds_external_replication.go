@@ -0,0 +1,273 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceRDSExternalReplication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateRDSExternalReplication,
+		UpdateContext: UpdateRDSExternalReplication,
+		ReadContext:   ReadRDSExternalReplication,
+		DeleteContext: DeleteRDSExternalReplication,
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  3306,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"auto_position": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+			"log_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"auto_position"},
+			},
+			"log_position": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"auto_position"},
+			},
+			"ssl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"ssl_ca": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"ssl_cert", "ssl_key"},
+			},
+			"ssl_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"ssl_ca", "ssl_key"},
+			},
+			"ssl_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"ssl_ca", "ssl_cert"},
+			},
+			"started": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func CreateRDSExternalReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !d.Get("auto_position").(bool) && d.Get("log_file").(string) == "" {
+		return diag.Errorf("log_file and log_position are required without auto_position")
+	}
+
+	if d.Get("ssl_ca").(string) != "" {
+		material, err := rdsSSLMaterialJSON(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// The certificates aren't logged, as they contain the key.
+		stmtSQL := "CALL mysql.rds_import_binlog_ssl_material(?)"
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL, material); err != nil {
+			return diag.Errorf("failed importing SSL material: %v", err)
+		}
+	}
+
+	sslEncryption := 0
+	if d.Get("ssl").(bool) {
+		sslEncryption = 1
+	}
+
+	var stmtSQL string
+	args := []interface{}{d.Get("host").(string), d.Get("port").(int), d.Get("user").(string), d.Get("password").(string)}
+	if d.Get("auto_position").(bool) {
+		stmtSQL = "CALL mysql.rds_set_external_master_with_auto_position(?, ?, ?, ?, ?, 0)"
+		args = append(args, sslEncryption)
+	} else {
+		stmtSQL = "CALL mysql.rds_set_external_master(?, ?, ?, ?, ?, ?, ?)"
+		args = append(args, d.Get("log_file").(string), d.Get("log_position").(int), sslEncryption)
+	}
+	// Arguments aren't logged, as they contain the password.
+	logStatement(ctx, stmtSQL)
+	if _, err := db.ExecContext(ctx, stmtSQL, args...); err != nil {
+		return diag.Errorf("failed setting external source: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", d.Get("host").(string), d.Get("port").(int)))
+
+	if d.Get("started").(bool) {
+		if err := startOrStopRDSReplication(ctx, db, true); err != nil {
+			return diag.Errorf("failed starting replication: %v", err)
+		}
+	}
+
+	return ReadRDSExternalReplication(ctx, d, meta)
+}
+
+func UpdateRDSExternalReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("started") {
+		if err := startOrStopRDSReplication(ctx, db, d.Get("started").(bool)); err != nil {
+			return diag.Errorf("failed starting or stopping replication: %v", err)
+		}
+	}
+
+	return ReadRDSExternalReplication(ctx, d, meta)
+}
+
+func ReadRDSExternalReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := showRDSReplicaStatus(ctx, db, meta)
+	if err != nil {
+		return diag.Errorf("failed reading replica status: %v", err)
+	}
+	host := replicaStatusValue(status, "Source_Host", "Master_Host")
+	if status == nil || host == "" {
+		log.Printf("[WARN] External replication (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	portValue := replicaStatusValue(status, "Source_Port", "Master_Port")
+	port, err := strconv.Atoi(portValue)
+	if err != nil {
+		return diag.Errorf("failed parsing source port %q: %v", portValue, err)
+	}
+
+	d.Set("host", host)
+	d.Set("port", port)
+	d.Set("user", replicaStatusValue(status, "Source_User", "Master_User"))
+	d.Set("auto_position", status["Auto_Position"] == "1")
+	d.Set("ssl", replicaStatusValue(status, "Source_SSL_Allowed", "Master_SSL_Allowed") != "No")
+	d.Set("started", replicaStatusValue(status, "Replica_IO_Running", "Slave_IO_Running") != "No" ||
+		replicaStatusValue(status, "Replica_SQL_Running", "Slave_SQL_Running") != "No")
+
+	return nil
+}
+
+func DeleteRDSExternalReplication(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := startOrStopRDSReplication(ctx, db, false); err != nil {
+		return diag.Errorf("failed stopping replication: %v", err)
+	}
+
+	stmtSQL := "CALL mysql.rds_reset_external_master"
+	logStatement(ctx, stmtSQL)
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed resetting external source: %v", err)
+	}
+
+	if d.Get("ssl_ca").(string) != "" {
+		stmtSQL := "CALL mysql.rds_remove_binlog_ssl_material"
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+			return diag.Errorf("failed removing SSL material: %v", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func startOrStopRDSReplication(ctx context.Context, db *sql.DB, start bool) error {
+	stmtSQL := "CALL mysql.rds_stop_replication"
+	if start {
+		stmtSQL = "CALL mysql.rds_start_replication"
+	}
+	logStatement(ctx, stmtSQL)
+
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+// showRDSReplicaStatus returns the replica status of the default channel. RDS
+// for MySQL 5.7 only knows SHOW SLAVE STATUS.
+func showRDSReplicaStatus(ctx context.Context, db *sql.DB, meta interface{}) (map[string]string, error) {
+	requiredVersion, _ := version.NewVersion("8.0.22")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		stmtSQL := "SHOW SLAVE STATUS"
+		logStatement(ctx, stmtSQL)
+		return queryStatusRow(ctx, db, stmtSQL)
+	}
+	return showReplicaStatus(ctx, db, "")
+}
+
+// replicaStatusValue returns the first of the columns present, as MySQL
+// renamed them from master and slave to source and replica.
+func replicaStatusValue(status map[string]string, columns ...string) string {
+	for _, column := range columns {
+		if value, ok := status[column]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// rdsSSLMaterialJSON returns the argument of rds_import_binlog_ssl_material.
+func rdsSSLMaterialJSON(d *schema.ResourceData) (string, error) {
+	material, err := json.Marshal(map[string]string{
+		"ssl_ca":   d.Get("ssl_ca").(string),
+		"ssl_cert": d.Get("ssl_cert").(string),
+		"ssl_key":  d.Get("ssl_key").(string),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(material), nil
+}
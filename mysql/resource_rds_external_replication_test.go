@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReplicaStatusValue(t *testing.T) {
+	tests := []struct {
+		status map[string]string
+		want   string
+	}{
+		{map[string]string{"Source_Host": "primary"}, "primary"},
+		{map[string]string{"Master_Host": "primary"}, "primary"},
+		{map[string]string{"Source_Host": "", "Master_Host": "other"}, ""},
+		{map[string]string{}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := replicaStatusValue(tt.status, "Source_Host", "Master_Host"); got != tt.want {
+			t.Errorf("replicaStatusValue(%v) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRDSSSLMaterialJSON(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceRDSExternalReplication().Schema, map[string]interface{}{
+		"host":     "primary.example.com",
+		"user":     "replicator",
+		"password": "secret",
+		"ssl_ca":   "-----BEGIN CERTIFICATE-----\nca\n-----END CERTIFICATE-----",
+		"ssl_cert": "cert",
+		"ssl_key":  "key",
+	})
+
+	material, err := rdsSSLMaterialJSON(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed map[string]string
+	if err := json.Unmarshal([]byte(material), &parsed); err != nil {
+		t.Fatalf("material %q isn't JSON: %v", material, err)
+	}
+	want := map[string]string{
+		"ssl_ca":   "-----BEGIN CERTIFICATE-----\nca\n-----END CERTIFICATE-----",
+		"ssl_cert": "cert",
+		"ssl_key":  "key",
+	}
+	for key, value := range want {
+		if parsed[key] != value {
+			t.Errorf("material[%q] = %q, want %q", key, parsed[key], value)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// replicationFilterLists maps the list attributes to the filter names of
+// CHANGE REPLICATION FILTER.
+var replicationFilterLists = map[string]string{
+	"do_db":             "REPLICATE_DO_DB",
+	"ignore_db":         "REPLICATE_IGNORE_DB",
+	"do_table":          "REPLICATE_DO_TABLE",
+	"ignore_table":      "REPLICATE_IGNORE_TABLE",
+	"wild_do_table":     "REPLICATE_WILD_DO_TABLE",
+	"wild_ignore_table": "REPLICATE_WILD_IGNORE_TABLE",
+}
+
+var rewriteDbRuleRegex = regexp.MustCompile(`\(([^,()]*),([^,()]*)\)`)
+
+func resourceReplicationFilter() *schema.Resource {
+	filterSchema := map[string]*schema.Schema{
+		"channel": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  "",
+		},
+		"rewrite_db": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for key := range replicationFilterLists {
+		filterSchema[key] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: CreateOrUpdateReplicationFilter,
+		UpdateContext: CreateOrUpdateReplicationFilter,
+		ReadContext:   ReadReplicationFilter,
+		DeleteContext: DeleteReplicationFilter,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReplicationFilter,
+		},
+		Schema: filterSchema,
+	}
+}
+
+func CreateOrUpdateReplicationFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channel := d.Get("channel").(string)
+	rewrites := map[string]string{}
+	for from, to := range d.Get("rewrite_db").(map[string]interface{}) {
+		rewrites[from] = to.(string)
+	}
+	lists := map[string][]string{}
+	for key := range replicationFilterLists {
+		lists[key] = setToArray(d.Get(key))
+	}
+
+	if err := changeReplicationFilter(ctx, db, channel, lists, rewrites); err != nil {
+		return diag.Errorf("failed changing replication filter: %v", err)
+	}
+
+	if channel == "" {
+		d.SetId(defaultReplicationChannelId)
+	} else {
+		d.SetId(channel)
+	}
+
+	return ReadReplicationFilter(ctx, d, meta)
+}
+
+func ReadReplicationFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT FILTER_NAME, FILTER_RULE FROM performance_schema.replication_applier_filters WHERE CHANNEL_NAME = ?"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL, d.Get("channel").(string))
+	if err != nil {
+		return diag.Errorf("failed reading replication filters: %v", err)
+	}
+	defer rows.Close()
+
+	rules := map[string]string{}
+	for rows.Next() {
+		var name, rule string
+		if err := rows.Scan(&name, &rule); err != nil {
+			return diag.Errorf("failed scanning replication filters: %v", err)
+		}
+		rules[strings.ToUpper(name)] = rule
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading replication filters: %v", err)
+	}
+
+	for key, filterName := range replicationFilterLists {
+		values := []string{}
+		if rule := rules[filterName]; rule != "" {
+			values = strings.Split(rule, ",")
+		}
+		d.Set(key, values)
+	}
+
+	rewrites := map[string]string{}
+	for _, match := range rewriteDbRuleRegex.FindAllStringSubmatch(rules["REPLICATE_REWRITE_DB"], -1) {
+		rewrites[match[1]] = match[2]
+	}
+	d.Set("rewrite_db", rewrites)
+
+	return nil
+}
+
+func DeleteReplicationFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := changeReplicationFilter(ctx, db, d.Get("channel").(string), map[string][]string{}, map[string]string{}); err != nil {
+		return diag.Errorf("failed clearing replication filter: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportReplicationFilter(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != defaultReplicationChannelId {
+		d.Set("channel", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// changeReplicationFilter sets all filters of the channel, clearing the ones
+// that aren't given. Filters can only be changed with the applier stopped, so
+// it's stopped meanwhile if it's running.
+func changeReplicationFilter(ctx context.Context, db *sql.DB, channel string, lists map[string][]string, rewrites map[string]string) error {
+	status, err := showReplicaStatus(ctx, db, channel)
+	if err != nil {
+		return fmt.Errorf("failed reading replica status: %v", err)
+	}
+	if status == nil {
+		return fmt.Errorf("replication channel %q doesn't exist", channel)
+	}
+
+	restart := status["Replica_SQL_Running"] == "Yes"
+	if restart {
+		stmtSQL := "STOP REPLICA SQL_THREAD FOR CHANNEL ?"
+		logStatement(ctx, stmtSQL, channel)
+		if _, err := db.ExecContext(ctx, stmtSQL, channel); err != nil {
+			return fmt.Errorf("failed stopping replica: %v", err)
+		}
+	}
+
+	// No arguments are passed, so question marks in the filters are left alone.
+	stmtSQL := fmt.Sprintf("CHANGE REPLICATION FILTER %s FOR CHANNEL %s", replicationFilterSQL(lists, rewrites), quoteLiteral(channel))
+	logStatement(ctx, stmtSQL)
+	_, changeErr := db.ExecContext(ctx, stmtSQL)
+
+	if restart {
+		stmtSQL := "START REPLICA SQL_THREAD FOR CHANNEL ?"
+		logStatement(ctx, stmtSQL, channel)
+		if _, err := db.ExecContext(ctx, stmtSQL, channel); err != nil && changeErr == nil {
+			return fmt.Errorf("failed starting replica: %v", err)
+		}
+	}
+
+	return changeErr
+}
+
+func replicationFilterSQL(lists map[string][]string, rewrites map[string]string) string {
+	var filters []string
+	for _, key := range []string{"do_db", "ignore_db", "do_table", "ignore_table", "wild_do_table", "wild_ignore_table"} {
+		var values []string
+		for _, value := range lists[key] {
+			switch key {
+			case "do_db", "ignore_db":
+				values = append(values, quoteIdentifier(value))
+			case "do_table", "ignore_table":
+				parts := strings.SplitN(value, ".", 2)
+				if len(parts) == 2 {
+					values = append(values, fmt.Sprintf("%s.%s", quoteIdentifier(parts[0]), quoteIdentifier(parts[1])))
+				} else {
+					values = append(values, quoteIdentifier(value))
+				}
+			default:
+				values = append(values, quoteLiteral(value))
+			}
+		}
+		filters = append(filters, fmt.Sprintf("%s = (%s)", replicationFilterLists[key], strings.Join(values, ", ")))
+	}
+
+	var pairs []string
+	sources := make([]string, 0, len(rewrites))
+	for from := range rewrites {
+		sources = append(sources, from)
+	}
+	sort.Strings(sources)
+	for _, from := range sources {
+		pairs = append(pairs, fmt.Sprintf("(%s, %s)", quoteIdentifier(from), quoteIdentifier(rewrites[from])))
+	}
+	filters = append(filters, fmt.Sprintf("REPLICATE_REWRITE_DB = (%s)", strings.Join(pairs, ", ")))
+
+	return strings.Join(filters, ", ")
+}
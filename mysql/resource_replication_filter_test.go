@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccReplicationFilter_basic(t *testing.T) {
+	channel := "tf_test_filter_channel"
+	resourceName := "mysql_replication_filter.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "8.0.23")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccReplicationSourceCheckDestroy(channel),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccReplicationFilterConfig(channel, `["app"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "do_db.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "wild_ignore_table.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rewrite_db.app", "app_copy"),
+				),
+			},
+			{
+				Config: testAccReplicationFilterConfig(channel, `["app", "other"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "do_db.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccReplicationFilterConfig(channel string, doDb string) string {
+	return fmt.Sprintf(`
+resource "mysql_replication_source" "test" {
+  channel = "%s"
+  host    = "source.example.com"
+  user    = "replicator"
+  started = false
+}
+
+resource "mysql_replication_filter" "test" {
+  channel           = mysql_replication_source.test.channel
+  do_db             = %s
+  wild_ignore_table = ["app.tmp%%"]
+  rewrite_db = {
+    app = "app_copy"
+  }
+}
+`, channel, doDb)
+}
@@ -0,0 +1,328 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ID used for the default replication channel, which has an empty name.
+const defaultReplicationChannelId = "default"
+
+func resourceReplicationSource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateReplicationSource,
+		UpdateContext: UpdateReplicationSource,
+		ReadContext:   ReadReplicationSource,
+		DeleteContext: DeleteReplicationSource,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportReplicationSource,
+		},
+		Schema: map[string]*schema.Schema{
+			"channel": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "",
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  3306,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"auto_position": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ssl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ssl_ca": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ssl_verify_server_cert": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"started": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func getReplicaDatabaseFromMeta(ctx context.Context, meta interface{}) (*sql.DB, error) {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredVersion, _ := version.NewVersion("8.0.23")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return nil, fmt.Errorf("replication sources require MySQL 8.0.23 or newer")
+	}
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "replication sources"); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func CreateReplicationSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channel := d.Get("channel").(string)
+	options, args := replicationSourceOptionsSQL(d, false)
+	if err := changeReplicationSource(ctx, db, channel, options, args); err != nil {
+		return diag.Errorf("failed changing replication source: %v", err)
+	}
+
+	if channel == "" {
+		d.SetId(defaultReplicationChannelId)
+	} else {
+		d.SetId(channel)
+	}
+
+	if d.Get("started").(bool) {
+		if err := startOrStopReplica(ctx, db, channel, true); err != nil {
+			return diag.Errorf("failed starting replica: %v", err)
+		}
+	}
+
+	return ReadReplicationSource(ctx, d, meta)
+}
+
+func UpdateReplicationSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channel := d.Get("channel").(string)
+	options, args := replicationSourceOptionsSQL(d, true)
+	if len(options) > 0 {
+		// The source can only be changed with the replication threads stopped.
+		if err := startOrStopReplica(ctx, db, channel, false); err != nil {
+			return diag.Errorf("failed stopping replica: %v", err)
+		}
+		if err := changeReplicationSource(ctx, db, channel, options, args); err != nil {
+			return diag.Errorf("failed changing replication source: %v", err)
+		}
+	}
+
+	if len(options) > 0 || d.HasChange("started") {
+		if err := startOrStopReplica(ctx, db, channel, d.Get("started").(bool)); err != nil {
+			return diag.Errorf("failed starting or stopping replica: %v", err)
+		}
+	}
+
+	return ReadReplicationSource(ctx, d, meta)
+}
+
+func ReadReplicationSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := showReplicaStatus(ctx, db, d.Get("channel").(string))
+	if err != nil {
+		return diag.Errorf("failed reading replica status: %v", err)
+	}
+	if status == nil {
+		log.Printf("[WARN] Replication channel (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	port, err := strconv.Atoi(status["Source_Port"])
+	if err != nil {
+		return diag.Errorf("failed parsing source port %q: %v", status["Source_Port"], err)
+	}
+
+	d.Set("host", status["Source_Host"])
+	d.Set("port", port)
+	d.Set("user", status["Source_User"])
+	d.Set("auto_position", status["Auto_Position"] == "1")
+	d.Set("ssl", status["Source_SSL_Allowed"] != "No")
+	d.Set("ssl_ca", status["Source_SSL_CA_File"])
+	d.Set("ssl_verify_server_cert", status["Source_SSL_Verify_Server_Cert"] == "Yes")
+	d.Set("started", status["Replica_IO_Running"] != "No" || status["Replica_SQL_Running"] != "No")
+
+	return nil
+}
+
+func DeleteReplicationSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReplicaDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channel := d.Get("channel").(string)
+	if err := startOrStopReplica(ctx, db, channel, false); err != nil {
+		return diag.Errorf("failed stopping replica: %v", err)
+	}
+
+	stmtSQL := "RESET REPLICA ALL FOR CHANNEL ?"
+	logStatement(ctx, stmtSQL, channel)
+	if _, err := db.ExecContext(ctx, stmtSQL, channel); err != nil {
+		return diag.Errorf("failed resetting replica: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportReplicationSource(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != defaultReplicationChannelId {
+		d.Set("channel", d.Id())
+	}
+
+	id := d.Id()
+	diags := ReadReplicationSource(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading replication source: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("replication channel %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// replicationSourceOptionsSQL returns the options of CHANGE REPLICATION SOURCE
+// TO, limited to the changed ones when onlyChanged is set.
+func replicationSourceOptionsSQL(d *schema.ResourceData, onlyChanged bool) ([]string, []interface{}) {
+	var options []string
+	var args []interface{}
+	add := func(key string, option string, value interface{}) {
+		if onlyChanged && !d.HasChange(key) {
+			return
+		}
+		options = append(options, option+" = ?")
+		args = append(args, value)
+	}
+	boolValue := func(key string) int {
+		if d.Get(key).(bool) {
+			return 1
+		}
+		return 0
+	}
+
+	add("host", "SOURCE_HOST", d.Get("host").(string))
+	add("port", "SOURCE_PORT", d.Get("port").(int))
+	add("user", "SOURCE_USER", d.Get("user").(string))
+	// The password can't be read back, so it's only sent when it's configured.
+	if password := d.Get("password").(string); password != "" {
+		add("password", "SOURCE_PASSWORD", password)
+	}
+	add("auto_position", "SOURCE_AUTO_POSITION", boolValue("auto_position"))
+	add("ssl", "SOURCE_SSL", boolValue("ssl"))
+	if d.Get("ssl_ca").(string) != "" || d.HasChange("ssl_ca") {
+		add("ssl_ca", "SOURCE_SSL_CA", d.Get("ssl_ca").(string))
+	}
+	add("ssl_verify_server_cert", "SOURCE_SSL_VERIFY_SERVER_CERT", boolValue("ssl_verify_server_cert"))
+
+	return options, args
+}
+
+func changeReplicationSource(ctx context.Context, db *sql.DB, channel string, options []string, args []interface{}) error {
+	stmtSQL := fmt.Sprintf("CHANGE REPLICATION SOURCE TO %s FOR CHANNEL ?", strings.Join(options, ", "))
+	// Arguments aren't logged, as they contain the password.
+	logStatement(ctx, stmtSQL)
+
+	_, err := db.ExecContext(ctx, stmtSQL, append(args, channel)...)
+	return err
+}
+
+func startOrStopReplica(ctx context.Context, db *sql.DB, channel string, start bool) error {
+	stmtSQL := "STOP REPLICA FOR CHANNEL ?"
+	if start {
+		stmtSQL = "START REPLICA FOR CHANNEL ?"
+	}
+	logStatement(ctx, stmtSQL, channel)
+
+	_, err := db.ExecContext(ctx, stmtSQL, channel)
+	return err
+}
+
+// showReplicaStatus returns the row of SHOW REPLICA STATUS for the channel by
+// column name, or nil if the channel doesn't exist.
+func showReplicaStatus(ctx context.Context, db *sql.DB, channel string) (map[string]string, error) {
+	stmtSQL := "SHOW REPLICA STATUS FOR CHANNEL ?"
+	logStatement(ctx, stmtSQL, channel)
+
+	return queryStatusRow(ctx, db, stmtSQL, channel)
+}
+
+// queryStatusRow returns the first row of the statement by column name, or
+// nil if there's none. The columns differ between versions, so they're read
+// by name.
+func queryStatusRow(ctx context.Context, db *sql.DB, stmtSQL string, args ...interface{}) (map[string]string, error) {
+	rows, err := queryStatusRows(ctx, db, stmtSQL, args...)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return rows[0], nil
+}
+
+// queryStatusRows returns all rows of the statement by column name.
+func queryStatusRows(ctx context.Context, db *sql.DB, stmtSQL string, args ...interface{}) ([]map[string]string, error) {
+	rows, err := db.QueryContext(ctx, stmtSQL, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	result := []map[string]string{}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		status := make(map[string]string, len(columns))
+		for i, column := range columns {
+			status[column] = values[i].String
+		}
+		result = append(result, status)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,85 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReplicationSource_basic(t *testing.T) {
+	channel := "tf_test_channel"
+	resourceName := "mysql_replication_source.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "8.0.23")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccReplicationSourceCheckDestroy(channel),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccReplicationSourceConfig(channel, "source.example.com", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host", "source.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "port", "3306"),
+					resource.TestCheckResourceAttr(resourceName, "auto_position", "true"),
+					resource.TestCheckResourceAttr(resourceName, "ssl", "false"),
+					resource.TestCheckResourceAttr(resourceName, "started", "false"),
+				),
+			},
+			{
+				Config: testAccReplicationSourceConfig(channel, "other.example.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host", "other.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "ssl", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccReplicationSourceCheckDestroy(channel string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM performance_schema.replication_connection_configuration WHERE CHANNEL_NAME = ?", channel).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading replication channels: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("replication channel %s still exists after destroy", channel)
+		}
+
+		return nil
+	}
+}
+
+func testAccReplicationSourceConfig(channel string, host string, ssl bool) string {
+	return fmt.Sprintf(`
+resource "mysql_replication_source" "test" {
+  channel  = "%s"
+  host     = "%s"
+  user     = "replicator"
+  password = "secret"
+  ssl      = %t
+  started  = false
+}
+`, channel, host, ssl)
+}
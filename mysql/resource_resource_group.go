@@ -0,0 +1,215 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceResourceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateResourceGroup,
+		UpdateContext: UpdateResourceGroup,
+		ReadContext:   ReadResourceGroup,
+		DeleteContext: DeleteResourceGroup,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportResourceGroup,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "USER",
+				ValidateFunc: validation.StringInSlice([]string{"USER", "SYSTEM"}, false),
+			},
+			"vcpus": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+(-[0-9]+)?$`), "must be a CPU number or a range like 0-3"),
+				},
+			},
+			"thread_priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(-20, 19),
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func CreateResourceGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkResourceGroupsSupported(ctx, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	stmtSQL := fmt.Sprintf("CREATE RESOURCE GROUP %s TYPE = %s%s", quoteIdentifier(name), d.Get("type").(string), resourceGroupOptionsSQL(d))
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed creating resource group: %v", err)
+	}
+
+	d.SetId(name)
+
+	return ReadResourceGroup(ctx, d, meta)
+}
+
+func UpdateResourceGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !d.HasChangesExcept("force") {
+		return ReadResourceGroup(ctx, d, meta)
+	}
+
+	// Without FORCE, threads of a disabled group keep running in it until
+	// they end; with it, they're moved to the default group.
+	stmtSQL := fmt.Sprintf("ALTER RESOURCE GROUP %s%s", quoteIdentifier(d.Id()), resourceGroupOptionsSQL(d))
+	if d.Get("force").(bool) {
+		stmtSQL += " FORCE"
+	}
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed updating resource group: %v", err)
+	}
+
+	return ReadResourceGroup(ctx, d, meta)
+}
+
+func ReadResourceGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT RESOURCE_GROUP_TYPE, RESOURCE_GROUP_ENABLED, VCPU_IDS, THREAD_PRIORITY FROM INFORMATION_SCHEMA.RESOURCE_GROUPS WHERE RESOURCE_GROUP_NAME = ?"
+	logStatement(ctx, stmtSQL, d.Id())
+
+	var groupType string
+	var enabled bool
+	var vcpus sql.NullString
+	var threadPriority int
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&groupType, &enabled, &vcpus, &threadPriority)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Resource group (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading resource group: %v", err)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("type", groupType)
+	d.Set("enabled", enabled)
+	d.Set("vcpus", parseVCPUs(vcpus.String))
+	d.Set("thread_priority", threadPriority)
+
+	return nil
+}
+
+func DeleteResourceGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Dropping a group that threads are assigned to fails without FORCE.
+	stmtSQL := "DROP RESOURCE GROUP " + quoteIdentifier(d.Id())
+	if d.Get("force").(bool) {
+		stmtSQL += " FORCE"
+	}
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed deleting resource group: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportResourceGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("force", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func checkResourceGroupsSupported(ctx context.Context, meta interface{}) error {
+	if getFlavorFromMeta(ctx, meta) != flavorMySQL {
+		return fmt.Errorf("resource groups are not supported on %s", getFlavorFromMeta(ctx, meta))
+	}
+	requiredVersion, _ := version.NewVersion("8.0.3")
+	if getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		return fmt.Errorf("resource groups require MySQL 8.0.3 or newer")
+	}
+	return nil
+}
+
+// resourceGroupOptionsSQL returns the options shared by CREATE and ALTER
+// RESOURCE GROUP. Without vcpus, the group runs on all CPUs.
+func resourceGroupOptionsSQL(d *schema.ResourceData) string {
+	options := ""
+	if vcpus := d.Get("vcpus").([]interface{}); len(vcpus) > 0 {
+		ranges := make([]string, 0, len(vcpus))
+		for _, vcpu := range vcpus {
+			ranges = append(ranges, vcpu.(string))
+		}
+		options += " VCPU = " + strings.Join(ranges, ",")
+	}
+	options += fmt.Sprintf(" THREAD_PRIORITY = %d", d.Get("thread_priority").(int))
+	if d.Get("enabled").(bool) {
+		options += " ENABLE"
+	} else {
+		options += " DISABLE"
+	}
+	return options
+}
+
+// parseVCPUs splits VCPU_IDS, e.g. "0-3,5", into its ranges.
+func parseVCPUs(vcpus string) []string {
+	ranges := []string{}
+	for _, vcpu := range strings.Split(vcpus, ",") {
+		if vcpu = strings.TrimSpace(vcpu); vcpu != "" {
+			ranges = append(ranges, vcpu)
+		}
+	}
+	return ranges
+}
@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceGroup_basic(t *testing.T) {
+	groupName := "tf_test_batch"
+	resourceName := "mysql_resource_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipRds(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceGroupCheckDestroy(groupName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupConfig(groupName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", groupName),
+					resource.TestCheckResourceAttr(resourceName, "type", "USER"),
+					resource.TestCheckResourceAttr(resourceName, "vcpus.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "vcpus.0", "0"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccResourceGroupConfig(groupName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceGroupOptionsSQL(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceResourceGroup().Schema, map[string]interface{}{
+		"name":            "batch",
+		"vcpus":           []interface{}{"0-3", "5"},
+		"thread_priority": 10,
+		"enabled":         false,
+	})
+
+	if got, want := resourceGroupOptionsSQL(d), " VCPU = 0-3,5 THREAD_PRIORITY = 10 DISABLE"; got != want {
+		t.Errorf("resourceGroupOptionsSQL() = %q, want %q", got, want)
+	}
+	if got, want := parseVCPUs("0-3,5"), []string{"0-3", "5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseVCPUs() = %v, want %v", got, want)
+	}
+}
+
+func testAccResourceGroupCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.RESOURCE_GROUPS WHERE RESOURCE_GROUP_NAME = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading resource groups: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("resource group %s still exists after destroy", name)
+		}
+		return nil
+	}
+}
+
+func testAccResourceGroupConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "mysql_resource_group" "test" {
+  name    = "%s"
+  vcpus   = ["0"]
+  enabled = %t
+}
+`, name, enabled)
+}
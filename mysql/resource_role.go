@@ -2,9 +2,11 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -14,72 +16,164 @@ func resourceRole() *schema.Resource {
 		CreateContext: CreateRole,
 		ReadContext:   ReadRole,
 		DeleteContext: DeleteRole,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportRole,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserName,
+			},
+
+			"host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "%",
+				ValidateFunc: validateHostPattern,
 			},
 		},
 	}
 }
 
+func roleFromData(d *schema.ResourceData) UserOrRole {
+	role := UserOrRole{Name: d.Get("name").(string)}
+	// Roles without a host are stored with '%' by MySQL. Leaving it out keeps
+	// the statements compatible with MariaDB, which doesn't have role hosts.
+	if host := d.Get("host").(string); host != "%" {
+		role.Host = host
+	}
+	return role
+}
+
 func CreateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	db, err := getDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	roleName := d.Get("name").(string)
+	defer lockPrivilegeChanges(ctx, meta)()
+	defer invalidateGrants(ctx, meta, roleFromData(d))
 
-	sql := fmt.Sprintf("CREATE ROLE '%s'", roleName)
-	log.Printf("[DEBUG] SQL: %s", sql)
+	role := roleFromData(d)
+
+	sql := fmt.Sprintf("CREATE ROLE IF NOT EXISTS %s", role.SQLString())
+	logStatement(ctx, sql)
 
 	_, err = db.ExecContext(ctx, sql)
 	if err != nil {
 		return diag.Errorf("error creating role: %s", err)
 	}
 
-	d.SetId(roleName)
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId(role.IDString())
 
 	return nil
 }
 
 func ReadRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getReadDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	sql := fmt.Sprintf("SHOW GRANTS FOR '%s'", d.Id())
-	log.Printf("[DEBUG] SQL: %s", sql)
+	nameHost := strings.SplitN(d.Id(), "@", 2)
+	name := nameHost[0]
+	host := "%"
+	if len(nameHost) == 2 {
+		host = nameHost[1]
+	}
 
-	_, err = db.ExecContext(ctx, sql)
+	exists, err := roleExists(ctx, db, name, host)
 	if err != nil {
+		return diag.Errorf("failed reading role %s: %v", d.Id(), err)
+	}
+	if !exists {
 		log.Printf("[WARN] Role (%s) not found; removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	d.Set("name", d.Id())
+	d.Set("name", name)
+	d.Set("host", host)
 
 	return nil
 }
 
+// roleExists checks mysql.user, where roles are stored as locked accounts.
+// If mysql.user can't be read (e.g. missing privileges), it falls back to
+// checking whether SHOW GRANTS succeeds for the role.
+func roleExists(ctx context.Context, db *sql.DB, name string, host string) (bool, error) {
+	var accountLocked string
+	// MariaDB stores roles with an empty host.
+	err := db.QueryRowContext(ctx, "SELECT account_locked FROM mysql.user WHERE User = ? AND (Host = ? OR (? = '%' AND Host = ''))", name, host, host).Scan(&accountLocked)
+	if err == nil {
+		if accountLocked != "Y" {
+			log.Printf("[WARN] Role %s@%s is not locked; it might be a user rather than a role", name, host)
+		}
+		return true, nil
+	}
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	log.Printf("[DEBUG] Reading role from mysql.user failed, falling back to SHOW GRANTS: %v", err)
+	role := UserOrRole{Name: name}
+	if host != "%" {
+		role.Host = host
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SHOW GRANTS FOR %s", role.SQLString()))
+	// 1141 = ER_NONEXISTING_GRANT, which SHOW GRANTS fails with for unknown
+	// accounts. Other errors, e.g. missing privileges, don't tell.
+	if mysqlErrorNumber(err) == 1141 {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	rows.Close()
+	return true, nil
+}
+
 func DeleteRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	db, err := getDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	sql := fmt.Sprintf("DROP ROLE '%s'", d.Get("name").(string))
-	log.Printf("[DEBUG] SQL: %s", sql)
+	defer lockPrivilegeChanges(ctx, meta)()
+	defer invalidateGrants(ctx, meta, roleFromData(d))
+
+	sql := fmt.Sprintf("DROP ROLE %s", roleFromData(d).SQLString())
+	logStatement(ctx, sql)
 
 	_, err = db.ExecContext(ctx, sql)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
 	return nil
 }
+
+func ImportRole(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	err := ReadRole(ctx, d, meta)
+	if err.HasError() {
+		return nil, fmt.Errorf("failed reading role: %v", err)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("role %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -43,8 +43,15 @@ func TestAccRole_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccRoleExists(roleName),
 					resource.TestCheckResourceAttr(resourceName, "name", roleName),
+					resource.TestCheckResourceAttr(resourceName, "host", "%"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     roleName,
+			},
 		},
 	})
 }
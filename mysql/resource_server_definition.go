@@ -0,0 +1,189 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverDefinitionOptions maps attributes to the string options of CREATE
+// SERVER. PORT is a number and handled apart.
+var serverDefinitionOptions = []struct {
+	attribute string
+	option    string
+}{
+	{"host", "HOST"},
+	{"database", "DATABASE"},
+	{"user", "USER"},
+	{"password", "PASSWORD"},
+	{"socket", "SOCKET"},
+	{"owner", "OWNER"},
+}
+
+func resourceServerDefinition() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateServerDefinition,
+		UpdateContext: UpdateServerDefinition,
+		ReadContext:   ReadServerDefinition,
+		DeleteContext: DeleteServerDefinition,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"wrapper": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "mysql",
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"database": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"socket": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func CreateServerDefinition(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkFlavorUnsupported(ctx, meta, flavorTiDB, "server definitions"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	stmtSQL := fmt.Sprintf("CREATE SERVER %s FOREIGN DATA WRAPPER %s %s",
+		quoteIdentifier(name), quoteIdentifier(d.Get("wrapper").(string)), serverDefinitionOptionsSQL(d, false))
+	// The password is logged redacted.
+	logStatement(ctx, fmt.Sprintf("CREATE SERVER %s FOREIGN DATA WRAPPER %s %s",
+		quoteIdentifier(name), quoteIdentifier(d.Get("wrapper").(string)), serverDefinitionOptionsSQL(d, true)))
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed creating server definition: %v", err)
+	}
+
+	d.SetId(name)
+
+	return ReadServerDefinition(ctx, d, meta)
+}
+
+func UpdateServerDefinition(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// ALTER SERVER keeps the options it isn't given, so all of them are sent.
+	stmtSQL := fmt.Sprintf("ALTER SERVER %s %s", quoteIdentifier(d.Id()), serverDefinitionOptionsSQL(d, false))
+	logStatement(ctx, fmt.Sprintf("ALTER SERVER %s %s", quoteIdentifier(d.Id()), serverDefinitionOptionsSQL(d, true)))
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed updating server definition: %v", err)
+	}
+
+	return ReadServerDefinition(ctx, d, meta)
+}
+
+func ReadServerDefinition(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT Wrapper, Host, Db, Username, Password, Port, Socket, Owner FROM mysql.servers WHERE Server_name = ?"
+	logStatement(ctx, stmtSQL, d.Id())
+
+	var wrapper, host, database, user, password, socket, owner string
+	var port int
+	err = db.QueryRowContext(ctx, stmtSQL, d.Id()).Scan(&wrapper, &host, &database, &user, &password, &port, &socket, &owner)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Server definition (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading server definition: %v", err)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("wrapper", wrapper)
+	d.Set("host", host)
+	d.Set("database", database)
+	d.Set("user", user)
+	d.Set("password", password)
+	d.Set("port", port)
+	d.Set("socket", socket)
+	d.Set("owner", owner)
+
+	return nil
+}
+
+func DeleteServerDefinition(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "DROP SERVER IF EXISTS " + quoteIdentifier(d.Id())
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed deleting server definition: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// serverDefinitionOptionsSQL returns the OPTIONS clause, with the password
+// replaced if it's for the log.
+func serverDefinitionOptionsSQL(d *schema.ResourceData, redact bool) string {
+	var options []string
+	for _, opt := range serverDefinitionOptions {
+		value := d.Get(opt.attribute).(string)
+		if redact && opt.attribute == "password" && value != "" {
+			value = "<sensitive>"
+		}
+		options = append(options, fmt.Sprintf("%s %s", opt.option, quoteLiteral(value)))
+	}
+	options = append(options, fmt.Sprintf("PORT %d", d.Get("port").(int)))
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(options, ", "))
+}
@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerDefinition_basic(t *testing.T) {
+	serverName := "tf_test_remote"
+	resourceName := "mysql_server_definition.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipRds(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccServerDefinitionCheckDestroy(serverName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServerDefinitionConfig(serverName, "remote1.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", serverName),
+					resource.TestCheckResourceAttr(resourceName, "wrapper", "mysql"),
+					resource.TestCheckResourceAttr(resourceName, "host", "remote1.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "port", "3306"),
+				),
+			},
+			{
+				Config: testAccServerDefinitionConfig(serverName, "remote2.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host", "remote2.example.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestServerDefinitionOptionsSQL(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceServerDefinition().Schema, map[string]interface{}{
+		"name":     "remote",
+		"host":     "remote.example.com",
+		"database": "app",
+		"user":     "federated",
+		"password": "it's secret",
+		"port":     3307,
+	})
+
+	want := "OPTIONS (HOST 'remote.example.com', DATABASE 'app', USER 'federated', PASSWORD 'it''s secret', SOCKET '', OWNER '', PORT 3307)"
+	if got := serverDefinitionOptionsSQL(d, false); got != want {
+		t.Errorf("serverDefinitionOptionsSQL() = %q, want %q", got, want)
+	}
+	want = "OPTIONS (HOST 'remote.example.com', DATABASE 'app', USER 'federated', PASSWORD '<sensitive>', SOCKET '', OWNER '', PORT 3307)"
+	if got := serverDefinitionOptionsSQL(d, true); got != want {
+		t.Errorf("serverDefinitionOptionsSQL(redact) = %q, want %q", got, want)
+	}
+}
+
+func testAccServerDefinitionCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM mysql.servers WHERE Server_name = ?", name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading server definitions: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("server definition %s still exists after destroy", name)
+		}
+		return nil
+	}
+}
+
+func testAccServerDefinitionConfig(name string, host string) string {
+	return fmt.Sprintf(`
+resource "mysql_server_definition" "test" {
+  name     = "%s"
+  host     = "%s"
+  database = "app"
+  user     = "federated"
+  password = "secret"
+  port     = 3306
+}
+`, name, host)
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"log"
 
@@ -12,7 +13,9 @@ func resourceSql() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: CreateSql,
 		ReadContext:   ReadSql,
+		UpdateContext: UpdateSql,
 		DeleteContext: DeleteSql,
+		CustomizeDiff: customizeDiffSql,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -23,17 +26,39 @@ func resourceSql() *schema.Resource {
 			"create_sql": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"delete_sql": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+			},
+			"update_sql": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"check_query": {
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 		},
 	}
 }
 
+// customizeDiffSql replaces the resource when create_sql or delete_sql change,
+// unless update_sql says how to change it in place.
+func customizeDiffSql(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("update_sql").(string) != "" {
+		return nil
+	}
+	for _, key := range []string{"create_sql", "delete_sql"} {
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func CreateSql(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	db, err := getDatabaseFromMeta(ctx, meta)
 	if err != nil {
@@ -42,7 +67,17 @@ func CreateSql(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	name := d.Get("name").(string)
 	createSql := d.Get("create_sql").(string)
 
-	log.Println("Executing SQL", createSql)
+	exists, err := checkSql(ctx, db, d)
+	if err != nil {
+		return diag.Errorf("failed to run check query: %v", err)
+	}
+	if exists {
+		log.Printf("[DEBUG] check_query of %s returned rows, skipping create_sql", name)
+		d.SetId(name)
+		return nil
+	}
+
+	logStatement(ctx, createSql)
 
 	_, err = db.ExecContext(ctx, createSql)
 	if err != nil {
@@ -55,6 +90,49 @@ func CreateSql(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 }
 
 func ReadSql(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("check_query").(string) == "" {
+		return nil
+	}
+
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exists, err := checkSql(ctx, db, d)
+	if err != nil {
+		return diag.Errorf("failed to run check query: %v", err)
+	}
+	if !exists {
+		log.Printf("[WARN] check_query of %s returned no rows; removing from state", d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func UpdateSql(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A changed delete_sql is only stored, it's used when destroying.
+	if !d.HasChanges("create_sql", "update_sql") {
+		return nil
+	}
+
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	updateSql := d.Get("update_sql").(string)
+	if updateSql == "" {
+		return nil
+	}
+
+	logStatement(ctx, updateSql)
+
+	_, err = db.ExecContext(ctx, updateSql)
+	if err != nil {
+		return diag.Errorf("failed to run update SQL: %v", err)
+	}
+
 	return nil
 }
 
@@ -65,7 +143,7 @@ func DeleteSql(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	}
 	deleteSql := d.Get("delete_sql").(string)
 
-	log.Println("Executing SQL:", deleteSql)
+	logStatement(ctx, deleteSql)
 
 	_, err = db.ExecContext(ctx, deleteSql)
 	if err != nil {
@@ -75,3 +153,26 @@ func DeleteSql(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	d.SetId("")
 	return nil
 }
+
+// checkSql returns whether check_query returns any rows. Without check_query
+// it returns false, so create_sql always runs.
+func checkSql(ctx context.Context, db *sql.DB, d *schema.ResourceData) (bool, error) {
+	checkQuery := d.Get("check_query").(string)
+	if checkQuery == "" {
+		return false, nil
+	}
+
+	logStatement(ctx, checkQuery)
+
+	rows, err := db.QueryContext(ctx, checkQuery)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	exists := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSql_basic(t *testing.T) {
+	dbName := "tf_test_sql"
+	resourceName := "mysql_sql.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccSqlCheckDatabase(dbName, false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSqlConfig(dbName, "utf8mb4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", dbName),
+					testAccSqlCheckDatabase(dbName, true),
+				),
+			},
+			{
+				// update_sql runs in place instead of replacing the resource.
+				Config: testAccSqlConfig(dbName, "latin1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "update_sql", fmt.Sprintf("ALTER DATABASE %s CHARACTER SET latin1", dbName)),
+					testAccSqlCheckDatabase(dbName, true),
+				),
+			},
+			{
+				// The database is re-created when check_query finds it missing.
+				PreConfig: func() {
+					db, err := connectToMySQL(context.Background(), testAccProvider.Meta().(*MySQLConfiguration))
+					if err != nil {
+						return
+					}
+					db.Exec(fmt.Sprintf("DROP DATABASE `%s`", dbName))
+				},
+				Config: testAccSqlConfig(dbName, "latin1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSqlCheckDatabase(dbName, true),
+				),
+			},
+		},
+	})
+}
+
+func testAccSqlCheckDatabase(dbName string, shouldExist bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME = ?", dbName).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading databases: %s", err)
+		}
+		if shouldExist && count == 0 {
+			return fmt.Errorf("database %s not found", dbName)
+		}
+		if !shouldExist && count != 0 {
+			return fmt.Errorf("database %s still exists", dbName)
+		}
+
+		return nil
+	}
+}
+
+func testAccSqlConfig(dbName string, charset string) string {
+	return fmt.Sprintf(`
+resource "mysql_sql" "test" {
+  name        = "%[1]s"
+  create_sql  = "CREATE DATABASE %[1]s CHARACTER SET %[2]s"
+  update_sql  = "ALTER DATABASE %[1]s CHARACTER SET %[2]s"
+  delete_sql  = "DROP DATABASE %[1]s"
+  check_query = "SELECT 1 FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME = '%[1]s'"
+}
+`, dbName, charset)
+}
@@ -0,0 +1,399 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const kRoutineProcedure = "PROCEDURE"
+
+func resourceStoredProcedure() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateStoredProcedure,
+		UpdateContext: UpdateStoredProcedure,
+		ReadContext:   ReadStoredProcedure,
+		DeleteContext: DeleteStoredProcedure,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportStoredProcedure,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"parameters": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: whitespaceSuppressFunc,
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: trimSpaceSuppressFunc,
+			},
+			"definer": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: definerSuppressFunc,
+			},
+			"sql_security": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DEFINER",
+				ValidateFunc: validation.StringInSlice([]string{"DEFINER", "INVOKER"}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func CreateStoredProcedure(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createRoutine(ctx, db, kRoutineProcedure, d, ""); err != nil {
+		return diag.Errorf("failed creating procedure: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", d.Get("database").(string), d.Get("name").(string)))
+
+	return ReadStoredProcedure(ctx, d, meta)
+}
+
+func UpdateStoredProcedure(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("parameters", "body", "definer") {
+		if err := replaceRoutine(ctx, db, kRoutineProcedure, d, ""); err != nil {
+			return diag.Errorf("failed replacing procedure: %v", err)
+		}
+	} else if err := alterRoutine(ctx, db, kRoutineProcedure, d, ""); err != nil {
+		return diag.Errorf("failed updating procedure: %v", err)
+	}
+
+	return ReadStoredProcedure(ctx, d, meta)
+}
+
+func ReadStoredProcedure(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	routine, err := readRoutine(ctx, db, kRoutineProcedure, d.Get("database").(string), d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("failed reading procedure: %v", err)
+	}
+	if routine == nil {
+		log.Printf("[WARN] Procedure (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("parameters", routine.Parameters)
+	d.Set("body", routine.Body)
+	d.Set("definer", routine.Definer)
+	d.Set("sql_security", routine.SQLSecurity)
+	d.Set("comment", routine.Comment)
+
+	return nil
+}
+
+func DeleteStoredProcedure(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := dropRoutine(ctx, db, kRoutineProcedure, d.Get("database").(string), d.Get("name").(string)); err != nil {
+		return diag.Errorf("failed dropping procedure: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportStoredProcedure(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := importRoutineId(d); err != nil {
+		return nil, err
+	}
+
+	id := d.Id()
+	diags := ReadStoredProcedure(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading procedure: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("procedure %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// Routine is a stored procedure or function as read from the server.
+type Routine struct {
+	Parameters  string
+	Body        string
+	Definer     string
+	SQLSecurity string
+	Comment     string
+	// Only used by functions.
+	Returns       string
+	Deterministic bool
+	DataAccess    string
+}
+
+// readRoutine returns nil if the routine doesn't exist. Parameters come from
+// SHOW CREATE, as INFORMATION_SCHEMA normalizes them.
+func readRoutine(ctx context.Context, db *sql.DB, routineType string, database string, name string) (*Routine, error) {
+	stmtSQL := `SELECT ROUTINE_DEFINITION, DEFINER, SECURITY_TYPE, ROUTINE_COMMENT,
+		DTD_IDENTIFIER, IS_DETERMINISTIC, SQL_DATA_ACCESS
+		FROM INFORMATION_SCHEMA.ROUTINES WHERE ROUTINE_SCHEMA = ? AND ROUTINE_NAME = ? AND ROUTINE_TYPE = ?`
+	logStatement(ctx, stmtSQL)
+
+	var body, returns sql.NullString
+	var deterministic string
+	routine := Routine{}
+	err := db.QueryRowContext(ctx, stmtSQL, database, name, routineType).Scan(
+		&body, &routine.Definer, &routine.SQLSecurity, &routine.Comment,
+		&returns, &deterministic, &routine.DataAccess)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	routine.Body = body.String
+	routine.Returns = returns.String
+	routine.Deterministic = deterministic == "YES"
+
+	createSQL, err := showCreateRoutine(ctx, db, routineType, database, name)
+	if err != nil {
+		return nil, err
+	}
+	parameters, _, err := extractRoutineParameters(createSQL, routineType)
+	if err != nil {
+		return nil, err
+	}
+	routine.Parameters = parameters
+
+	return &routine, nil
+}
+
+func showCreateRoutine(ctx context.Context, db *sql.DB, routineType string, database string, name string) (string, error) {
+	stmtSQL := fmt.Sprintf("SHOW CREATE %s %s.%s", routineType, quoteIdentifier(database), quoteIdentifier(name))
+	logStatement(ctx, stmtSQL)
+
+	rows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	// The number of columns differs between MySQL, MariaDB and TiDB.
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%s %s.%s not found", strings.ToLower(routineType), database, name)
+	}
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return "", err
+	}
+
+	wanted := "Create " + string(routineType[0]) + strings.ToLower(routineType[1:])
+	for i, column := range columns {
+		if strings.EqualFold(column, wanted) {
+			if !values[i].Valid {
+				return "", fmt.Errorf("no privileges to read the definition of %s.%s", database, name)
+			}
+			return values[i].String, nil
+		}
+	}
+	return "", fmt.Errorf("unexpected output of %s", stmtSQL)
+}
+
+// extractRoutineParameters returns the parameter list of a CREATE PROCEDURE or
+// CREATE FUNCTION statement and everything after it.
+func extractRoutineParameters(createSQL string, routineType string) (string, string, error) {
+	upper := strings.ToUpper(createSQL)
+	start := strings.Index(upper, " "+routineType+" ")
+	if start == -1 {
+		return "", "", fmt.Errorf("failed parsing %s", createSQL)
+	}
+	i := start + len(routineType) + 2
+
+	// Skip the (possibly quoted) name.
+	if i < len(createSQL) && createSQL[i] == '`' {
+		for i++; i < len(createSQL); i++ {
+			if createSQL[i] == '`' {
+				if i+1 < len(createSQL) && createSQL[i+1] == '`' {
+					i++
+					continue
+				}
+				break
+			}
+		}
+	}
+	open := strings.IndexByte(createSQL[i:], '(')
+	if open == -1 {
+		return "", "", fmt.Errorf("failed parsing %s", createSQL)
+	}
+	i += open
+
+	depth := 0
+	var quote byte
+	for j := i; j < len(createSQL); j++ {
+		c := createSQL[j]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(createSQL[i+1 : j]), createSQL[j+1:], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("failed parsing %s", createSQL)
+}
+
+func createRoutine(ctx context.Context, db *sql.DB, routineType string, d *schema.ResourceData, characteristics string) error {
+	var definerClause string
+	if definer := d.Get("definer").(string); definer != "" {
+		definerClause = " DEFINER = " + parseDefiner(definer).SQLString()
+	}
+
+	stmtSQL := fmt.Sprintf("CREATE%s %s %s.%s(%s)",
+		definerClause,
+		routineType,
+		quoteIdentifier(d.Get("database").(string)),
+		quoteIdentifier(d.Get("name").(string)),
+		d.Get("parameters").(string))
+	if characteristics != "" {
+		stmtSQL += " " + characteristics
+	}
+	stmtSQL += " " + routineCharacteristicsSQL(d) + "\n" + d.Get("body").(string)
+	logStatement(ctx, stmtSQL)
+
+	// No arguments are passed, so question marks in the body are left alone.
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+// replaceRoutine drops and re-creates the routine, as MySQL has no CREATE OR
+// REPLACE for routines. If creating fails, the routine stays dropped and the
+// next apply creates it again.
+func replaceRoutine(ctx context.Context, db *sql.DB, routineType string, d *schema.ResourceData, characteristics string) error {
+	if err := dropRoutine(ctx, db, routineType, d.Get("database").(string), d.Get("name").(string)); err != nil {
+		return err
+	}
+	return createRoutine(ctx, db, routineType, d, characteristics)
+}
+
+func alterRoutine(ctx context.Context, db *sql.DB, routineType string, d *schema.ResourceData, characteristics string) error {
+	stmtSQL := fmt.Sprintf("ALTER %s %s.%s %s",
+		routineType,
+		quoteIdentifier(d.Get("database").(string)),
+		quoteIdentifier(d.Get("name").(string)),
+		routineCharacteristicsSQL(d))
+	if characteristics != "" {
+		stmtSQL += " " + characteristics
+	}
+	logStatement(ctx, stmtSQL)
+
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+func dropRoutine(ctx context.Context, db *sql.DB, routineType string, database string, name string) error {
+	stmtSQL := fmt.Sprintf("DROP %s IF EXISTS %s.%s", routineType, quoteIdentifier(database), quoteIdentifier(name))
+	logStatement(ctx, stmtSQL)
+
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
+// routineCharacteristicsSQL returns the characteristics that can be changed
+// with ALTER PROCEDURE and ALTER FUNCTION.
+func routineCharacteristicsSQL(d *schema.ResourceData) string {
+	return fmt.Sprintf("SQL SECURITY %s COMMENT %s",
+		strings.ToUpper(d.Get("sql_security").(string)),
+		quoteLiteral(d.Get("comment").(string)))
+}
+
+// parseDefiner parses definers like "user@host" as reported by
+// INFORMATION_SCHEMA. A missing host means "%".
+func parseDefiner(definer string) UserOrRole {
+	userHost := parseRoleSpec(definer)
+	if userHost.Host == "" {
+		userHost.Host = "%"
+	}
+	return userHost
+}
+
+func definerSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	// An empty definer means the current user, which is what was read back.
+	return new == "" || parseDefiner(old) == parseDefiner(new)
+}
+
+func importRoutineId(d *schema.ResourceData) error {
+	parts := strings.SplitN(d.Id(), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("wrong ID format %s (expected database.name)", d.Id())
+	}
+
+	d.Set("database", parts[0])
+	d.Set("name", parts[1])
+	return nil
+}
+
+func whitespaceSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return strings.Join(strings.Fields(old), " ") == strings.Join(strings.Fields(new), " ")
+}
+
+func trimSpaceSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStoredProcedure_basic(t *testing.T) {
+	dbName := "tf_test_procedure"
+	resourceName := "mysql_stored_procedure.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipTiDB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRoutineCheckDestroy(dbName, "add_numbers", "PROCEDURE"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStoredProcedureConfig(dbName, "DEFINER", "a + b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRoutineExists(dbName, "add_numbers", "PROCEDURE"),
+					resource.TestCheckResourceAttr(resourceName, "parameters", "IN a INT, IN b INT, OUT total INT"),
+					resource.TestCheckResourceAttr(resourceName, "sql_security", "DEFINER"),
+					resource.TestCheckResourceAttrSet(resourceName, "definer"),
+				),
+			},
+			{
+				Config: testAccStoredProcedureConfig(dbName, "INVOKER", "a + b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "sql_security", "INVOKER"),
+				),
+			},
+			{
+				Config: testAccStoredProcedureConfig(dbName, "INVOKER", "a + b + 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRoutineExists(dbName, "add_numbers", "PROCEDURE"),
+					resource.TestCheckResourceAttr(resourceName, "body", "BEGIN\n  SET total = a + b + 1;\nEND\n"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s.add_numbers", dbName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRoutineExists(dbName string, name string, routineType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.ROUTINES WHERE ROUTINE_SCHEMA = ? AND ROUTINE_NAME = ? AND ROUTINE_TYPE = ?", dbName, name, routineType).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading routines: %s", err)
+		}
+		if count != 1 {
+			return fmt.Errorf("%s %s.%s not found", routineType, dbName, name)
+		}
+
+		return nil
+	}
+}
+
+func testAccRoutineCheckDestroy(dbName string, name string, routineType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.ROUTINES WHERE ROUTINE_SCHEMA = ? AND ROUTINE_NAME = ? AND ROUTINE_TYPE = ?", dbName, name, routineType).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading routines: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("%s %s.%s still exists after destroy", routineType, dbName, name)
+		}
+
+		return nil
+	}
+}
+
+func testAccStoredProcedureConfig(dbName string, sqlSecurity string, expression string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_stored_procedure" "test" {
+  database     = mysql_database.test.name
+  name         = "add_numbers"
+  parameters   = "IN a INT, IN b INT, OUT total INT"
+  sql_security = "%s"
+  body         = <<-EOT
+    BEGIN
+      SET total = %s;
+    END
+  EOT
+}
+`, dbName, sqlSecurity, expression)
+}
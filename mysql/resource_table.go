@@ -0,0 +1,774 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	integerDisplayWidthRegex = regexp.MustCompile(`^(tinyint|smallint|mediumint|int|bigint)\(\d+\)`)
+	columnTypeCharsetRegex   = regexp.MustCompile(`\s+(charset|character set|collate)\s+\w+`)
+	// columnTypeRegex only allows words and a list of numbers or quoted
+	// values, e.g. varchar(255) or enum('a','b'), as the type is put into
+	// the SQL as is.
+	columnTypeRegex = regexp.MustCompile(`(?i)^[a-z][a-z0-9_]*( [a-z0-9_]+)* ?(\(\s*('([^'\\]|'')*'|\d+)\s*(,\s*('([^'\\]|'')*'|\d+)\s*)*\))?( [a-z0-9_]+)*$`)
+)
+
+// columnTypeAliases are the other names of types, in the order they're
+// matched.
+var columnTypeAliases = [][2]string{
+	{"double precision", "double"},
+	{"real", "double"},
+	{"float8", "double"},
+	{"float4", "float"},
+	{"integer", "int"},
+	{"int1", "tinyint"},
+	{"int2", "smallint"},
+	{"int3", "mediumint"},
+	{"int4", "int"},
+	{"int8", "bigint"},
+	{"middleint", "mediumint"},
+	{"dec", "decimal"},
+	{"numeric", "decimal"},
+	{"fixed", "decimal"},
+	{"nchar", "char"},
+	{"nvarchar", "varchar"},
+	{"character varying", "varchar"},
+	{"char varying", "varchar"},
+	{"character", "char"},
+	{"bool", "tinyint(1)"},
+	{"boolean", "tinyint(1)"},
+}
+
+func resourceTable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateTable,
+		UpdateContext: UpdateTable,
+		ReadContext:   ReadTable,
+		DeleteContext: DeleteTable,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportTable,
+		},
+		CustomizeDiff: tableCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+
+			"column": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateColumnType,
+							DiffSuppressFunc: columnTypeSuppressFunc,
+						},
+						"nullable": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"default_expression": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: defaultExpressionSuppressFunc,
+						},
+						"auto_increment": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"primary_key": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"index": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"columns": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"unique": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"engine": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				// The engine is put into the SQL as is, so it mustn't be more than a name.
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_]+$`), "must be the name of a storage engine"),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+
+			"default_character_set": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: charsetAliasSuppressFunc,
+			},
+
+			"default_collation": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: collationAliasSuppressFunc,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func CreateTable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	name := d.Get("name").(string)
+
+	var definitions []string
+	var args []interface{}
+	for _, column := range d.Get("column").([]interface{}) {
+		definition, columnArgs := columnDefinitionSQL(column.(map[string]interface{}))
+		definitions = append(definitions, definition)
+		args = append(args, columnArgs...)
+	}
+	if primaryKey := d.Get("primary_key").([]interface{}); len(primaryKey) > 0 {
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", identifierListSQL(primaryKey)))
+	}
+	for _, index := range d.Get("index").(*schema.Set).List() {
+		definitions = append(definitions, indexDefinitionSQL(index.(map[string]interface{})))
+	}
+
+	stmtSQL := fmt.Sprintf("CREATE TABLE %s.%s (%s)", quoteIdentifier(database), quoteIdentifier(name), strings.Join(definitions, ", "))
+
+	var options []string
+	if engine := d.Get("engine").(string); engine != "" {
+		options = append(options, "ENGINE = "+engine)
+	}
+	charsetOptions := tableCharsetSQL(d)
+	if charsetOptions != "" {
+		options = append(options, charsetOptions)
+	}
+	if comment := d.Get("comment").(string); comment != "" {
+		options = append(options, "COMMENT = ?")
+		args = append(args, comment)
+	}
+	if len(options) > 0 {
+		stmtSQL += " " + strings.Join(options, " ")
+	}
+
+	logStatement(ctx, stmtSQL)
+	if _, err := db.ExecContext(ctx, stmtSQL, args...); err != nil {
+		return diag.Errorf("failed creating table: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", database, name))
+
+	return ReadTable(ctx, d, meta)
+}
+
+func UpdateTable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var clauses []string
+	var args []interface{}
+
+	if d.HasChange("column") {
+		oldColumnsIf, newColumnsIf := d.GetChange("column")
+		oldColumns := oldColumnsIf.([]interface{})
+		newColumns := newColumnsIf.([]interface{})
+
+		// Incompatible column changes force a new table in tableCustomizeDiff,
+		// so only attribute changes and appended columns are left here.
+		for i, column := range newColumns {
+			newColumn := column.(map[string]interface{})
+			definition, columnArgs := columnDefinitionSQL(newColumn)
+			if i < len(oldColumns) {
+				if columnDefinitionEqual(oldColumns[i].(map[string]interface{}), newColumn) {
+					continue
+				}
+				clauses = append(clauses, "MODIFY COLUMN "+definition)
+			} else {
+				clauses = append(clauses, "ADD COLUMN "+definition)
+			}
+			args = append(args, columnArgs...)
+		}
+	}
+
+	if d.HasChange("index") {
+		oldIndexesIf, newIndexesIf := d.GetChange("index")
+		oldIndexes := oldIndexesIf.(*schema.Set)
+		newIndexes := newIndexesIf.(*schema.Set)
+
+		for _, index := range oldIndexes.Difference(newIndexes).List() {
+			clauses = append(clauses, "DROP INDEX "+quoteIdentifier(index.(map[string]interface{})["name"].(string)))
+		}
+		for _, index := range newIndexes.Difference(oldIndexes).List() {
+			clauses = append(clauses, "ADD "+indexDefinitionSQL(index.(map[string]interface{})))
+		}
+	}
+
+	if d.HasChange("engine") {
+		clauses = append(clauses, "ENGINE = "+d.Get("engine").(string))
+	}
+	if d.HasChanges("default_character_set", "default_collation") {
+		if charsetOptions := tableCharsetSQL(d); charsetOptions != "" {
+			clauses = append(clauses, charsetOptions)
+		}
+	}
+	if d.HasChange("comment") {
+		clauses = append(clauses, "COMMENT = ?")
+		args = append(args, d.Get("comment").(string))
+	}
+
+	if len(clauses) > 0 {
+		stmtSQL := fmt.Sprintf("ALTER TABLE %s.%s %s",
+			quoteIdentifier(d.Get("database").(string)),
+			quoteIdentifier(d.Get("name").(string)),
+			strings.Join(clauses, ", "))
+		logStatement(ctx, stmtSQL)
+		if _, err := db.ExecContext(ctx, stmtSQL, args...); err != nil {
+			return diag.Errorf("failed updating table: %v", err)
+		}
+	}
+
+	return ReadTable(ctx, d, meta)
+}
+
+func ReadTable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	name := d.Get("name").(string)
+
+	var engine, collation sql.NullString
+	var comment string
+	stmtSQL := "SELECT ENGINE, TABLE_COLLATION, TABLE_COMMENT FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?"
+	logStatement(ctx, stmtSQL)
+	err = db.QueryRowContext(ctx, stmtSQL, database, name).Scan(&engine, &collation, &comment)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Table (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading table: %v", err)
+	}
+
+	var charset string
+	if collation.Valid {
+		err = db.QueryRowContext(ctx, "SELECT CHARACTER_SET_NAME FROM INFORMATION_SCHEMA.COLLATIONS WHERE COLLATION_NAME = ?", collation.String).Scan(&charset)
+		if err != nil && err != sql.ErrNoRows {
+			return diag.Errorf("failed reading table character set: %v", err)
+		}
+	}
+
+	columns, err := readTableColumns(ctx, db, meta, database, name)
+	if err != nil {
+		return diag.Errorf("failed reading table columns: %v", err)
+	}
+	// Types keep their configured spelling if the server reports the same
+	// type, e.g. int for INTEGER(11).
+	stateColumns := d.Get("column").([]interface{})
+	for i, column := range columns {
+		if i >= len(stateColumns) || stateColumns[i] == nil {
+			break
+		}
+		stateColumn := stateColumns[i].(map[string]interface{})
+		if stateColumn["name"] == column["name"] && normalizeColumnType(stateColumn["type"].(string)) == normalizeColumnType(column["type"].(string)) {
+			column["type"] = stateColumn["type"]
+		}
+	}
+
+	primaryKey, indexes, err := readTableIndexes(ctx, db, database, name)
+	if err != nil {
+		return diag.Errorf("failed reading table indexes: %v", err)
+	}
+	foreignKeys, err := readTableForeignKeys(ctx, db, database, name)
+	if err != nil {
+		return diag.Errorf("failed reading table foreign keys: %v", err)
+	}
+	indexes = withoutForeignKeyIndexes(indexes, foreignKeys, d.Get("index").(*schema.Set))
+
+	d.Set("engine", engine.String)
+	d.Set("default_character_set", charset)
+	d.Set("default_collation", collation.String)
+	d.Set("comment", comment)
+	if err := d.Set("column", columns); err != nil {
+		return diag.Errorf("failed setting column field: %v", err)
+	}
+	d.Set("primary_key", primaryKey)
+	if err := d.Set("index", indexes); err != nil {
+		return diag.Errorf("failed setting index field: %v", err)
+	}
+
+	return nil
+}
+
+func DeleteTable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := fmt.Sprintf("DROP TABLE %s.%s", quoteIdentifier(d.Get("database").(string)), quoteIdentifier(d.Get("name").(string)))
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed dropping table: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportTable(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	database, name, err := splitQualifiedName(id)
+	if err != nil || database == "" || name == "" {
+		return nil, fmt.Errorf("wrong ID format %s (expected database.table, quoting names with dots in backticks)", id)
+	}
+
+	d.Set("database", database)
+	d.Set("name", name)
+	d.SetId(fmt.Sprintf("%s.%s", database, name))
+
+	diags := ReadTable(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading table: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("table %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// tableCustomizeDiff validates the primary key and replaces the table when an
+// existing column would be renamed, retyped, reordered or removed, as those
+// can lose data.
+func tableCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	columnsByName := map[string]map[string]interface{}{}
+	for _, column := range d.Get("column").([]interface{}) {
+		c := column.(map[string]interface{})
+		columnsByName[c["name"].(string)] = c
+		if c["default"].(string) != "" && c["default_expression"].(string) != "" {
+			return fmt.Errorf("column %s: only one of default and default_expression can be set", c["name"])
+		}
+	}
+	for _, key := range d.Get("primary_key").([]interface{}) {
+		column, ok := columnsByName[key.(string)]
+		if !ok {
+			return fmt.Errorf("primary key column %s is not defined", key)
+		}
+		if column["nullable"].(bool) {
+			return fmt.Errorf("primary key column %s must have nullable = false", key)
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("column") {
+		return nil
+	}
+
+	oldColumnsIf, newColumnsIf := d.GetChange("column")
+	oldColumns := oldColumnsIf.([]interface{})
+	newColumns := newColumnsIf.([]interface{})
+	if len(newColumns) < len(oldColumns) {
+		return d.ForceNew("column")
+	}
+	for i := range oldColumns {
+		if !columnStructureEqual(oldColumns[i].(map[string]interface{}), newColumns[i].(map[string]interface{})) {
+			return d.ForceNew("column")
+		}
+	}
+
+	return nil
+}
+
+// columnStructureEqual compares the parts of a column that can't be changed in
+// place. Defaults and comments can, see columnDefinitionEqual.
+func columnStructureEqual(a, b map[string]interface{}) bool {
+	return a["name"] == b["name"] &&
+		normalizeColumnType(a["type"].(string)) == normalizeColumnType(b["type"].(string)) &&
+		a["nullable"] == b["nullable"] &&
+		a["auto_increment"] == b["auto_increment"]
+}
+
+func columnDefinitionEqual(a, b map[string]interface{}) bool {
+	return columnStructureEqual(a, b) &&
+		a["default"] == b["default"] &&
+		normalizeDefaultExpression(a["default_expression"].(string)) == normalizeDefaultExpression(b["default_expression"].(string)) &&
+		a["comment"] == b["comment"]
+}
+
+func columnDefinitionSQL(column map[string]interface{}) (string, []interface{}) {
+	var args []interface{}
+	definition := fmt.Sprintf("%s %s", quoteIdentifier(column["name"].(string)), column["type"].(string))
+
+	if column["nullable"].(bool) {
+		definition += " NULL"
+	} else {
+		definition += " NOT NULL"
+	}
+	if expression := column["default_expression"].(string); expression != "" {
+		definition += " DEFAULT " + expression
+	} else if value := column["default"].(string); value != "" {
+		definition += " DEFAULT ?"
+		args = append(args, value)
+	}
+	if column["auto_increment"].(bool) {
+		definition += " AUTO_INCREMENT"
+	}
+	if comment := column["comment"].(string); comment != "" {
+		definition += " COMMENT ?"
+		args = append(args, comment)
+	}
+
+	return definition, args
+}
+
+func indexDefinitionSQL(index map[string]interface{}) string {
+	kind := "INDEX"
+	if index["unique"].(bool) {
+		kind = "UNIQUE INDEX"
+	}
+	return fmt.Sprintf("%s %s (%s)", kind, quoteIdentifier(index["name"].(string)), identifierListSQL(index["columns"].([]interface{})))
+}
+
+func identifierListSQL(identifiers []interface{}) string {
+	quoted := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		quoted = append(quoted, quoteIdentifier(identifier.(string)))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func tableCharsetSQL(d *schema.ResourceData) string {
+	var options []string
+	if charset := d.Get("default_character_set").(string); charset != "" {
+		options = append(options, defaultCharacterSetKeyword+quoteIdentifier(charset))
+	}
+	if collation := d.Get("default_collation").(string); collation != "" {
+		options = append(options, defaultCollateKeyword+quoteIdentifier(collation))
+	}
+	if len(options) == 0 {
+		return ""
+	}
+	return "DEFAULT " + strings.Join(options, " ")
+}
+
+func readTableColumns(ctx context.Context, db *sql.DB, meta interface{}, database string, table string) ([]map[string]interface{}, error) {
+	isMariaDB := getFlavorFromMeta(ctx, meta) == flavorMariaDB
+
+	stmtSQL := `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA, COLUMN_COMMENT
+		FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL, database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []map[string]interface{}{}
+	for rows.Next() {
+		var name, columnType, nullable, extra, comment string
+		var columnDefault sql.NullString
+		if err := rows.Scan(&name, &columnType, &nullable, &columnDefault, &extra, &comment); err != nil {
+			return nil, err
+		}
+
+		defaultValue, defaultExpression := splitColumnDefault(columnDefault, columnType, extra, isMariaDB)
+		columns = append(columns, map[string]interface{}{
+			"name":               name,
+			"type":               columnType,
+			"nullable":           nullable == "YES",
+			"default":            defaultValue,
+			"default_expression": defaultExpression,
+			"auto_increment":     strings.Contains(strings.ToLower(extra), "auto_increment"),
+			"comment":            comment,
+		})
+	}
+	return columns, rows.Err()
+}
+
+// splitColumnDefault tells literal defaults from expressions. MySQL marks
+// expressions with DEFAULT_GENERATED, older versions only report
+// CURRENT_TIMESTAMP for temporal columns. MariaDB quotes literals instead.
+func splitColumnDefault(columnDefault sql.NullString, columnType string, extra string, isMariaDB bool) (string, string) {
+	if !columnDefault.Valid {
+		return "", ""
+	}
+	value := columnDefault.String
+
+	if isMariaDB {
+		if value == "NULL" {
+			return "", ""
+		}
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), ""
+		}
+		if _, err := fmt.Sscanf(value, "%g", new(float64)); err == nil {
+			return value, ""
+		}
+		return "", value
+	}
+
+	if strings.Contains(extra, "DEFAULT_GENERATED") {
+		return "", value
+	}
+	lowerType := strings.ToLower(columnType)
+	if (strings.HasPrefix(lowerType, "timestamp") || strings.HasPrefix(lowerType, "datetime")) &&
+		strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP") {
+		return "", value
+	}
+	return value, ""
+}
+
+func readTableIndexes(ctx context.Context, db *sql.DB, database string, table string) ([]string, []map[string]interface{}, error) {
+	stmtSQL := `SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL, database, table)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	primaryKey := []string{}
+	indexes := []map[string]interface{}{}
+	indexByName := map[string]map[string]interface{}{}
+	for rows.Next() {
+		var indexName string
+		var nonUnique int
+		var columnName sql.NullString
+		if err := rows.Scan(&indexName, &nonUnique, &columnName); err != nil {
+			return nil, nil, err
+		}
+		// Functional key parts have no column name and can't be managed here.
+		if !columnName.Valid {
+			continue
+		}
+
+		if indexName == "PRIMARY" {
+			primaryKey = append(primaryKey, columnName.String)
+			continue
+		}
+
+		index, ok := indexByName[indexName]
+		if !ok {
+			index = map[string]interface{}{
+				"name":    indexName,
+				"columns": []interface{}{},
+				"unique":  nonUnique == 0,
+			}
+			indexByName[indexName] = index
+		}
+		index["columns"] = append(index["columns"].([]interface{}), columnName.String)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, index := range indexByName {
+		indexes = append(indexes, index)
+	}
+	return primaryKey, indexes, nil
+}
+
+// withoutForeignKeyIndexes leaves out the indexes InnoDB creates for foreign
+// keys, e.g. the ones of constraints added outside of Terraform, unless the
+// index is managed, i.e. in the state. Such an index has the columns of the
+// foreign key and is named after the constraint or its first column, so only
+// the columns can tell.
+func withoutForeignKeyIndexes(indexes []map[string]interface{}, foreignKeys []map[string]interface{}, managed *schema.Set) []map[string]interface{} {
+	managedNames := map[string]bool{}
+	for _, index := range managed.List() {
+		managedNames[index.(map[string]interface{})["name"].(string)] = true
+	}
+
+	kept := []map[string]interface{}{}
+	for _, index := range indexes {
+		if !managedNames[index["name"].(string)] && !index["unique"].(bool) && foreignKeyColumns(index["columns"].([]interface{}), foreignKeys) {
+			continue
+		}
+		kept = append(kept, index)
+	}
+	return kept
+}
+
+func foreignKeyColumns(columns []interface{}, foreignKeys []map[string]interface{}) bool {
+	for _, foreignKey := range foreignKeys {
+		if reflect.DeepEqual(columns, foreignKey["columns"]) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeColumnType makes types comparable to the COLUMN_TYPE of
+// INFORMATION_SCHEMA.COLUMNS, e.g. "INTEGER(11) UNSIGNED" and "int unsigned"
+// are the same. COLUMN_TYPE has no character set or collation, these are
+// dropped.
+func normalizeColumnType(columnType string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(columnType), " "))
+	normalized = columnTypeCharsetRegex.ReplaceAllString(normalized, "")
+	normalized = strings.ReplaceAll(normalized, ", ", ",")
+	normalized = strings.ReplaceAll(normalized, " (", "(")
+	normalized = strings.TrimPrefix(normalized, "national ")
+
+	for _, alias := range columnTypeAliases {
+		if rest, ok := strings.CutPrefix(normalized, alias[0]); ok && (rest == "" || rest[0] == '(' || rest[0] == ' ') {
+			normalized = alias[1] + rest
+			break
+		}
+	}
+
+	name, rest, _ := strings.Cut(normalized, " ")
+	switch name {
+	case "decimal":
+		name = "decimal(10,0)"
+	case "char", "binary", "bit":
+		name += "(1)"
+	case "datetime(0)", "timestamp(0)", "time(0)", "year(4)":
+		name = name[:strings.Index(name, "(")]
+	}
+	if precision, ok := strings.CutPrefix(name, "decimal("); ok && !strings.Contains(precision, ",") {
+		name = "decimal(" + strings.TrimSuffix(precision, ")") + ",0)"
+	}
+
+	// Integers are signed unless unsigned, which zerofill implies; binary
+	// stands for the binary collation of the character set.
+	var attributes []string
+	for _, attribute := range strings.Fields(rest) {
+		switch attribute {
+		case "signed", "binary":
+		case "zerofill":
+			if len(attributes) == 0 || attributes[len(attributes)-1] != "unsigned" {
+				attributes = append(attributes, "unsigned")
+			}
+			attributes = append(attributes, attribute)
+		default:
+			attributes = append(attributes, attribute)
+		}
+	}
+	normalized = strings.Join(append([]string{name}, attributes...), " ")
+
+	// Integer display widths are deprecated and not reported by MySQL 8.0.19+.
+	return integerDisplayWidthRegex.ReplaceAllString(normalized, "$1")
+}
+
+func validateColumnType(val any, key string) (warns []string, errs []error) {
+	columnType := strings.Join(strings.Fields(val.(string)), " ")
+	if !columnTypeRegex.MatchString(columnType) {
+		errs = append(errs, fmt.Errorf("%q must be a column type like varchar(255) or enum('a','b'), got: %s", key, val))
+	}
+	return
+}
+
+func columnTypeSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeColumnType(old) == normalizeColumnType(new)
+}
+
+func normalizeDefaultExpression(expression string) string {
+	normalized := strings.ToLower(strings.TrimSpace(expression))
+	for enclosedInParentheses(normalized) {
+		normalized = strings.TrimSpace(normalized[1 : len(normalized)-1])
+	}
+	return normalized
+}
+
+// enclosedInParentheses returns whether the outer parentheses of s belong
+// together, i.e. "(a + b)" but not "(a) + (b)".
+func enclosedInParentheses(s string) bool {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return false
+	}
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(s)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func defaultExpressionSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeDefaultExpression(old) == normalizeDefaultExpression(new)
+}
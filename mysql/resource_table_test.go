@@ -0,0 +1,278 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTable_basic(t *testing.T) {
+	dbName := "tf_test_table"
+	tableName := "settings"
+	resourceName := "mysql_table.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTableCheckDestroy(dbName, tableName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTableConfig_basic(dbName, tableName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTableExists(dbName, tableName),
+					resource.TestCheckResourceAttr(resourceName, "column.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "primary_key.0", "id"),
+					resource.TestCheckResourceAttr(resourceName, "engine", "InnoDB"),
+				),
+			},
+			{
+				Config: testAccTableConfig_addColumn(dbName, tableName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTableExists(dbName, tableName),
+					resource.TestCheckResourceAttr(resourceName, "column.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "column.2.name", "updated_at"),
+					resource.TestCheckResourceAttr(resourceName, "index.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s.%s", dbName, tableName),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("`%s`.`%s`", dbName, tableName),
+				ImportStateVerify: true,
+			},
+			{
+				Config:      strings.Replace(testAccTableConfig_basic(dbName, tableName), `"InnoDB"`, `"InnoDB COMMENT 'x'"`, 1),
+				ExpectError: regexp.MustCompile("must be the name of a storage engine"),
+			},
+		},
+	})
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	// The types are as configured and as COLUMN_TYPE reports them.
+	for _, tc := range []struct {
+		configured string
+		reported   string
+	}{
+		{"INT", "int(11)"},
+		{"INTEGER(11) UNSIGNED", "int unsigned"},
+		{"int signed", "int"},
+		{"int zerofill", "int(10) unsigned zerofill"},
+		{"BOOLEAN", "tinyint(1)"},
+		{"DECIMAL", "decimal(10,0)"},
+		{"numeric(8)", "decimal(8,0)"},
+		{"dec(8, 2)", "decimal(8,2)"},
+		{"double precision", "double"},
+		{"REAL", "double"},
+		{"char", "char(1)"},
+		{"national varchar(20)", "varchar(20)"},
+		{"VARCHAR (255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin", "varchar(255)"},
+		{"varchar(255) binary", "varchar(255)"},
+		{"datetime(0)", "datetime"},
+		{"ENUM('a', 'b')", "enum('a','b')"},
+	} {
+		if !columnTypeSuppressFunc("column.0.type", tc.reported, tc.configured, nil) {
+			t.Errorf("expected %q to be the same as %q, got %q and %q", tc.configured, tc.reported, normalizeColumnType(tc.configured), normalizeColumnType(tc.reported))
+		}
+	}
+
+	for _, tc := range [][2]string{
+		{"int", "bigint"},
+		{"int", "int unsigned"},
+		{"varchar(255)", "varchar(64)"},
+		{"decimal(10,2)", "decimal"},
+		{"datetime(3)", "datetime"},
+		{"binary(16)", "varbinary(16)"},
+	} {
+		if columnTypeSuppressFunc("column.0.type", tc[0], tc[1], nil) {
+			t.Errorf("expected %q to differ from %q", tc[0], tc[1])
+		}
+	}
+}
+
+func TestValidateColumnType(t *testing.T) {
+	for _, columnType := range []string{
+		"int",
+		"INT UNSIGNED",
+		"varchar(255)",
+		"varchar (255) character set utf8mb4",
+		"decimal(10, 2)",
+		"enum('a', 'b''c', 'd e')",
+		"double precision",
+		"point srid 4326",
+	} {
+		if _, errs := validateColumnType(columnType, "type"); len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", columnType, errs)
+		}
+	}
+
+	for _, columnType := range []string{
+		"",
+		"int; DROP TABLE t",
+		"int -- comment",
+		"int /* comment */",
+		"int, extra int",
+		"varchar(255)) ENGINE=MyISAM",
+		"enum('a\\', 'b')",
+		"enum('a') DEFAULT 'a'",
+		"int(1) (2)",
+		"`int`",
+	} {
+		if _, errs := validateColumnType(columnType, "type"); len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", columnType)
+		}
+	}
+}
+
+func TestWithoutForeignKeyIndexes(t *testing.T) {
+	indexes := []map[string]interface{}{
+		{"name": "fk_orders_customer", "columns": []interface{}{"customer_id"}, "unique": false},
+		{"name": "tenant_id", "columns": []interface{}{"tenant_id", "region"}, "unique": false},
+		{"name": "idx_created", "columns": []interface{}{"created_at"}, "unique": false},
+		{"name": "uq_customer", "columns": []interface{}{"customer_id"}, "unique": true},
+		{"name": "idx_tenant", "columns": []interface{}{"tenant_id"}, "unique": false},
+	}
+	foreignKeys := []map[string]interface{}{
+		{"name": "fk_orders_customer", "columns": []interface{}{"customer_id"}},
+		{"name": "orders_ibfk_1", "columns": []interface{}{"tenant_id", "region"}},
+		{"name": "fk_orders_owner", "columns": []interface{}{"tenant_id"}},
+	}
+	indexSchema := resourceTable().Schema["index"].Elem.(*schema.Resource)
+	managed := schema.NewSet(schema.HashResource(indexSchema), []interface{}{
+		map[string]interface{}{"name": "idx_tenant", "columns": []interface{}{"tenant_id"}, "unique": false},
+	})
+
+	names := []string{}
+	for _, index := range withoutForeignKeyIndexes(indexes, foreignKeys, managed) {
+		names = append(names, index["name"].(string))
+	}
+	expected := []string{"idx_created", "uq_customer", "idx_tenant"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected the indexes %v, got %v", expected, names)
+	}
+}
+
+func testAccTableExists(dbName string, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", dbName, tableName).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading tables: %s", err)
+		}
+		if count != 1 {
+			return fmt.Errorf("table %s.%s not found", dbName, tableName)
+		}
+
+		return nil
+	}
+}
+
+func testAccTableCheckDestroy(dbName string, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", dbName, tableName).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading tables: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("table %s.%s still exists after destroy", dbName, tableName)
+		}
+
+		return nil
+	}
+}
+
+func testAccTableConfig_basic(dbName string, tableName string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_table" "test" {
+  database = mysql_database.test.name
+  name     = "%s"
+  engine   = "InnoDB"
+
+  column {
+    name           = "id"
+    type           = "INT UNSIGNED"
+    nullable       = false
+    auto_increment = true
+  }
+
+  column {
+    name    = "value"
+    type    = "VARCHAR(255)"
+    default = "none"
+    comment = "setting value"
+  }
+
+  primary_key = ["id"]
+}
+`, dbName, tableName)
+}
+
+func testAccTableConfig_addColumn(dbName string, tableName string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_table" "test" {
+  database = mysql_database.test.name
+  name     = "%s"
+  engine   = "InnoDB"
+
+  column {
+    name           = "id"
+    type           = "INT UNSIGNED"
+    nullable       = false
+    auto_increment = true
+  }
+
+  column {
+    name    = "value"
+    type    = "VARCHAR(255)"
+    default = "none"
+    comment = "setting value"
+  }
+
+  column {
+    name               = "updated_at"
+    type               = "TIMESTAMP"
+    default_expression = "CURRENT_TIMESTAMP"
+  }
+
+  primary_key = ["id"]
+
+  index {
+    name    = "idx_updated_at"
+    columns = ["updated_at"]
+  }
+}
+`, dbName, tableName)
+}
@@ -47,7 +47,7 @@ func resourceTiConfigVariable() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"pd", "tikv"}, true),
+				ValidateFunc: validation.StringInSlice([]string{"pd", "tikv", "tiflash"}, true),
 			},
 			"instance": {
 				Type:     schema.TypeString,
@@ -58,7 +58,7 @@ func resourceTiConfigVariable() *schema.Resource {
 }
 
 func CreateOrUpdateConfigVariable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "cluster configuration variables")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -74,12 +74,12 @@ func CreateOrUpdateConfigVariable(ctx context.Context, d *schema.ResourceData, m
 	configQuery := fmt.Sprintf("SET CONFIG %s %s=", varInstanceType, quoteIdentifier(varName))
 
 	if varInstance != "" {
-		configQuery = fmt.Sprintf("SET CONFIG \"%s\" %s=", varInstance, quoteIdentifier(varName))
+		configQuery = fmt.Sprintf("SET CONFIG %s %s=", quoteLiteral(varInstance), quoteIdentifier(varName))
 	}
 
-	configQuery = fmt.Sprintf("%s'%s'", configQuery, varValue)
+	configQuery += quoteLiteral(varValue)
 
-	log.Printf("[DEBUG] SQL: %s\n", configQuery)
+	logStatement(ctx, configQuery)
 
 	_, err = db.ExecContext(ctx, configQuery)
 	if err != nil {
@@ -105,28 +105,28 @@ func CreateOrUpdateConfigVariable(ctx context.Context, d *schema.ResourceData, m
 func ReadConfigVariable(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var resType, resInstance, resName, resValue string
 
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "cluster configuration variables")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	match, _ := regexp.MatchString("^(pd|tikv)#(.*)$", d.Id())
+	match, _ := regexp.MatchString("^(pd|tikv|tiflash)#(.*)$", d.Id())
 	if !match {
-		return diag.Errorf("error parsing TiDB component (tikv or pd) type from ID.  \n Acceptable format is <pd|tikv>#<config_variable>#<optional_instance>")
+		return diag.Errorf("error parsing TiDB component (tikv, pd or tiflash) type from ID.  \n Acceptable format is <pd|tikv|tiflash>#<config_variable>#<optional_instance>")
 	}
 
 	indexParts := strings.Split(d.Id(), "#")
 	splitedResType := indexParts[0]
 	splitedResName := indexParts[1]
 
-	configQuery := fmt.Sprintf("SHOW CONFIG WHERE type = '%s' AND name = '%s'", splitedResType, splitedResName)
+	configQuery := fmt.Sprintf("SHOW CONFIG WHERE type = %s AND name = %s", quoteLiteral(splitedResType), quoteLiteral(splitedResName))
 	if len(indexParts) > 2 {
-		configQuery = configQuery + fmt.Sprintf(" AND instance = '%s'", (indexParts[2]))
+		configQuery = configQuery + " AND instance = " + quoteLiteral(indexParts[2])
 	}
 
-	log.Printf("[DEBUG] SQL: %s\n", configQuery)
+	logStatement(ctx, configQuery)
 
-	err = db.QueryRow(configQuery).Scan(&resType, &resInstance, &resName, &resValue)
+	err = db.QueryRowContext(ctx, configQuery).Scan(&resType, &resInstance, &resName, &resValue)
 	if err != nil && err != sql.ErrNoRows {
 		d.SetId("")
 		return diag.Errorf("error during show config variables: %s", err)
@@ -158,6 +158,10 @@ func DeleteConfigVariable(ctx context.Context, d *schema.ResourceData, meta inte
 		jsonCfg, err = json.MarshalIndent(&defCfg.Pd, "", "    ")
 	case "tikv":
 		jsonCfg, err = json.MarshalIndent(&defCfg.TiKv, "", "    ")
+	case "tiflash":
+		log.Printf("[WARN] TiFlash variable (%s) don't have default values; removing from state", d.Id())
+		d.SetId("")
+		return nil
 	default:
 		return diag.Errorf("error during destory config variables: %s is not allowed type", varInstanceType)
 	}
@@ -171,7 +175,7 @@ func DeleteConfigVariable(ctx context.Context, d *schema.ResourceData, meta inte
 	log.Printf("[DEBUG]: DESTROY %s %s->%s\n", varInstanceType, varName, defaultValue)
 	match, _ := regexp.MatchString("^(IGNOREONDESTROY)#(.*)$", defaultValue.String())
 	if match {
-		log.Printf("[WARN] Variable_name (%s) dont have default values; removing from state", d.Id())
+		log.Printf("[WARN] Variable_name (%s) don't have default values; removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
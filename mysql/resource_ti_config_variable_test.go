@@ -156,6 +156,12 @@ func getGetInstance(varType string, t *testing.T) string {
 	if match {
 		t.Skip("Skip on MySQL")
 	}
+	// This runs before resource.Test, so the provider has to be configured and
+	// the acceptance test checks done here.
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheckSkipNotTiDB(t)
 
 	ctx := context.Background()
 	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
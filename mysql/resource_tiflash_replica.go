@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTiFlashReplica() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateOrUpdateTiFlashReplica,
+		UpdateContext: CreateOrUpdateTiFlashReplica,
+		ReadContext:   ReadTiFlashReplica,
+		DeleteContext: DeleteTiFlashReplica,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportTiFlashReplica,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"table": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"replica_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateOrUpdateTiFlashReplica(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "TiFlash replicas")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database := d.Get("database").(string)
+	table := d.Get("table").(string)
+	if err := setTiFlashReplica(ctx, db, database, table, d.Get("replica_count").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", database, table))
+
+	return ReadTiFlashReplica(ctx, d, meta)
+}
+
+func ReadTiFlashReplica(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "TiFlash replicas")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT REPLICA_COUNT, AVAILABLE FROM INFORMATION_SCHEMA.TIFLASH_REPLICA WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?"
+	logStatement(ctx, stmtSQL)
+
+	var replicaCount int
+	var available bool
+	err = db.QueryRowContext(ctx, stmtSQL, d.Get("database").(string), d.Get("table").(string)).Scan(&replicaCount, &available)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] TiFlash replica (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading TiFlash replica: %v", err)
+	}
+
+	d.Set("replica_count", replicaCount)
+	d.Set("available", available)
+
+	return nil
+}
+
+func DeleteTiFlashReplica(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getTiDBDatabaseFromMeta(ctx, meta, "TiFlash replicas")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setTiFlashReplica(ctx, db, d.Get("database").(string), d.Get("table").(string), 0); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportTiFlashReplica(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	database, table, err := splitQualifiedName(id)
+	if err != nil || database == "" || table == "" {
+		return nil, fmt.Errorf("wrong ID format %s (expected database.table, quoting names with dots in backticks)", id)
+	}
+
+	d.Set("database", database)
+	d.Set("table", table)
+	d.SetId(fmt.Sprintf("%s.%s", database, table))
+
+	diags := ReadTiFlashReplica(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading TiFlash replica: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("table %s has no TiFlash replica", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setTiFlashReplica(ctx context.Context, db *sql.DB, database string, table string, count int) error {
+	stmtSQL := fmt.Sprintf("ALTER TABLE %s.%s SET TIFLASH REPLICA %d", quoteIdentifier(database), quoteIdentifier(table), count)
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return fmt.Errorf("failed setting TiFlash replica: %v", err)
+	}
+	return nil
+}
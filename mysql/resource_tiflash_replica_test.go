@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTiFlashReplica_basic(t *testing.T) {
+	dbName := "tf_test_tiflash"
+	tableName := "replicated"
+	resourceName := "mysql_tiflash_replica.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipNoTiFlash(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTiFlashReplicaCheckDestroy(dbName, tableName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTiFlashReplicaConfig(dbName, tableName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "replica_count", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Replication progresses in the background.
+				ImportStateVerifyIgnore: []string{"available"},
+			},
+			{
+				// Only the replica goes away, the table is kept.
+				Config: testAccTiFlashReplicaConfig_table(dbName, tableName),
+				Check:  testAccTiFlashReplicaCheckDestroy(dbName, tableName),
+			},
+		},
+	})
+}
+
+func testAccPreCheckSkipNoTiFlash(t *testing.T) {
+	testAccPreCheckSkipNotTiDB(t)
+
+	ctx := context.Background()
+	db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+	if err != nil {
+		t.Fatalf("Cannot connect to DB (SkipNoTiFlash): %v", err)
+		return
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.CLUSTER_INFO WHERE TYPE = 'tiflash'").Scan(&count)
+	if err != nil || count == 0 {
+		t.Skip("Skip without TiFlash")
+	}
+}
+
+func testAccTiFlashReplicaCheckDestroy(dbName string, tableName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.TIFLASH_REPLICA WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", dbName, tableName).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading TiFlash replicas: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("TiFlash replica of %s.%s still exists after destroy", dbName, tableName)
+		}
+
+		return nil
+	}
+}
+
+func testAccTiFlashReplicaConfig_table(dbName string, tableName string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_table" "test" {
+  database = mysql_database.test.name
+  name     = "%s"
+
+  column {
+    name     = "id"
+    type     = "INT"
+    nullable = false
+  }
+
+  primary_key = ["id"]
+}
+`, dbName, tableName)
+}
+
+func testAccTiFlashReplicaConfig(dbName string, tableName string, count int) string {
+	return testAccTiFlashReplicaConfig_table(dbName, tableName) + fmt.Sprintf(`
+resource "mysql_tiflash_replica" "test" {
+  database      = mysql_table.test.database
+  table         = mysql_table.test.name
+  replica_count = %d
+}
+`, count)
+}
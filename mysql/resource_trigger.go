@@ -0,0 +1,271 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceTrigger() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateTrigger,
+		UpdateContext: UpdateTrigger,
+		ReadContext:   ReadTrigger,
+		DeleteContext: DeleteTrigger,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportTrigger,
+		},
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"table": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateIdentifier,
+			},
+			"timing": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"BEFORE", "AFTER"}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"event": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"INSERT", "UPDATE", "DELETE"}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: trimSpaceSuppressFunc,
+			},
+			"definer": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: definerSuppressFunc,
+			},
+		},
+	}
+}
+
+// triggerDefinition holds what's needed to (re-)create a trigger.
+type triggerDefinition struct {
+	Database string
+	Name     string
+	Table    string
+	Timing   string
+	Event    string
+	Body     string
+	Definer  string
+}
+
+func (t triggerDefinition) SQLCreateStatement() string {
+	var definerClause string
+	if t.Definer != "" {
+		definerClause = " DEFINER = " + parseDefiner(t.Definer).SQLString()
+	}
+	return fmt.Sprintf("CREATE%s TRIGGER %s.%s %s %s ON %s.%s FOR EACH ROW\n%s",
+		definerClause,
+		quoteIdentifier(t.Database),
+		quoteIdentifier(t.Name),
+		strings.ToUpper(t.Timing),
+		strings.ToUpper(t.Event),
+		quoteIdentifier(t.Database),
+		quoteIdentifier(t.Table),
+		t.Body)
+}
+
+func (t triggerDefinition) SQLDropStatement() string {
+	return fmt.Sprintf("DROP TRIGGER IF EXISTS %s.%s", quoteIdentifier(t.Database), quoteIdentifier(t.Name))
+}
+
+func triggerFromData(d *schema.ResourceData) triggerDefinition {
+	return triggerDefinition{
+		Database: d.Get("database").(string),
+		Name:     d.Get("name").(string),
+		Table:    d.Get("table").(string),
+		Timing:   d.Get("timing").(string),
+		Event:    d.Get("event").(string),
+		Body:     d.Get("body").(string),
+		Definer:  d.Get("definer").(string),
+	}
+}
+
+func CreateTrigger(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := triggerFromData(d).SQLCreateStatement()
+	logStatement(ctx, stmtSQL)
+
+	// No arguments are passed, so question marks in the body are left alone.
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed creating trigger: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", d.Get("database").(string), d.Get("name").(string)))
+
+	return ReadTrigger(ctx, d, meta)
+}
+
+// UpdateTrigger drops and re-creates the trigger, as triggers can't be
+// altered. The tables are locked meanwhile, so no rows are written without the
+// trigger, and the old trigger is restored if the new one can't be created.
+func UpdateTrigger(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	newTrigger := triggerFromData(d)
+	oldTrigger := newTrigger
+	for key, field := range map[string]*string{
+		"table":   &oldTrigger.Table,
+		"timing":  &oldTrigger.Timing,
+		"event":   &oldTrigger.Event,
+		"body":    &oldTrigger.Body,
+		"definer": &oldTrigger.Definer,
+	} {
+		oldValue, _ := d.GetChange(key)
+		*field = oldValue.(string)
+	}
+
+	if err := replaceTrigger(ctx, db, oldTrigger, newTrigger); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadTrigger(ctx, d, meta)
+}
+
+func replaceTrigger(ctx context.Context, db *sql.DB, oldTrigger triggerDefinition, newTrigger triggerDefinition) error {
+	// LOCK TABLES only applies to the session, so all statements have to run
+	// on the same connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed getting connection: %v", err)
+	}
+	defer conn.Close()
+
+	lockSQL := fmt.Sprintf("LOCK TABLES %s.%s WRITE", quoteIdentifier(oldTrigger.Database), quoteIdentifier(oldTrigger.Table))
+	if newTrigger.Table != oldTrigger.Table {
+		lockSQL += fmt.Sprintf(", %s.%s WRITE", quoteIdentifier(newTrigger.Database), quoteIdentifier(newTrigger.Table))
+	}
+	logStatement(ctx, lockSQL)
+	if _, err := conn.ExecContext(ctx, lockSQL); err != nil {
+		return fmt.Errorf("failed locking tables: %v", err)
+	}
+	defer func() {
+		logStatement(ctx, "UNLOCK TABLES")
+		if _, err := conn.ExecContext(ctx, "UNLOCK TABLES"); err != nil {
+			log.Printf("[WARN] Failed unlocking tables: %v", err)
+		}
+	}()
+
+	stmtSQL := oldTrigger.SQLDropStatement()
+	logStatement(ctx, stmtSQL)
+	if _, err := conn.ExecContext(ctx, stmtSQL); err != nil {
+		return fmt.Errorf("failed dropping trigger: %v", err)
+	}
+
+	stmtSQL = newTrigger.SQLCreateStatement()
+	logStatement(ctx, stmtSQL)
+	_, createErr := conn.ExecContext(ctx, stmtSQL)
+	if createErr == nil {
+		return nil
+	}
+
+	stmtSQL = oldTrigger.SQLCreateStatement()
+	logStatement(ctx, stmtSQL)
+	if _, err := conn.ExecContext(ctx, stmtSQL); err != nil {
+		return fmt.Errorf("failed creating trigger: %v; restoring the previous trigger failed as well: %v", createErr, err)
+	}
+	return fmt.Errorf("failed creating trigger, the previous trigger was restored: %v", createErr)
+}
+
+func ReadTrigger(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := `SELECT EVENT_OBJECT_TABLE, ACTION_TIMING, EVENT_MANIPULATION, ACTION_STATEMENT, DEFINER
+		FROM INFORMATION_SCHEMA.TRIGGERS WHERE TRIGGER_SCHEMA = ? AND TRIGGER_NAME = ?`
+	logStatement(ctx, stmtSQL)
+
+	var table, timing, event, body, definer string
+	err = db.QueryRowContext(ctx, stmtSQL, d.Get("database").(string), d.Get("name").(string)).Scan(&table, &timing, &event, &body, &definer)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] Trigger (%s) not found; removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading trigger: %v", err)
+	}
+
+	d.Set("table", table)
+	d.Set("timing", timing)
+	d.Set("event", event)
+	d.Set("body", body)
+	d.Set("definer", definer)
+
+	return nil
+}
+
+func DeleteTrigger(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := triggerFromData(d).SQLDropStatement()
+	logStatement(ctx, stmtSQL)
+
+	if _, err := db.ExecContext(ctx, stmtSQL); err != nil {
+		return diag.Errorf("failed dropping trigger: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportTrigger(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := importRoutineId(d); err != nil {
+		return nil, err
+	}
+
+	id := d.Id()
+	diags := ReadTrigger(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed reading trigger: %v", diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("trigger %s not found", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTrigger_basic(t *testing.T) {
+	dbName := "tf_test_trigger"
+	resourceName := "mysql_trigger.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckSkipTiDB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccTriggerCheckDestroy(dbName, "set_defaults"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTriggerConfig(dbName, "BEFORE", "SET NEW.doubled = NEW.value * 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "timing", "BEFORE"),
+					resource.TestCheckResourceAttr(resourceName, "event", "INSERT"),
+					resource.TestCheckResourceAttr(resourceName, "table", "events"),
+					resource.TestCheckResourceAttrSet(resourceName, "definer"),
+				),
+			},
+			{
+				Config: testAccTriggerConfig(dbName, "BEFORE", "SET NEW.doubled = NEW.value * 3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "body", "SET NEW.doubled = NEW.value * 3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s.set_defaults", dbName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccTriggerCheckDestroy(dbName string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM INFORMATION_SCHEMA.TRIGGERS WHERE TRIGGER_SCHEMA = ? AND TRIGGER_NAME = ?", dbName, name).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("error reading triggers: %s", err)
+		}
+		if count != 0 {
+			return fmt.Errorf("trigger %s.%s still exists after destroy", dbName, name)
+		}
+
+		return nil
+	}
+}
+
+func testAccTriggerConfig(dbName string, timing string, body string) string {
+	return fmt.Sprintf(`
+resource "mysql_database" "test" {
+  name = "%s"
+}
+
+resource "mysql_table" "test" {
+  database = mysql_database.test.name
+  name     = "events"
+
+  column {
+    name = "value"
+    type = "INT"
+  }
+
+  column {
+    name = "doubled"
+    type = "INT"
+  }
+}
+
+resource "mysql_trigger" "test" {
+  database = mysql_database.test.name
+  name     = "set_defaults"
+  table    = mysql_table.test.name
+  timing   = "%s"
+  event    = "INSERT"
+  body     = "%s"
+}
+`, dbName, timing, body)
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
@@ -26,16 +27,18 @@ func resourceUser() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"user": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserName,
 			},
 
 			"host": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "localhost",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "localhost",
+				ValidateFunc: validateHostPattern,
 			},
 
 			"plaintext_password": {
@@ -94,18 +97,48 @@ func resourceUser() *schema.Resource {
 				Sensitive:        true,
 				DiffSuppressFunc: NewEmptyStringSuppressFunc,
 				ConflictsWith:    []string{"plaintext_password", "password"},
+				ValidateFunc:     validateEscapedLiteral,
+			},
+
+			"auth_string_plaintext": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: NewEmptyStringSuppressFunc,
+				ConflictsWith:    []string{"plaintext_password", "password", "auth_string_hashed"},
 			},
 
 			"tls_option": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "NONE",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "NONE",
+				DiffSuppressFunc: tlsOptionSuppressFunc,
+				ValidateFunc:     validateTLSOption,
 			},
 
 			"retain_old_password": {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+
+			"detect_password_drift": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"password_fingerprint": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"default_roles": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
 		},
 	}
 }
@@ -124,6 +157,9 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		return diag.FromErr(err)
 	}
 
+	defer lockPrivilegeChanges(ctx, meta)()
+	defer invalidateGrants(ctx, meta, UserOrRole{Name: d.Get("user").(string), Host: d.Get("host").(string)})
+
 	var authStm string
 	var auth string
 	var createObj = "USER"
@@ -143,7 +179,7 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			authStm = " IDENTIFIED WITH AWSAuthenticationPlugin as 'RDS'"
 		} else {
 			// mysql_no_login, auth_pam, ...
-			authStm = " IDENTIFIED WITH " + auth
+			authStm = " IDENTIFIED WITH " + quoteIdentifier(auth)
 		}
 	}
 	if v, ok := d.GetOk("auth_string_hashed"); ok {
@@ -152,9 +188,21 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			if authStm == "" {
 				return diag.Errorf("auth_string_hashed is not supported for auth plugin %s", auth)
 			}
+			// The hash is escaped already, as SHOW CREATE USER prints it.
 			authStm = fmt.Sprintf("%s AS '%s'", authStm, hashed)
 		}
 	}
+	if v, ok := d.GetOk("auth_string_plaintext"); ok {
+		plain := v.(string)
+		if plain != "" {
+			if authStm == "" {
+				return diag.Errorf("auth_string_plaintext is not supported for auth plugin %s", auth)
+			}
+			// The plugin gets the string and decides how to store it - e.g. LDAP
+			// plugins keep the user DN, password plugins hash it.
+			authStm = fmt.Sprintf("%s BY %s", authStm, quoteLiteral(plain))
+		}
+	}
 
 	var stmtSQL string
 
@@ -163,21 +211,21 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 		if aadIdentity["type"].(string) == "service_principal" {
 			// CREATE AADUSER 'mysqlProtocolLoginName"@"mysqlHostRestriction' IDENTIFIED BY 'identityId'
-			stmtSQL = fmt.Sprintf("CREATE AADUSER '%s'@'%s' IDENTIFIED BY '%s'",
-				d.Get("user").(string),
-				d.Get("host").(string),
-				aadIdentity["identity"].(string))
+			stmtSQL = fmt.Sprintf("CREATE AADUSER %s@%s IDENTIFIED BY %s",
+				quoteLiteral(d.Get("user").(string)),
+				quoteLiteral(d.Get("host").(string)),
+				quoteLiteral(aadIdentity["identity"].(string)))
 		} else {
 			// CREATE AADUSER 'identityName"@"mysqlHostRestriction' AS 'mysqlProtocolLoginName'
-			stmtSQL = fmt.Sprintf("CREATE AADUSER '%s'@'%s' AS '%s'",
-				aadIdentity["identity"].(string),
-				d.Get("host").(string),
-				d.Get("user").(string))
+			stmtSQL = fmt.Sprintf("CREATE AADUSER %s@%s AS %s",
+				quoteLiteral(aadIdentity["identity"].(string)),
+				quoteLiteral(d.Get("host").(string)),
+				quoteLiteral(d.Get("user").(string)))
 		}
 	} else {
-		stmtSQL = fmt.Sprintf("CREATE USER '%s'@'%s'",
-			d.Get("user").(string),
-			d.Get("host").(string))
+		stmtSQL = fmt.Sprintf("CREATE USER %s@%s",
+			quoteLiteral(d.Get("user").(string)),
+			quoteLiteral(d.Get("host").(string)))
 	}
 
 	var password string
@@ -187,6 +235,17 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		password = d.Get("password").(string)
 	}
 
+	// Users without an auth_plugin get the provider's default_auth_plugin,
+	// instead of the server's default.
+	if authStm == "" && createObj == "USER" {
+		if defaultAuthPlugin := meta.(*MySQLConfiguration).DefaultAuthPlugin; defaultAuthPlugin != "" {
+			authStm = " IDENTIFIED WITH " + quoteIdentifier(defaultAuthPlugin)
+			if password != "" {
+				authStm += " BY " + quoteLiteral(password)
+			}
+		}
+	}
+
 	if auth == "AWSAuthenticationPlugin" && d.Get("host").(string) == "localhost" {
 		return diag.Errorf("cannot use IAM auth against localhost")
 	}
@@ -194,7 +253,23 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	if authStm != "" {
 		stmtSQL = stmtSQL + authStm
 	} else if password != "" {
-		stmtSQL = stmtSQL + fmt.Sprintf(" IDENTIFIED BY '%s'", password)
+		stmtSQL = stmtSQL + " IDENTIFIED BY " + quoteLiteral(password)
+	}
+
+	var defaultRolesStmtSql = ""
+	defaultRoles := setToArray(d.Get("default_roles"))
+	if len(defaultRoles) > 0 {
+		if err := checkDefaultRolesSupport(ctx, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if createObj == "AADUSER" {
+			defaultRolesStmtSql = setDefaultRolesSQL(defaultRoles)
+		} else {
+			// Setting default roles as part of CREATE USER means there is no window
+			// in which the user can log in without them.
+			stmtSQL += " DEFAULT ROLE " + rolesSQLList(defaultRoles)
+		}
 	}
 
 	requiredVersion, _ := version.NewVersion("5.7.0")
@@ -203,9 +278,9 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 	if getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) && d.Get("tls_option").(string) != "" {
 		if createObj == "AADUSER" {
-			updateStmtSql = fmt.Sprintf("ALTER USER '%s'@'%s' REQUIRE %s",
-				d.Get("user").(string),
-				d.Get("host").(string),
+			updateStmtSql = fmt.Sprintf("ALTER USER %s@%s REQUIRE %s",
+				quoteLiteral(d.Get("user").(string)),
+				quoteLiteral(d.Get("host").(string)),
 				d.Get("tls_option").(string))
 		} else {
 			stmtSQL += fmt.Sprintf(" REQUIRE %s", d.Get("tls_option").(string))
@@ -220,7 +295,7 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		}
 	}
 
-	log.Println("Executing statement:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 	_, err = db.ExecContext(ctx, stmtSQL)
 	if err != nil {
 		return diag.Errorf("failed executing SQL: %v", err)
@@ -230,7 +305,7 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	d.SetId(user)
 
 	if updateStmtSql != "" {
-		log.Println("Executing statement:", updateStmtSql)
+		logStatement(ctx, updateStmtSql)
 		_, err = db.ExecContext(ctx, updateStmtSql)
 		if err != nil {
 			d.Set("tls_option", "")
@@ -238,9 +313,94 @@ func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		}
 	}
 
+	if defaultRolesStmtSql != "" {
+		logStatement(ctx, defaultRolesStmtSql)
+		_, err = db.ExecContext(ctx, defaultRolesStmtSql, d.Get("user").(string), d.Get("host").(string))
+		if err != nil {
+			return diag.Errorf("failed setting default roles: %v", err)
+		}
+	}
+
+	if err := setPasswordFingerprint(ctx, db, meta, d); err != nil {
+		return diag.Errorf("failed reading password fingerprint: %v", err)
+	}
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	return nil
+}
+
+// rolesSQLList formats role names as a comma-separated list usable in
+// DEFAULT ROLE clauses. Roles may be given either as "name" or "name@host".
+func rolesSQLList(roles []string) string {
+	quoted := make([]string, 0, len(roles))
+	for _, role := range roles {
+		quoted = append(quoted, parseRoleSpec(role).SQLString())
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// setDefaultRolesSQL returns the statement setting default roles of a user;
+// the user and host are bound as parameters.
+func setDefaultRolesSQL(roles []string) string {
+	if len(roles) == 0 {
+		return "SET DEFAULT ROLE NONE TO ?@?"
+	}
+	return fmt.Sprintf("SET DEFAULT ROLE %s TO ?@?", rolesSQLList(roles))
+}
+
+// checkDefaultRolesSupport fails unless the server keeps default roles in
+// mysql.default_roles, as MySQL 8 and TiDB do. MariaDB keeps a single default
+// role in mysql.user instead.
+func checkDefaultRolesSupport(ctx context.Context, meta interface{}) error {
+	hasRoles, err := supportsRoles(ctx, meta)
+	if err != nil {
+		return fmt.Errorf("failed getting role support: %w", err)
+	}
+	if !hasRoles || getFlavorFromMeta(ctx, meta) == flavorMariaDB {
+		return fmt.Errorf("default_roles are not supported by this version of MySQL")
+	}
 	return nil
 }
 
+// setDefaultRoles reads the default roles of the user into default_roles, if
+// the server supports them.
+func setDefaultRoles(ctx context.Context, db *sql.DB, meta interface{}, d *schema.ResourceData) error {
+	if checkDefaultRolesSupport(ctx, meta) != nil {
+		return nil
+	}
+	defaultRoles, err := readDefaultRoles(ctx, db, d.Get("user").(string), d.Get("host").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("default_roles", defaultRoles)
+	return nil
+}
+
+func readDefaultRoles(ctx context.Context, db *sql.DB, user string, host string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT DEFAULT_ROLE_USER, DEFAULT_ROLE_HOST FROM mysql.default_roles WHERE USER = ? AND HOST = ?", user, host)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var roleName, roleHost string
+		if err := rows.Scan(&roleName, &roleHost); err != nil {
+			return nil, err
+		}
+		if roleHost == "%" {
+			roles = append(roles, roleName)
+		} else {
+			roles = append(roles, fmt.Sprintf("%s@%s", roleName, roleHost))
+		}
+	}
+	return roles, rows.Err()
+}
+
 func getSetPasswordStatement(ctx context.Context, meta interface{}, retainPassword bool) (string, error) {
 	if retainPassword {
 		return "ALTER USER ?@? IDENTIFIED BY ? RETAIN CURRENT PASSWORD", nil
@@ -261,25 +421,43 @@ func UpdateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		return diag.FromErr(err)
 	}
 
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	// Changing only the password leaves the authentication plugin, TLS
+	// requirement, roles and grants as they are, so it skips all of them.
+	if !d.HasChangesExcept(userPasswordAttributes...) {
+		if diags := updateUserPassword(ctx, db, meta, d); diags != nil {
+			return diags
+		}
+		if err := flushPrivileges(ctx, db, meta); err != nil {
+			return diag.Errorf("failed flushing privileges: %v", err)
+		}
+		return nil
+	}
+
+	defer invalidateGrants(ctx, meta, UserOrRole{Name: d.Get("user").(string), Host: d.Get("host").(string)})
+
 	var auth string
 	if v, ok := d.GetOk("auth_plugin"); ok {
 		auth = v.(string)
 	}
 	if len(auth) > 0 {
-		if d.HasChange("tls_option") || d.HasChange("auth_plugin") || d.HasChange("auth_string_hashed") {
+		if d.HasChange("tls_option") || d.HasChange("auth_plugin") || d.HasChange("auth_string_hashed") || d.HasChange("auth_string_plaintext") {
 			var stmtSQL string
 
 			authString := ""
 			if d.Get("auth_string_hashed").(string) != "" {
-				authString = fmt.Sprintf("IDENTIFIED WITH %s AS '%s'", d.Get("auth_plugin"), d.Get("auth_string_hashed"))
+				authString = fmt.Sprintf("IDENTIFIED WITH %s AS '%s'", quoteIdentifier(auth), d.Get("auth_string_hashed"))
+			} else if d.Get("auth_string_plaintext").(string) != "" {
+				authString = fmt.Sprintf("IDENTIFIED WITH %s BY %s", quoteIdentifier(auth), quoteLiteral(d.Get("auth_string_plaintext").(string)))
 			}
-			stmtSQL = fmt.Sprintf("ALTER USER '%s'@'%s' %s  REQUIRE %s",
-				d.Get("user").(string),
-				d.Get("host").(string),
+			stmtSQL = fmt.Sprintf("ALTER USER %s@%s %s  REQUIRE %s",
+				quoteLiteral(d.Get("user").(string)),
+				quoteLiteral(d.Get("host").(string)),
 				authString,
 				d.Get("tls_option").(string))
 
-			log.Println("Executing query:", stmtSQL)
+			logStatement(ctx, stmtSQL)
 			_, err := db.ExecContext(ctx, stmtSQL)
 			if err != nil {
 				return diag.Errorf("failed running query: %v", err)
@@ -287,6 +465,55 @@ func UpdateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		}
 	}
 
+	if diags := updateUserPassword(ctx, db, meta, d); diags != nil {
+		return diags
+	}
+
+	if d.HasChange("default_roles") {
+		if err := checkDefaultRolesSupport(ctx, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		stmtSQL := setDefaultRolesSQL(setToArray(d.Get("default_roles")))
+		logStatement(ctx, stmtSQL)
+		_, err = db.ExecContext(ctx, stmtSQL, d.Get("user").(string), d.Get("host").(string))
+		if mysqlErrorNumber(err) == roleNotGrantedErrCode {
+			return diag.Errorf("failed setting default roles: %v; the roles have to be granted to the user first, e.g. with mysql_grant", err)
+		}
+		if err != nil {
+			return diag.Errorf("failed setting default roles: %v", err)
+		}
+	}
+
+	requiredVersion, _ := version.NewVersion("5.7.0")
+	if d.HasChange("tls_option") && getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) {
+		var stmtSQL string
+
+		stmtSQL = fmt.Sprintf("ALTER USER %s@%s REQUIRE %s",
+			quoteLiteral(d.Get("user").(string)),
+			quoteLiteral(d.Get("host").(string)),
+			d.Get("tls_option").(string))
+
+		logStatement(ctx, stmtSQL)
+		_, err := db.ExecContext(ctx, stmtSQL)
+		if err != nil {
+			return diag.Errorf("failed setting require tls option: %v", err)
+		}
+	}
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	return nil
+}
+
+// userPasswordAttributes are the attributes updateUserPassword takes care of.
+var userPasswordAttributes = []string{"plaintext_password", "password", "retain_old_password", "detect_password_drift", "password_fingerprint", "planned_statements"}
+
+// updateUserPassword sets the new password, if it changed, and refreshes the
+// password fingerprint.
+func updateUserPassword(ctx context.Context, db *sql.DB, meta interface{}, d *schema.ResourceData) diag.Diagnostics {
 	var newpw interface{}
 	if d.HasChange("plaintext_password") {
 		_, newpw = d.GetChange("plaintext_password")
@@ -310,7 +537,7 @@ func UpdateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			return diag.Errorf("failed getting change password statement: %v", err)
 		}
 
-		log.Println("Executing query:", stmtSQL)
+		logStatement(ctx, stmtSQL)
 		_, err = db.ExecContext(ctx, stmtSQL,
 			d.Get("user").(string),
 			d.Get("host").(string),
@@ -320,30 +547,22 @@ func UpdateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		}
 	}
 
-	requiredVersion, _ := version.NewVersion("5.7.0")
-	if d.HasChange("tls_option") && getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) {
-		var stmtSQL string
-
-		stmtSQL = fmt.Sprintf("ALTER USER '%s'@'%s' REQUIRE %s",
-			d.Get("user").(string),
-			d.Get("host").(string),
-			d.Get("tls_option").(string))
-
-		log.Println("Executing query:", stmtSQL)
-		_, err := db.ExecContext(ctx, stmtSQL)
-		if err != nil {
-			return diag.Errorf("failed setting require tls option: %v", err)
-		}
+	if err := setPasswordFingerprint(ctx, db, meta, d); err != nil {
+		return diag.Errorf("failed reading password fingerprint: %v", err)
 	}
 
 	return nil
 }
 
 func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	db, err := getDatabaseFromMeta(ctx, meta)
+	db, err := getReadDatabaseFromMeta(ctx, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if err := checkPasswordDrift(ctx, db, meta, d); err != nil {
+		return diag.Errorf("failed checking password drift: %v", err)
+	}
 	requiredVersion, _ := version.NewVersion("5.7.0")
 	if getVersionFromMeta(ctx, meta).GreaterThan(requiredVersion) {
 		stmt := "SHOW CREATE USER ?@?"
@@ -358,6 +577,20 @@ func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 			return diag.Errorf("failed getting version: %v", err)
 		}
 
+		// Some proxies quote the account with double quotes even without
+		// ANSI_QUOTES.
+		if getAnsiQuotesFromMeta(ctx, meta) || strings.HasPrefix(createUserStmt, `CREATE USER "`) {
+			createUserStmt = backtickAnsiIdentifiers(createUserStmt)
+		}
+
+		// Default roles are only read if they're managed, so users without
+		// them configured don't show changes.
+		if _, ok := d.GetOk("default_roles"); ok {
+			if err := setDefaultRoles(ctx, db, meta, d); err != nil {
+				return diag.Errorf("failed reading default roles: %v", err)
+			}
+		}
+
 		// Examples of create user:
 		// CREATE USER 'some_app'@'%' IDENTIFIED WITH 'mysql_native_password' AS '*0something' REQUIRE NONE PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK
 		// CREATE USER `jdoe-tf-test-47`@`example.com` IDENTIFIED WITH 'caching_sha2_password' REQUIRE NONE PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK PASSWORD HISTORY DEFAULT PASSWORD REUSE INTERVAL DEFAULT PASSWORD REQUIRE CURRENT DEFAULT
@@ -367,7 +600,7 @@ func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 			d.Set("user", m[1])
 			d.Set("host", m[2])
 			d.Set("auth_plugin", m[3])
-			d.Set("tls_option", m[5])
+			d.Set("tls_option", parseTLSOption(createUserStmt))
 
 			if m[3] == "aad_auth" {
 				// AADGroup:98e61c8d-e104-4f8c-b1a6-7ae873617fe6:upn:Doe_Family_Group
@@ -403,6 +636,14 @@ func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 				} else {
 					return diag.Errorf("AAD identity couldn't be parsed - it is %s", m[4])
 				}
+			} else if isPassthroughAuthPlugin(m[3]) {
+				// LDAP and PAM plugins store the auth string verbatim, so we can
+				// detect drift of whichever attribute was used to set it.
+				if _, ok := d.GetOk("auth_string_plaintext"); ok {
+					d.Set("auth_string_plaintext", m[4])
+				} else {
+					d.Set("auth_string_hashed", m[4])
+				}
 			} else {
 				d.Set("auth_string_hashed", m[4])
 			}
@@ -413,17 +654,17 @@ func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 		re2 := regexp.MustCompile("^CREATE USER")
 		if m := re2.FindStringSubmatch(createUserStmt); m != nil {
 			// Ok, we have at least something - it's probably in MariaDB.
+			d.Set("tls_option", parseTLSOption(createUserStmt))
 			return nil
 		}
 		return diag.Errorf("Create user couldn't be parsed - it is %s", createUserStmt)
 	} else {
 		// Worse user detection, only for compat with MySQL 5.6
-		stmtSQL := fmt.Sprintf("SELECT USER FROM mysql.user WHERE USER='%s'",
-			d.Get("user").(string))
+		stmtSQL := "SELECT USER FROM mysql.user WHERE USER = ?"
 
-		log.Println("Executing statement:", stmtSQL)
+		logStatement(ctx, stmtSQL)
 
-		rows, err := db.QueryContext(ctx, stmtSQL)
+		rows, err := db.QueryContext(ctx, stmtSQL, d.Get("user").(string))
 		if err != nil {
 			return diag.Errorf("failed getting user from DB: %v", err)
 		}
@@ -446,18 +687,86 @@ func DeleteUser(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		return diag.FromErr(err)
 	}
 
+	defer lockPrivilegeChanges(ctx, meta)()
+	defer invalidateGrants(ctx, meta, UserOrRole{Name: d.Get("user").(string), Host: d.Get("host").(string)})
+
 	stmtSQL := fmt.Sprintf("DROP USER ?@?")
 
-	log.Println("Executing statement:", stmtSQL)
+	logStatement(ctx, stmtSQL)
 
 	_, err = db.ExecContext(ctx, stmtSQL,
 		d.Get("user").(string),
 		d.Get("host").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
 
-	if err == nil {
-		d.SetId("")
+// readAuthString returns what the server stores to authenticate the user,
+// which changes whenever the password does.
+func readAuthString(ctx context.Context, db *sql.DB, meta interface{}, user string, host string) (string, error) {
+	stmtSQL := "SELECT authentication_string FROM mysql.user WHERE User = ? AND Host = ?"
+	requiredVersion, _ := version.NewVersion("5.7.0")
+	if getFlavorFromMeta(ctx, meta) == flavorMariaDB || getVersionFromMeta(ctx, meta).LessThan(requiredVersion) {
+		// Native passwords are kept in the Password column there.
+		stmtSQL = "SELECT CONCAT(Password, authentication_string) FROM mysql.user WHERE User = ? AND Host = ?"
 	}
-	return diag.FromErr(err)
+
+	logStatement(ctx, stmtSQL, user, host)
+	var authString string
+	err := db.QueryRowContext(ctx, stmtSQL, user, host).Scan(&authString)
+	return authString, err
+}
+
+// setPasswordFingerprint remembers the hash of the auth string after the
+// password was set, for detect_password_drift.
+func setPasswordFingerprint(ctx context.Context, db *sql.DB, meta interface{}, d *schema.ResourceData) error {
+	if !d.Get("detect_password_drift").(bool) {
+		d.Set("password_fingerprint", "")
+		return nil
+	}
+
+	authString, err := readAuthString(ctx, db, meta, d.Get("user").(string), d.Get("host").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("password_fingerprint", hashSum(authString))
+	return nil
+}
+
+// checkPasswordDrift clears the password in the state if the auth string
+// changed since Terraform set it, so that the password is set again.
+func checkPasswordDrift(ctx context.Context, db *sql.DB, meta interface{}, d *schema.ResourceData) error {
+	fingerprint := d.Get("password_fingerprint").(string)
+	if !d.Get("detect_password_drift").(bool) || fingerprint == "" {
+		return nil
+	}
+
+	authString, err := readAuthString(ctx, db, meta, d.Get("user").(string), d.Get("host").(string))
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if hashSum(authString) == fingerprint {
+		return nil
+	}
+
+	log.Printf("[WARN] Password of user %s was changed outside of Terraform", d.Id())
+	for _, key := range []string{"plaintext_password", "password"} {
+		if d.Get(key).(string) != "" {
+			d.Set(key, "")
+		}
+	}
+	return nil
 }
 
 func ImportUser(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -468,18 +777,45 @@ func ImportUser(ctx context.Context, d *schema.ResourceData, meta interface{}) (
 	}
 
 	user := userHost[0]
-	host := userHost[1]
+	host := unbracketHost(userHost[1])
 	d.Set("user", user)
 	d.Set("host", host)
 	err := ReadUser(ctx, d, meta)
 	var ferror error
 	if err.HasError() {
 		ferror = fmt.Errorf("failed reading user: %v", err)
+	} else if d.Id() != "" {
+		db, dbErr := getReadDatabaseFromMeta(ctx, meta)
+		if dbErr == nil {
+			dbErr = setDefaultRoles(ctx, db, meta, d)
+		}
+		if dbErr != nil {
+			ferror = fmt.Errorf("failed reading default roles: %v", dbErr)
+		}
 	}
 
 	return []*schema.ResourceData{d}, ferror
 }
 
+// Auth plugins which store the given auth string (user DN, PAM service name
+// and group mapping, ...) as-is instead of hashing it.
+var passthroughAuthPlugins = []string{
+	"authentication_ldap_simple",
+	"authentication_ldap_sasl",
+	"authentication_pam",
+	"auth_pam",
+	"auth_pam_compat",
+}
+
+func isPassthroughAuthPlugin(plugin string) bool {
+	for _, p := range passthroughAuthPlugins {
+		if strings.EqualFold(p, plugin) {
+			return true
+		}
+	}
+	return false
+}
+
 func NewEmptyStringSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 	if new == "" {
 		return true
@@ -487,3 +823,9 @@ func NewEmptyStringSuppressFunc(k, old, new string, d *schema.ResourceData) bool
 
 	return false
 }
+
+// tlsOptionSuppressFunc ignores differences in case, order and AND between
+// the requirements of tls_option.
+func tlsOptionSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeTLSOption(old) == normalizeTLSOption(new)
+}
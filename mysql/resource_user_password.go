@@ -19,19 +19,37 @@ func resourceUserPassword() *schema.Resource {
 		DeleteContext: DeleteUserPassword,
 		Schema: map[string]*schema.Schema{
 			"user": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUserName,
 			},
 			"host": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "localhost",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "localhost",
+				ValidateFunc: validateHostPattern,
 			},
 			"plaintext_password": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"auth_string_hashed"},
+			},
+
+			"auth_plugin": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"auth_string_hashed"},
+			},
+
+			"auth_string_hashed": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				RequiredWith:  []string{"auth_plugin"},
+				ConflictsWith: []string{"plaintext_password"},
 			},
 
 			"retain_old_password": {
@@ -48,17 +66,6 @@ func SetUserPassword(ctx context.Context, d *schema.ResourceData, meta interface
 		return diag.FromErr(err)
 	}
 
-	uuid, err := uuid.NewV4()
-	if err != nil {
-		return diag.Errorf("failed getting UUID: %v", err)
-	}
-
-	password, passOk := d.GetOk("plaintext_password")
-	if !passOk {
-		password = uuid.String()
-		d.Set("plaintext_password", password)
-	}
-
 	retainPassword := d.Get("retain_old_password").(bool)
 	if retainPassword {
 		err := checkRetainCurrentPasswordSupport(ctx, meta)
@@ -67,17 +74,50 @@ func SetUserPassword(ctx context.Context, d *schema.ResourceData, meta interface
 		}
 	}
 
-	stmtSQL, err := getSetPasswordStatement(ctx, meta, retainPassword)
-	if err != nil {
-		return diag.Errorf("failed getting password statement: %v", err)
+	if hashed, ok := d.GetOk("auth_string_hashed"); ok {
+		// Pre-hashed auth strings are passed through as-is, so they never need to
+		// be known in plain text by Terraform.
+		stmtSQL, err := getSetHashedPasswordStatement(ctx, meta, d.Get("auth_plugin").(string), retainPassword)
+		if err != nil {
+			return diag.Errorf("failed getting password statement: %v", err)
+		}
+		logStatement(ctx, stmtSQL)
+		_, err = db.ExecContext(ctx, stmtSQL,
+			d.Get("user").(string),
+			d.Get("host").(string),
+			hashed.(string))
+		if err != nil {
+			return diag.Errorf("failed executing change statement: %v", err)
+		}
+	} else {
+		uuid, err := uuid.NewV4()
+		if err != nil {
+			return diag.Errorf("failed getting UUID: %v", err)
+		}
+
+		password, passOk := d.GetOk("plaintext_password")
+		if !passOk {
+			password = uuid.String()
+			d.Set("plaintext_password", password)
+		}
+
+		stmtSQL, err := getSetPasswordStatement(ctx, meta, retainPassword)
+		if err != nil {
+			return diag.Errorf("failed getting password statement: %v", err)
+		}
+		_, err = db.ExecContext(ctx, stmtSQL,
+			d.Get("user").(string),
+			d.Get("host").(string),
+			password)
+		if err != nil {
+			return diag.Errorf("failed executing change statement: %v", err)
+		}
 	}
-	_, err = db.ExecContext(ctx, stmtSQL,
-		d.Get("user").(string),
-		d.Get("host").(string),
-		password)
-	if err != nil {
-		return diag.Errorf("failed executing change statement: %v", err)
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
 	}
+
 	user := fmt.Sprintf("%s@%s",
 		d.Get("user").(string),
 		d.Get("host").(string))
@@ -85,6 +125,20 @@ func SetUserPassword(ctx context.Context, d *schema.ResourceData, meta interface
 	return nil
 }
 
+func getSetHashedPasswordStatement(ctx context.Context, meta interface{}, authPlugin string, retainPassword bool) (string, error) {
+	ver, _ := version.NewVersion("5.7.6")
+	if getVersionFromMeta(ctx, meta).LessThan(ver) {
+		return "", fmt.Errorf("setting a hashed auth string requires MySQL 5.7.6 or newer")
+	}
+
+	// MySQL only allows RETAIN CURRENT PASSWORD together with IDENTIFIED BY.
+	if retainPassword {
+		return "", fmt.Errorf("retain_old_password cannot be used with auth_string_hashed")
+	}
+
+	return fmt.Sprintf("ALTER USER ?@? IDENTIFIED WITH %s AS ?", quoteIdentifier(authPlugin)), nil
+}
+
 func canReadPassword(ctx context.Context, meta interface{}) (bool, error) {
 	serverVersion := getVersionFromMeta(ctx, meta)
 	ver, _ := version.NewVersion("8.0.0")
@@ -92,6 +146,11 @@ func canReadPassword(ctx context.Context, meta interface{}) (bool, error) {
 }
 
 func ReadUserPassword(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if _, ok := d.GetOk("auth_string_hashed"); ok {
+		// We have no plain text to compare against; trust the stored hash.
+		return nil
+	}
+
 	canRead, err := canReadPassword(ctx, meta)
 	if err != nil {
 		return diag.Errorf("cannot get whether we can read password: %v", err)
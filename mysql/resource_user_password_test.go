@@ -1,8 +1,12 @@
 package mysql
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"context"
+	"fmt"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccUserPassword_basic(t *testing.T) {
@@ -23,6 +27,52 @@ func TestAccUserPassword_basic(t *testing.T) {
 	})
 }
 
+func TestAccUserPassword_hashed(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipNotMySQLVersionMin(t, "5.7.6")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserPasswordConfig_hashed,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserExists("mysql_user.test"),
+					testAccUserAuthString("mysql_user.test", "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserAuthString(rn string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var authString string
+		err = db.QueryRow("SELECT authentication_string FROM mysql.user WHERE CONCAT(user, '@', host) = ?", rs.Primary.ID).Scan(&authString)
+		if err != nil {
+			return fmt.Errorf("error reading user: %s", err)
+		}
+		if authString != expected {
+			return fmt.Errorf("expected auth string %s, got %s", expected, authString)
+		}
+
+		return nil
+	}
+}
+
 const testAccUserPasswordConfig_basic = `
 resource "mysql_user" "test" {
   user = "jdoe"
@@ -33,3 +83,15 @@ resource "mysql_user_password" "test" {
   plaintext_password = "somepass"
 }
 `
+
+const testAccUserPasswordConfig_hashed = `
+resource "mysql_user" "test" {
+  user = "jdoe"
+}
+
+resource "mysql_user_password" "test" {
+  user               = "${mysql_user.test.user}"
+  auth_plugin        = "mysql_native_password"
+  auth_string_hashed = "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19"
+}
+`
@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// userResourceLimits maps attributes to the options of ALTER USER ... WITH
+// and the columns of mysql.user.
+var userResourceLimits = []struct {
+	attribute string
+	option    string
+	column    string
+}{
+	{"max_queries_per_hour", "MAX_QUERIES_PER_HOUR", "max_questions"},
+	{"max_updates_per_hour", "MAX_UPDATES_PER_HOUR", "max_updates"},
+	{"max_connections_per_hour", "MAX_CONNECTIONS_PER_HOUR", "max_connections"},
+	{"max_user_connections", "MAX_USER_CONNECTIONS", "max_user_connections"},
+}
+
+func resourceUserResourceLimits() *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"user": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validateUserName,
+		},
+		"host": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "localhost",
+			ValidateFunc: validateHostPattern,
+		},
+	}
+	for _, limit := range userResourceLimits {
+		resourceSchema[limit.attribute] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: SetUserResourceLimits,
+		UpdateContext: SetUserResourceLimits,
+		ReadContext:   ReadUserResourceLimits,
+		DeleteContext: DeleteUserResourceLimits,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportUserResourceLimits,
+		},
+		Schema: resourceSchema,
+	}
+}
+
+func SetUserResourceLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	limits := map[string]int{}
+	for _, limit := range userResourceLimits {
+		limits[limit.attribute] = d.Get(limit.attribute).(int)
+	}
+	userOrRole := UserOrRole{Name: d.Get("user").(string), Host: d.Get("host").(string)}
+	if err := alterUserResourceLimits(ctx, db, meta, userOrRole, limits); err != nil {
+		return diag.Errorf("failed setting resource limits: %v", err)
+	}
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", userOrRole.Name, userOrRole.Host))
+
+	return ReadUserResourceLimits(ctx, d, meta)
+}
+
+func ReadUserResourceLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	columns := make([]string, 0, len(userResourceLimits))
+	for _, limit := range userResourceLimits {
+		columns = append(columns, limit.column)
+	}
+	stmtSQL := fmt.Sprintf("SELECT %s FROM mysql.user WHERE User = ? AND Host = ?", strings.Join(columns, ", "))
+	logStatement(ctx, stmtSQL, d.Get("user").(string), d.Get("host").(string))
+
+	values := make([]int, len(userResourceLimits))
+	pointers := make([]interface{}, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	err = db.QueryRowContext(ctx, stmtSQL, d.Get("user").(string), d.Get("host").(string)).Scan(pointers...)
+	if err == sql.ErrNoRows {
+		log.Printf("[WARN] User (%s) not found; removing resource limits from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed reading resource limits: %v", err)
+	}
+
+	for i, limit := range userResourceLimits {
+		d.Set(limit.attribute, values[i])
+	}
+
+	return nil
+}
+
+// DeleteUserResourceLimits lifts all limits of the user, if it still exists.
+func DeleteUserResourceLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	limits := map[string]int{}
+	for _, limit := range userResourceLimits {
+		limits[limit.attribute] = 0
+	}
+	userOrRole := UserOrRole{Name: d.Get("user").(string), Host: d.Get("host").(string)}
+	err = alterUserResourceLimits(ctx, db, meta, userOrRole, limits)
+	if mysqlErrorNumber(err) == unknownUserErrCode {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed lifting resource limits: %v", err)
+	}
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func ImportUserResourceLimits(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	userHost := strings.SplitN(d.Id(), "@", 2)
+	if len(userHost) != 2 {
+		return nil, fmt.Errorf("wrong ID format %s (expected USER@HOST)", d.Id())
+	}
+
+	d.Set("user", userHost[0])
+	d.Set("host", userHost[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func alterUserResourceLimits(ctx context.Context, db *sql.DB, meta interface{}, userOrRole UserOrRole, limits map[string]int) error {
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	stmtSQL := userResourceLimitsSQL(userOrRole, limits)
+	logStatement(ctx, stmtSQL)
+	_, err := db.ExecContext(ctx, stmtSQL)
+	// SHOW GRANTS of old servers lists the limits too.
+	invalidateGrants(ctx, meta, userOrRole)
+	return err
+}
+
+// userResourceLimitsSQL returns e.g. ALTER USER 'jdoe'@'%' WITH
+// MAX_QUERIES_PER_HOUR 100 MAX_UPDATES_PER_HOUR 0 ...
+func userResourceLimitsSQL(userOrRole UserOrRole, limits map[string]int) string {
+	options := make([]string, 0, len(userResourceLimits))
+	for _, limit := range userResourceLimits {
+		options = append(options, fmt.Sprintf("%s %d", limit.option, limits[limit.attribute]))
+	}
+	return fmt.Sprintf("ALTER USER %s WITH %s", userOrRole.SQLString(), strings.Join(options, " "))
+}
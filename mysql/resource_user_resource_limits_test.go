@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserResourceLimits_basic(t *testing.T) {
+	resourceName := "mysql_user_resource_limits.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceLimitsConfig(100, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "max_queries_per_hour", "100"),
+					resource.TestCheckResourceAttr(resourceName, "max_user_connections", "0"),
+				),
+			},
+			{
+				Config: testAccUserResourceLimitsConfig(200, 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "max_queries_per_hour", "200"),
+					resource.TestCheckResourceAttr(resourceName, "max_user_connections", "5"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "jdoe-limits@example.com",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUserResourceLimitsSQL(t *testing.T) {
+	limits := map[string]int{
+		"max_queries_per_hour": 100,
+		"max_user_connections": 5,
+	}
+
+	want := "ALTER USER 'jdoe'@'%' WITH MAX_QUERIES_PER_HOUR 100 MAX_UPDATES_PER_HOUR 0 MAX_CONNECTIONS_PER_HOUR 0 MAX_USER_CONNECTIONS 5"
+	if got := userResourceLimitsSQL(UserOrRole{Name: "jdoe", Host: "%"}, limits); got != want {
+		t.Errorf("userResourceLimitsSQL() = %q, want %q", got, want)
+	}
+}
+
+func testAccUserResourceLimitsConfig(queries int, connections int) string {
+	return fmt.Sprintf(`
+resource "mysql_user" "test" {
+  user               = "jdoe-limits"
+  host               = "example.com"
+  plaintext_password = "password"
+}
+
+resource "mysql_user_resource_limits" "test" {
+  user                 = mysql_user.test.user
+  host                 = mysql_user.test.host
+  max_queries_per_hour = %d
+  max_user_connections = %d
+}
+`, queries, connections)
+}
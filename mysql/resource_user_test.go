@@ -8,7 +8,9 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -52,6 +54,43 @@ func TestAccUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccUser_passwordDrift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckSkipMariaDB(t); testAccPreCheckSkipTiDB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_passwordDrift,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserExists("mysql_user.test"),
+					resource.TestCheckResourceAttrSet("mysql_user.test", "password_fingerprint"),
+				),
+			},
+			{
+				PreConfig: func() {
+					db, err := connectToMySQL(context.Background(), testAccProvider.Meta().(*MySQLConfiguration))
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := db.Exec("ALTER USER 'jdoe'@'%' IDENTIFIED BY 'rotated'"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccUserConfig_passwordDrift,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccUserConfig_passwordDrift,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_user.test", "plaintext_password", hashSum("password")),
+				),
+			},
+		},
+	})
+}
+
 func TestAccUser_auth(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheckSkipTiDB(t); testAccPreCheckSkipMariaDB(t); testAccPreCheckSkipRds(t) },
@@ -192,6 +231,81 @@ func TestAccUser_deprecated(t *testing.T) {
 	})
 }
 
+func TestAccUser_netmaskHost(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckSkipTiDB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_netmaskHost,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserExists("mysql_user.test"),
+					resource.TestCheckResourceAttr("mysql_user.test", "host", "192.168.0.0/255.255.255.0"),
+					resource.TestCheckResourceAttr("mysql_grant.test", "host", "192.168.0.0/255.255.255.0"),
+				),
+			},
+			{
+				ResourceName:            "mysql_user.test",
+				ImportState:             true,
+				ImportStateId:           "jdoe@192.168.0.0/255.255.255.0",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"plaintext_password"},
+			},
+		},
+	})
+}
+
+func TestAccUser_defaultRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSkipTiDB(t)
+			testAccPreCheckSkipMariaDB(t)
+			testAccPreCheckSkipNotMySQL8(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				// SET DEFAULT ROLE requires the roles to be granted, so they
+				// are granted before they become default roles.
+				Config: testAccUserConfig_defaultRoles(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserExists("mysql_user.test"),
+					resource.TestCheckResourceAttr("mysql_user.test", "default_roles.#", "0"),
+				),
+			},
+			{
+				Config: testAccUserConfig_defaultRoles("\"${mysql_role.reader.name}\""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_user.test", "default_roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mysql_user.test", "default_roles.*", "tf-test-reader"),
+				),
+			},
+			{
+				Config: testAccUserConfig_defaultRoles("\"${mysql_role.reader.name}\", \"${mysql_role.writer.name}\""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_user.test", "default_roles.#", "2"),
+				),
+			},
+			{
+				Config:                  testAccUserConfig_defaultRoles("\"${mysql_role.reader.name}\", \"${mysql_role.writer.name}\""),
+				ResourceName:            "mysql_user.test",
+				ImportState:             true,
+				ImportStateId:           "jdoe@%",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"plaintext_password"},
+			},
+			{
+				Config: testAccUserConfig_defaultRoles(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_user.test", "default_roles.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccUserExists(rn string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -308,6 +422,30 @@ resource "mysql_user" "test" {
 }
 `
 
+const testAccUserConfig_netmaskHost = `
+resource "mysql_user" "test" {
+    user = "jdoe"
+    host = "192.168.0.0/255.255.255.0"
+    plaintext_password = "password"
+}
+
+resource "mysql_grant" "test" {
+    user       = mysql_user.test.user
+    host       = mysql_user.test.host
+    database   = "*"
+    privileges = ["PROCESS"]
+}
+`
+
+const testAccUserConfig_passwordDrift = `
+resource "mysql_user" "test" {
+    user = "jdoe"
+    host = "%"
+    plaintext_password = "password"
+    detect_password_drift = true
+}
+`
+
 const testAccUserConfig_ssl = `
 resource "mysql_user" "test" {
 	user = "jdoe"
@@ -386,3 +524,63 @@ resource "mysql_user" "test" {
     retain_old_password = true
 }
 `
+
+func testAccUserConfig_defaultRoles(roles string) string {
+	return fmt.Sprintf(`
+resource "mysql_role" "reader" {
+    name = "tf-test-reader"
+}
+
+resource "mysql_role" "writer" {
+    name = "tf-test-writer"
+}
+
+resource "mysql_user" "test" {
+    user               = "jdoe"
+    host               = "%%"
+    plaintext_password = "password"
+    default_roles      = [%s]
+}
+
+resource "mysql_grant" "roles" {
+    user     = mysql_user.test.user
+    host     = mysql_user.test.host
+    database = ""
+    roles    = [mysql_role.reader.name, mysql_role.writer.name]
+}
+`, roles)
+}
+
+func TestUserPasswordAttributes(t *testing.T) {
+	// The statements planned for a new password change too, which mustn't
+	// keep the password from being changed on its own.
+	user := *Provider().ResourcesMap["mysql_user"]
+	passwordOnly := false
+	user.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		passwordOnly = !d.HasChangesExcept(userPasswordAttributes...)
+		return nil
+	}
+
+	state := &terraform.InstanceState{
+		ID: "jdoe@%",
+		Attributes: map[string]string{
+			"id":                 "jdoe@%",
+			"user":               "jdoe",
+			"host":               "%",
+			"plaintext_password": hashSum("old"),
+		},
+	}
+	diff := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"plaintext_password":   {Old: hashSum("old"), New: "new"},
+			"planned_statements.#": {Old: "0", New: "1"},
+			"planned_statements.0": {New: "ALTER USER 'jdoe'@'%' IDENTIFIED BY <redacted>"},
+		},
+	}
+	if _, diags := user.Apply(context.Background(), state, diff, &MySQLConfiguration{}); diags.HasError() {
+		t.Fatalf("applying failed: %v", diags)
+	}
+	if !passwordOnly {
+		t.Error("expected only the password to change")
+	}
+}
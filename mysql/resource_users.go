@@ -0,0 +1,337 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// usersBatchSize limits the accounts changed by one statement, so statements
+// stay well below max_allowed_packet.
+const usersBatchSize = 100
+
+func resourceUsers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateUsers,
+		UpdateContext: UpdateUsers,
+		ReadContext:   ReadUsers,
+		DeleteContext: DeleteUsers,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportUsers,
+		},
+		Schema: map[string]*schema.Schema{
+			"users": {
+				Type:         schema.TypeMap,
+				Required:     true,
+				Sensitive:    true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateUsersMap,
+			},
+		},
+	}
+}
+
+func CreateUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	users := d.Get("users").(map[string]interface{})
+	created, err := changeUsers(ctx, db, meta, "CREATE USER", users, sortedAccounts(users))
+	if err != nil {
+		// The users created before the failure are kept in the state.
+		if len(created) > 0 {
+			setUsers(d, accountsOf(users, created))
+		}
+		return diag.Errorf("failed creating users: %v", err)
+	}
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId(usersId(users))
+
+	return ReadUsers(ctx, d, meta)
+}
+
+func UpdateUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	oldUsersIf, newUsersIf := d.GetChange("users")
+	oldUsers := oldUsersIf.(map[string]interface{})
+	newUsers := newUsersIf.(map[string]interface{})
+
+	toDrop, toCreate, toAlter := []string{}, []string{}, []string{}
+	for _, key := range sortedAccounts(oldUsers) {
+		if _, ok := newUsers[key]; !ok {
+			toDrop = append(toDrop, key)
+		}
+	}
+	for _, key := range sortedAccounts(newUsers) {
+		if password, ok := oldUsers[key]; !ok {
+			toCreate = append(toCreate, key)
+		} else if password != newUsers[key] {
+			toAlter = append(toAlter, key)
+		}
+	}
+
+	// current are the users as they've been changed so far, which are kept
+	// in the state if a change fails.
+	current := accountsOf(oldUsers, sortedAccounts(oldUsers))
+	dropped, err := changeUsers(ctx, db, meta, "DROP USER", oldUsers, toDrop)
+	for _, key := range dropped {
+		delete(current, key)
+	}
+	if err != nil {
+		setUsers(d, current)
+		return diag.Errorf("failed dropping users: %v", err)
+	}
+	created, err := changeUsers(ctx, db, meta, "CREATE USER", newUsers, toCreate)
+	for _, key := range created {
+		current[key] = newUsers[key]
+	}
+	if err != nil {
+		setUsers(d, current)
+		return diag.Errorf("failed creating users: %v", err)
+	}
+	altered, err := changeUsers(ctx, db, meta, "ALTER USER", newUsers, toAlter)
+	for _, key := range altered {
+		current[key] = newUsers[key]
+	}
+	if err != nil {
+		setUsers(d, current)
+		return diag.Errorf("failed changing passwords: %v", err)
+	}
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId(usersId(newUsers))
+
+	return ReadUsers(ctx, d, meta)
+}
+
+func ReadUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getReadDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stmtSQL := "SELECT User, Host FROM mysql.user"
+	logStatement(ctx, stmtSQL)
+	rows, err := db.QueryContext(ctx, stmtSQL)
+	if err != nil {
+		return diag.Errorf("failed reading users: %v", err)
+	}
+	defer rows.Close()
+
+	existing := map[UserOrRole]bool{}
+	for rows.Next() {
+		var account UserOrRole
+		if err := rows.Scan(&account.Name, &account.Host); err != nil {
+			return diag.Errorf("failed scanning MySQL rows: %v", err)
+		}
+		existing[account] = true
+	}
+	if err := rows.Err(); err != nil {
+		return diag.Errorf("failed reading users: %v", err)
+	}
+
+	// Passwords can't be read back, so only dropped users are detected.
+	users := map[string]interface{}{}
+	for key, password := range d.Get("users").(map[string]interface{}) {
+		if existing[parseAccountKey(key)] {
+			users[key] = password
+		}
+	}
+	d.Set("users", users)
+
+	return nil
+}
+
+func DeleteUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := getDatabaseFromMeta(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer lockPrivilegeChanges(ctx, meta)()
+
+	users := d.Get("users").(map[string]interface{})
+	dropped, err := changeUsers(ctx, db, meta, "DROP USER", users, sortedAccounts(users))
+	if err != nil {
+		// The users dropped before the failure are gone from the state.
+		remaining := accountsOf(users, sortedAccounts(users))
+		for _, key := range dropped {
+			delete(remaining, key)
+		}
+		setUsers(d, remaining)
+		return diag.Errorf("failed dropping users: %v", err)
+	}
+
+	if err := flushPrivileges(ctx, db, meta); err != nil {
+		return diag.Errorf("failed flushing privileges: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// changeUsers runs the statement for the given accounts, changing up to
+// usersBatchSize accounts at once. It returns the accounts of the statements
+// that succeeded, also when a later one fails.
+func changeUsers(ctx context.Context, db *sql.DB, meta interface{}, verb string, users map[string]interface{}, keys []string) ([]string, error) {
+	defaultAuthPlugin := meta.(*MySQLConfiguration).DefaultAuthPlugin
+
+	changed := []string{}
+	for i, stmtSQL := range usersStatements(verb, users, keys, defaultAuthPlugin) {
+		batch := keys[i*usersBatchSize:]
+		if len(batch) > usersBatchSize {
+			batch = batch[:usersBatchSize]
+		}
+
+		logStatement(ctx, stmtSQL)
+		_, err := db.ExecContext(ctx, stmtSQL)
+		for _, key := range batch {
+			invalidateGrants(ctx, meta, parseAccountKey(key))
+		}
+		if err != nil {
+			return changed, err
+		}
+		changed = append(changed, batch...)
+	}
+	return changed, nil
+}
+
+// setUsers sets the users the resource manages, e.g. the ones left by a
+// failed change.
+func setUsers(d *schema.ResourceData, users map[string]interface{}) {
+	d.Set("users", users)
+	d.SetId(usersId(users))
+}
+
+// accountsOf returns the users of the given accounts.
+func accountsOf(users map[string]interface{}, keys []string) map[string]interface{} {
+	accounts := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		accounts[key] = users[key]
+	}
+	return accounts
+}
+
+// usersStatements returns the statements for the accounts, e.g. CREATE USER
+// 'a'@'%' IDENTIFIED BY 'x', 'b'@'%' IDENTIFIED BY 'y'.
+func usersStatements(verb string, users map[string]interface{}, keys []string, defaultAuthPlugin string) []string {
+	statements := []string{}
+	for start := 0; start < len(keys); start += usersBatchSize {
+		end := start + usersBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		accounts := []string{}
+		for _, key := range keys[start:end] {
+			account := parseAccountKey(key).SQLString()
+			if verb != "DROP USER" {
+				account += identifiedClause(users[key].(string), defaultAuthPlugin)
+			}
+			accounts = append(accounts, account)
+		}
+		statements = append(statements, fmt.Sprintf("%s %s", verb, strings.Join(accounts, ", ")))
+	}
+	return statements
+}
+
+func identifiedClause(password, defaultAuthPlugin string) string {
+	clause := ""
+	if defaultAuthPlugin != "" {
+		clause = " IDENTIFIED WITH " + quoteIdentifier(defaultAuthPlugin)
+		if password != "" {
+			clause += " BY " + quoteLiteral(password)
+		}
+	} else if password != "" {
+		clause = " IDENTIFIED BY " + quoteLiteral(password)
+	}
+	return clause
+}
+
+// parseAccountKey parses the keys of users, e.g. "jdoe@localhost", or "jdoe"
+// for host "%".
+func parseAccountKey(key string) UserOrRole {
+	if i := strings.LastIndex(key, "@"); i >= 0 {
+		return UserOrRole{Name: key[:i], Host: key[i+1:]}
+	}
+	return UserOrRole{Name: key, Host: "%"}
+}
+
+func sortedAccounts(users map[string]interface{}) []string {
+	keys := make([]string, 0, len(users))
+	for key := range users {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func usersId(users map[string]interface{}) string {
+	return hashSum(strings.Join(sortedAccounts(users), ","))
+}
+
+// ImportUsers imports the users of a comma-separated list of accounts, e.g.
+// "tenant_a,tenant_b@localhost". Their passwords can't be read, so they're
+// set by the next apply.
+func ImportUsers(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	users := map[string]interface{}{}
+	for _, key := range strings.Split(d.Id(), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			users[key] = ""
+		}
+	}
+	if _, errs := validateUsersMap(users, "users"); len(errs) > 0 {
+		return nil, fmt.Errorf("wrong ID format %s (expected USER@HOST,...): %v", d.Id(), errs[0])
+	}
+	setUsers(d, users)
+
+	if diags := ReadUsers(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("failed reading users: %v", diags[0].Summary)
+	}
+	existing := d.Get("users").(map[string]interface{})
+	for _, key := range sortedAccounts(users) {
+		if _, ok := existing[key]; !ok {
+			return nil, fmt.Errorf("user %s doesn't exist", key)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func validateUsersMap(val any, key string) (warns []string, errs []error) {
+	for _, account := range sortedAccounts(val.(map[string]interface{})) {
+		userOrRole := parseAccountKey(account)
+		if userOrRole.Name == "" {
+			errs = append(errs, fmt.Errorf("%q has an account without a user name: %q", key, account))
+			continue
+		}
+		accountKey := fmt.Sprintf("%s[%q]", key, account)
+		nameWarns, nameErrs := validateUserName(userOrRole.Name, accountKey)
+		hostWarns, hostErrs := validateHostPattern(userOrRole.Host, accountKey)
+		warns = append(append(warns, nameWarns...), hostWarns...)
+		errs = append(append(errs, nameErrs...), hostErrs...)
+	}
+	return
+}
@@ -0,0 +1,170 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccUsers_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUsersCheckDestroy("tf_tenant_a", "tf_tenant_b"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUsersConfig(`"tf_tenant_a" = "password-a"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_users.test", "users.%", "1"),
+					testAccAccountExists("tf_tenant_a", "%"),
+				),
+			},
+			{
+				Config: testAccUsersConfig(`"tf_tenant_a" = "password-c", "tf_tenant_b@localhost" = "password-b"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_users.test", "users.%", "2"),
+					testAccAccountExists("tf_tenant_a", "%"),
+					testAccAccountExists("tf_tenant_b", "localhost"),
+				),
+			},
+			{
+				Config: testAccUsersConfig(`"tf_tenant_b@localhost" = "password-b"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mysql_users.test", "users.%", "1"),
+					testAccUsersCheckDestroy("tf_tenant_a"),
+				),
+			},
+			{
+				// Passwords can't be read back.
+				ResourceName:            "mysql_users.test",
+				ImportState:             true,
+				ImportStateId:           "tf_tenant_b@localhost",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"users"},
+			},
+		},
+	})
+}
+
+func TestUsersStatements(t *testing.T) {
+	users := map[string]interface{}{"a": "x", "b@localhost": ""}
+	keys := sortedAccounts(users)
+
+	statements := usersStatements("CREATE USER", users, keys, "")
+	expected := []string{"CREATE USER 'a'@'%' IDENTIFIED BY 'x', 'b'@'localhost'"}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("expected %q, got %q", expected, statements)
+	}
+
+	statements = usersStatements("DROP USER", users, keys, "caching_sha2_password")
+	expected = []string{"DROP USER 'a'@'%', 'b'@'localhost'"}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("expected %q, got %q", expected, statements)
+	}
+
+	many := map[string]interface{}{}
+	for i := 0; i < usersBatchSize+1; i++ {
+		many[fmt.Sprintf("tenant%d", i)] = ""
+	}
+	if statements := usersStatements("DROP USER", many, sortedAccounts(many), ""); len(statements) != 2 {
+		t.Errorf("expected 2 batches, got %d", len(statements))
+	}
+}
+
+func TestChangeUsers(t *testing.T) {
+	users := map[string]interface{}{}
+	for i := 0; i < usersBatchSize+1; i++ {
+		users[fmt.Sprintf("tenant%03d", i)] = "secret"
+	}
+	keys := sortedAccounts(users)
+
+	// The second batch fails, which leaves the first one created.
+	fake := &fakeExecConn{errs: []error{nil, errors.New("Operation CREATE USER failed")}}
+	db := sql.OpenDB(&fakeConnector{conn: fake})
+	defer db.Close()
+	changed, err := changeUsers(context.Background(), db, &MySQLConfiguration{}, "CREATE USER", users, keys)
+	if err == nil {
+		t.Fatal("expected the second batch to fail")
+	}
+	if !reflect.DeepEqual(changed, keys[:usersBatchSize]) {
+		t.Errorf("expected the first %d users to be created, got %d", usersBatchSize, len(changed))
+	}
+
+	changed, err = changeUsers(context.Background(), db, &MySQLConfiguration{}, "DROP USER", users, keys)
+	if err != nil || !reflect.DeepEqual(changed, keys) {
+		t.Errorf("expected all users to be dropped, got %d: %v", len(changed), err)
+	}
+}
+
+func TestValidateUsersMap(t *testing.T) {
+	for _, tc := range []struct {
+		users  map[string]interface{}
+		errors int
+	}{
+		{map[string]interface{}{"tenant_a": "x", "tenant_b@10.0.0.%": "y"}, 0},
+		{map[string]interface{}{"@localhost": "x"}, 1},
+		{map[string]interface{}{"a_user_name_that_is_much_too_long_for_mysql@%": "x"}, 1},
+		{map[string]interface{}{"tenant@bad host": "x"}, 1},
+		{map[string]interface{}{"tenant@[::1]": "x", "other@10.0.0.0/33": "y"}, 2},
+	} {
+		if _, errs := validateUsersMap(tc.users, "users"); len(errs) != tc.errors {
+			t.Errorf("expected %d errors for %v, got %v", tc.errors, tc.users, errs)
+		}
+	}
+}
+
+func testAccUsersConfig(users string) string {
+	return fmt.Sprintf(`
+resource "mysql_users" "test" {
+  users = { %s }
+}
+`, users)
+}
+
+func testAccAccountExists(user, host string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM mysql.user WHERE User = ? AND Host = ?", user, host).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count != 1 {
+			return fmt.Errorf("user %s@%s doesn't exist", user, host)
+		}
+		return nil
+	}
+}
+
+func testAccUsersCheckDestroy(users ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		db, err := connectToMySQL(ctx, testAccProvider.Meta().(*MySQLConfiguration))
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			var count int
+			err = db.QueryRow("SELECT COUNT(*) FROM mysql.user WHERE User = ?", user).Scan(&count)
+			if err != nil {
+				return err
+			}
+			if count != 0 {
+				return fmt.Errorf("user %s still exists after destroy", user)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,323 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/proxy"
+)
+
+type SSHTunnel struct {
+	Host                 string
+	User                 string
+	PrivateKey           string
+	PrivateKeyPassphrase string
+	Password             string
+	UseAgent             bool
+	HostKey              string
+	JumpHost             string
+	JumpUser             string
+	JumpHostKey          string
+}
+
+func sshTunnelFromMap(raw map[string]interface{}) SSHTunnel {
+	tunnel := SSHTunnel{
+		Host:                 raw["host"].(string),
+		User:                 raw["user"].(string),
+		PrivateKey:           raw["private_key"].(string),
+		PrivateKeyPassphrase: raw["private_key_passphrase"].(string),
+		Password:             raw["password"].(string),
+		UseAgent:             raw["use_agent"].(bool),
+		HostKey:              raw["host_key"].(string),
+		JumpHost:             raw["jump_host"].(string),
+		JumpUser:             raw["jump_user"].(string),
+		JumpHostKey:          raw["jump_host_key"].(string),
+	}
+	if tunnel.JumpUser == "" {
+		tunnel.JumpUser = tunnel.User
+	}
+	return tunnel
+}
+
+// sshTunnelDialer dials MySQL connections through an SSH connection to the
+// bastion, which is opened on first use and re-opened if it breaks.
+type sshTunnelDialer struct {
+	tunnel  SSHTunnel
+	forward proxy.Dialer
+
+	mtx    sync.Mutex
+	client *ssh.Client
+	// jumpClient is the connection to the jump host that client goes through,
+	// if there's one.
+	jumpClient *ssh.Client
+	// agentConn is the connection to the SSH agent with use_agent, which
+	// client authenticated with.
+	agentConn net.Conn
+}
+
+// sshConnectTimeout limits connecting to the SSH hosts when the dial has no
+// deadline, i.e. without connect_timeout.
+const sshConnectTimeout = 30 * time.Second
+
+func newSSHTunnelDialer(tunnel SSHTunnel, forward proxy.Dialer) *sshTunnelDialer {
+	return &sshTunnelDialer{tunnel: tunnel, forward: forward}
+}
+
+var (
+	sshTunnelDialersMtx sync.Mutex
+	sshTunnelDialers    = map[string]*sshTunnelDialer{}
+)
+
+// registerSSHTunnelDialer registers a dialer through the tunnel with the
+// MySQL driver and returns its network name. The name is derived from the
+// tunnel settings and the proxy, so configurations with the same settings
+// share the SSH connection.
+func registerSSHTunnelDialer(tunnel SSHTunnel, proxyURL string, forward proxy.Dialer) string {
+	network := "ssh+" + hashSum(fmt.Sprintf("%#v %s", tunnel, proxyURL))[:16]
+
+	sshTunnelDialersMtx.Lock()
+	defer sshTunnelDialersMtx.Unlock()
+	if _, ok := sshTunnelDialers[network]; ok {
+		return network
+	}
+
+	dialer := newSSHTunnelDialer(tunnel, forward)
+	mysql.RegisterDialContext(network, func(ctx context.Context, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp", addr)
+	})
+	sshTunnelDialers[network] = dialer
+	return network
+}
+
+func (t *sshTunnelDialer) Dial(network, addr string) (net.Conn, error) {
+	return t.DialContext(context.Background(), network, addr)
+}
+
+// DialContext dials the address through the tunnel. The context, which has
+// connect_timeout as deadline, limits connecting to the SSH hosts as well.
+func (t *sshTunnelDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := t.sshClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.DialContext(ctx, network, addr)
+	if err == nil || ctx.Err() != nil {
+		return conn, err
+	}
+
+	// The SSH connection may have been closed meanwhile, so retry once with
+	// a new one.
+	log.Printf("[WARN] Dialing %s through SSH tunnel failed, reconnecting: %v", addr, err)
+	t.resetClient(client)
+	client, err = t.sshClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DialContext(ctx, network, addr)
+}
+
+func (t *sshTunnelDialer) sshClient(ctx context.Context) (*ssh.Client, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sshConnectTimeout)
+		defer cancel()
+	}
+
+	auth, agentConn, err := sshAuthMethods(t.tunnel)
+	if err != nil {
+		return nil, err
+	}
+
+	forward := t.forward
+	var jumpClient *ssh.Client
+	if t.tunnel.JumpHost != "" {
+		jumpClient, err = dialSSH(ctx, t.forward, sshAddress(t.tunnel.JumpHost), t.tunnel.JumpUser, t.tunnel.JumpHostKey, auth)
+		if err != nil {
+			closeAgent(agentConn)
+			return nil, fmt.Errorf("failed connecting to SSH jump host %s: %v", t.tunnel.JumpHost, err)
+		}
+		forward = jumpClient
+	}
+
+	client, err := dialSSH(ctx, forward, sshAddress(t.tunnel.Host), t.tunnel.User, t.tunnel.HostKey, auth)
+	if err != nil {
+		if jumpClient != nil {
+			jumpClient.Close()
+		}
+		closeAgent(agentConn)
+		return nil, fmt.Errorf("failed connecting to SSH host %s: %v", t.tunnel.Host, err)
+	}
+
+	t.client = client
+	t.jumpClient = jumpClient
+	t.agentConn = agentConn
+	return client, nil
+}
+
+func (t *sshTunnelDialer) resetClient(broken *ssh.Client) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.client == broken {
+		t.client.Close()
+		t.client = nil
+		if t.jumpClient != nil {
+			t.jumpClient.Close()
+			t.jumpClient = nil
+		}
+		closeAgent(t.agentConn)
+		t.agentConn = nil
+	}
+}
+
+// dialSSH connects to the SSH host through forward. The context limits the
+// handshake too, but not the connection.
+func dialSSH(ctx context.Context, forward proxy.Dialer, addr string, user string, hostKey string, auth []ssh.AuthMethod) (*ssh.Client, error) {
+	hostKeyCallback, err := sshHostKeyCallback(hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	if contextDialer, ok := forward.(proxy.ContextDialer); ok {
+		conn, err = contextDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = forward.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Canceling the context closes the connection, which interrupts the
+	// handshake; the deadline covers connections through the jump host.
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if !stop() {
+		if err == nil {
+			sshConn.Close()
+		}
+		return nil, fmt.Errorf("connecting canceled: %v", ctx.Err())
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+func closeAgent(agentConn net.Conn) {
+	if agentConn != nil {
+		agentConn.Close()
+	}
+}
+
+// sshAuthMethods returns the methods to authenticate with, and with use_agent
+// the connection to the agent, which has to be closed with the SSH connection.
+func sshAuthMethods(tunnel SSHTunnel) ([]ssh.AuthMethod, net.Conn, error) {
+	var auth []ssh.AuthMethod
+
+	if tunnel.PrivateKey != "" {
+		var pem []byte
+		if strings.HasPrefix(tunnel.PrivateKey, "-----BEGIN") {
+			pem = []byte(tunnel.PrivateKey)
+		} else {
+			var err error
+			pem, err = os.ReadFile(tunnel.PrivateKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read SSH private key: %v", err)
+			}
+		}
+
+		var signer ssh.Signer
+		var err error
+		if tunnel.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, []byte(tunnel.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(pem)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse SSH private key: %v", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+
+	var agentConn net.Conn
+	if tunnel.UseAgent {
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			return nil, nil, fmt.Errorf("use_agent is set, but SSH_AUTH_SOCK is not")
+		}
+		var err error
+		agentConn, err = net.Dial("unix", socket)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed connecting to SSH agent: %v", err)
+		}
+		auth = append(auth, ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers))
+	}
+
+	if tunnel.Password != "" {
+		auth = append(auth, ssh.Password(tunnel.Password))
+	}
+
+	if len(auth) == 0 {
+		return nil, nil, fmt.Errorf("ssh_tunnel requires private_key, use_agent or password")
+	}
+	return auth, agentConn, nil
+}
+
+// sshHostKeyCallback checks the host key against the given public key, or
+// against ~/.ssh/known_hosts without one. Unknown hosts are never accepted.
+func sshHostKeyCallback(hostKey string) (ssh.HostKeyCallback, error) {
+	if hostKey != "" {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SSH host key: %v", err)
+		}
+		return ssh.FixedHostKey(publicKey), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("no SSH host key given and finding known_hosts failed: %v", err)
+	}
+	callback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, fmt.Errorf("no SSH host key given and reading known_hosts failed: %v", err)
+	}
+	return callback, nil
+}
+
+// sshAddress adds the default SSH port if the host has none.
+func sshAddress(host string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, "22")
+}
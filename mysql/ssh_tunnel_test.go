@@ -0,0 +1,370 @@
+package mysql
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/net/proxy"
+)
+
+// testSSHServer is an in-process SSH server that accepts password logins and
+// forwards direct-tcpip channels, as a bastion does.
+type testSSHServer struct {
+	listener net.Listener
+	hostKey  string
+
+	mtx   sync.Mutex
+	conns []*ssh.ServerConn
+}
+
+func newTestSSHServer(t *testing.T) *testSSHServer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating host key failed: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("making host key signer failed: %v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "tunnel" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("wrong password for %s", conn.User())
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening failed: %v", err)
+	}
+	s := &testSSHServer{
+		listener: listener,
+		hostKey:  string(ssh.MarshalAuthorizedKey(signer.PublicKey())),
+	}
+	t.Cleanup(s.close)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, config)
+		}
+	}()
+	return s
+}
+
+func (s *testSSHServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	s.mtx.Lock()
+	s.conns = append(s.conns, sshConn)
+	s.mtx.Unlock()
+
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "only direct-tcpip is supported")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelReqs, err := newChannel.Accept()
+		if err != nil {
+			targetConn.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+		go func() {
+			io.Copy(channel, targetConn)
+			channel.Close()
+		}()
+		go func() {
+			io.Copy(targetConn, channel)
+			targetConn.Close()
+		}()
+	}
+}
+
+func (s *testSSHServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+// dropConnections closes all SSH connections, as a restarted bastion would.
+func (s *testSSHServer) dropConnections() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// openConnections counts the SSH connections that aren't closed within a
+// moment.
+func (s *testSSHServer) openConnections() int {
+	s.mtx.Lock()
+	conns := s.conns
+	s.mtx.Unlock()
+
+	open := 0
+	for _, conn := range conns {
+		closed := make(chan struct{})
+		go func() {
+			conn.Wait()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(200 * time.Millisecond):
+			open++
+		}
+	}
+	return open
+}
+
+func (s *testSSHServer) close() {
+	s.listener.Close()
+	s.dropConnections()
+}
+
+// newTestEchoServer returns the address of a TCP server echoing what it reads.
+func newTestEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening failed: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func checkEcho(t *testing.T, dialer proxy.Dialer, addr string) {
+	t.Helper()
+
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dialing %s through the tunnel failed: %v", addr, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("writing through the tunnel failed: %v", err)
+	}
+	reply := make([]byte, 4)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		t.Fatalf("reading through the tunnel failed: %v", err)
+	}
+	if string(reply) != "ping" {
+		t.Errorf("got %q through the tunnel, expected ping", reply)
+	}
+}
+
+func TestSSHTunnelDialer(t *testing.T) {
+	bastion := newTestSSHServer(t)
+	echo := newTestEchoServer(t)
+
+	dialer := newSSHTunnelDialer(SSHTunnel{
+		Host:     bastion.addr(),
+		User:     "tunnel",
+		Password: "secret",
+		HostKey:  bastion.hostKey,
+	}, proxy.Direct)
+
+	checkEcho(t, dialer, echo)
+	// Connections share the SSH connection.
+	checkEcho(t, dialer, echo)
+	if open := bastion.openConnections(); open != 1 {
+		t.Errorf("%d SSH connections are open, expected 1", open)
+	}
+
+	bastion.dropConnections()
+	checkEcho(t, dialer, echo)
+	if open := bastion.openConnections(); open != 1 {
+		t.Errorf("%d SSH connections are open after reconnecting, expected 1", open)
+	}
+}
+
+func TestSSHTunnelDialerJumpHost(t *testing.T) {
+	jumpHost := newTestSSHServer(t)
+	bastion := newTestSSHServer(t)
+	echo := newTestEchoServer(t)
+
+	dialer := newSSHTunnelDialer(SSHTunnel{
+		Host:        bastion.addr(),
+		User:        "tunnel",
+		Password:    "secret",
+		HostKey:     bastion.hostKey,
+		JumpHost:    jumpHost.addr(),
+		JumpUser:    "tunnel",
+		JumpHostKey: jumpHost.hostKey,
+	}, proxy.Direct)
+
+	checkEcho(t, dialer, echo)
+
+	bastion.dropConnections()
+	checkEcho(t, dialer, echo)
+	// The connection to the jump host is replaced along with the one it
+	// carried.
+	if open := jumpHost.openConnections(); open != 1 {
+		t.Errorf("%d SSH connections to the jump host are open after reconnecting, expected 1", open)
+	}
+}
+
+func TestSSHTunnelDialerWrongHostKey(t *testing.T) {
+	bastion := newTestSSHServer(t)
+	other := newTestSSHServer(t)
+
+	dialer := newSSHTunnelDialer(SSHTunnel{
+		Host:     bastion.addr(),
+		User:     "tunnel",
+		Password: "secret",
+		HostKey:  other.hostKey,
+	}, proxy.Direct)
+
+	if conn, err := dialer.Dial("tcp", newTestEchoServer(t)); err == nil {
+		conn.Close()
+		t.Errorf("dialing through a bastion with an unexpected host key succeeded")
+	}
+}
+
+func TestSSHTunnelDialerTimeout(t *testing.T) {
+	// A host that accepts connections but never answers the handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening failed: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(io.Discard, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	dialer := newSSHTunnelDialer(SSHTunnel{
+		Host:     listener.Addr().String(),
+		User:     "tunnel",
+		Password: "secret",
+	}, proxy.Direct)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if conn, err := dialer.DialContext(ctx, "tcp", newTestEchoServer(t)); err == nil {
+		conn.Close()
+		t.Fatal("dialing through an unresponsive bastion succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("dialing took %v, expected it to stop at the deadline", elapsed)
+	}
+}
+
+func TestSSHTunnelDialerAgent(t *testing.T) {
+	bastion := newTestSSHServer(t)
+	echo := newTestEchoServer(t)
+
+	// An agent without keys, so the password logs in.
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listening failed: %v", err)
+	}
+	defer listener.Close()
+	closed := make(chan struct{}, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				agent.ServeAgent(agent.NewKeyring(), conn)
+				closed <- struct{}{}
+			}()
+		}
+	}()
+	t.Setenv("SSH_AUTH_SOCK", socket)
+
+	dialer := newSSHTunnelDialer(SSHTunnel{
+		Host:     bastion.addr(),
+		User:     "tunnel",
+		Password: "secret",
+		UseAgent: true,
+		HostKey:  bastion.hostKey,
+	}, proxy.Direct)
+	checkEcho(t, dialer, echo)
+
+	// Tearing down the SSH connection closes the one to the agent.
+	dialer.resetClient(dialer.client)
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("expected the connection to the agent to be closed")
+	}
+}
+
+func TestRegisterSSHTunnelDialer(t *testing.T) {
+	first := registerSSHTunnelDialer(SSHTunnel{Host: "bastion-a", User: "tunnel"}, "", proxy.Direct)
+	second := registerSSHTunnelDialer(SSHTunnel{Host: "bastion-b", User: "tunnel"}, "", proxy.Direct)
+	again := registerSSHTunnelDialer(SSHTunnel{Host: "bastion-a", User: "tunnel"}, "", proxy.Direct)
+
+	if first == second {
+		t.Errorf("different tunnels share the network %s", first)
+	}
+	if first != again {
+		t.Errorf("the same tunnel got the networks %s and %s", first, again)
+	}
+	if first == "tcp" {
+		t.Errorf("the tunnel replaced the tcp network")
+	}
+}
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withStateUpgrade upgrades states of schema version 0, e.g. the ones of
+// earlier releases or of other forks of this provider moved over with
+// terraform state replace-provider. Those may lack attributes added since,
+// and a missing attribute with a default reads as its zero value, which for
+// e.g. the table of grants would replace them. Their IDs may have other
+// formats too, so the ID is replaced by the one stateID builds from the
+// attributes.
+func withStateUpgrade(r *schema.Resource, stateID func(d *schema.ResourceData) (string, error)) *schema.Resource {
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    r.CoreConfigSchema().ImpliedType(),
+			Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+				rawState = upgradeStateDefaults(r.Schema, rawState)
+
+				d, err := stateData(r, rawState)
+				if err != nil {
+					return nil, err
+				}
+				id, err := stateID(d)
+				if err != nil {
+					return nil, fmt.Errorf("failed building the ID of state %v: %v", rawState["id"], err)
+				}
+				rawState["id"] = id
+				return rawState, nil
+			},
+		},
+	}
+	return r
+}
+
+// upgradeStateDefaults sets the missing attributes of the state to their
+// defaults. Attributes the schema doesn't know are removed by the SDK.
+func upgradeStateDefaults(attributes map[string]*schema.Schema, rawState map[string]interface{}) map[string]interface{} {
+	if rawState == nil {
+		rawState = map[string]interface{}{}
+	}
+	for name, attribute := range attributes {
+		if attribute.Default == nil {
+			continue
+		}
+		if value, ok := rawState[name]; !ok || value == nil {
+			rawState[name] = attribute.Default
+		}
+	}
+	return rawState
+}
+
+// stateData returns the raw state as resource data, so that IDs are built by
+// the same code as the resources' own. Attributes of other providers that the
+// schema doesn't know are left out.
+func stateData(r *schema.Resource, rawState map[string]interface{}) (*schema.ResourceData, error) {
+	stateType := r.CoreConfigSchema().ImpliedType()
+	known := map[string]interface{}{}
+	for name, value := range rawState {
+		if stateType.HasAttribute(name) {
+			known[name] = value
+		}
+	}
+
+	js, err := json.Marshal(known)
+	if err != nil {
+		return nil, err
+	}
+	value, err := ctyjson.Unmarshal(js, stateType)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading state: %v", err)
+	}
+	state, err := r.ShimInstanceStateFromValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading state: %v", err)
+	}
+	return r.Data(state), nil
+}
+
+// Builders of the IDs the resources set on create.
+
+func databaseStateID(d *schema.ResourceData) (string, error) {
+	return d.Get("name").(string), nil
+}
+
+func roleStateID(d *schema.ResourceData) (string, error) {
+	return roleFromData(d).IDString(), nil
+}
+
+func userStateID(d *schema.ResourceData) (string, error) {
+	return fmt.Sprintf("%s@%s", d.Get("user").(string), d.Get("host").(string)), nil
+}
+
+// grantStateID replaces e.g. the user@host:database IDs of the winebarrel and
+// HashiCorp providers, which have no table.
+func grantStateID(d *schema.ResourceData) (string, error) {
+	grant, diags := parseResourceFromData(d)
+	if diags.HasError() {
+		return "", fmt.Errorf("%v", diags[0].Summary)
+	}
+	return grant.GetId(), nil
+}
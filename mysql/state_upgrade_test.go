@@ -0,0 +1,181 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUpgradeStateDefaults(t *testing.T) {
+	// A grant of an early release, without table and tls_option.
+	rawState := map[string]interface{}{
+		"id":         "jdoe@example.com:`app`",
+		"user":       "jdoe",
+		"host":       "example.com",
+		"database":   "app",
+		"privileges": []interface{}{"SELECT"},
+		"grant":      nil,
+	}
+
+	resource := withStateUpgrade(resourceGrant(), grantStateID)
+	if resource.SchemaVersion != 1 || len(resource.StateUpgraders) != 1 {
+		t.Fatalf("expected an upgrader of schema version 0, got %v", resource.StateUpgraders)
+	}
+	upgraded, err := resource.StateUpgraders[0].Upgrade(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("upgrading state failed: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":         "jdoe@example.com:`app`:*",
+		"user":       "jdoe",
+		"host":       "example.com",
+		"database":   "app",
+		"table":      "*",
+		"privileges": []interface{}{"SELECT"},
+		"grant":      false,
+		"tls_option": "NONE",
+	}
+	if !reflect.DeepEqual(upgraded, expected) {
+		t.Errorf("expected %v, got %v", expected, upgraded)
+	}
+}
+
+// TestUpgradeForeignStates upgrades the attributes of resources in states of
+// other forks of this provider, e.g. winebarrel/mysql, as they are in
+// terraform.tfstate.
+func TestUpgradeForeignStates(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		resource   string
+		attributes string
+		expectedID string
+		expected   map[string]interface{}
+	}{
+		{
+			name:     "database",
+			resource: "mysql_database",
+			attributes: `{
+				"default_character_set": "utf8mb4",
+				"default_collation": "utf8mb4_general_ci",
+				"id": "app",
+				"name": "app"
+			}`,
+			expectedID: "app",
+			expected:   map[string]interface{}{"default_collation": "utf8mb4_general_ci"},
+		},
+		{
+			name:     "grant with a user@host:database ID",
+			resource: "mysql_grant",
+			attributes: `{
+				"database": "app",
+				"grant": false,
+				"host": "10.0.0.%",
+				"id": "jdoe@10.0.0.%:app",
+				"privileges": ["SELECT", "UPDATE"],
+				"role": null,
+				"roles": null,
+				"table": "*",
+				"tls_option": "NONE",
+				"user": "jdoe"
+			}`,
+			expectedID: "jdoe@10.0.0.%:`app`:*",
+			expected:   map[string]interface{}{"user": "jdoe", "host": "10.0.0.%"},
+		},
+		{
+			name:     "grant of roles with a user@host: ID",
+			resource: "mysql_grant",
+			attributes: `{
+				"database": "",
+				"grant": false,
+				"host": "%",
+				"id": "jdoe@%:",
+				"privileges": null,
+				"role": null,
+				"roles": ["developers"],
+				"table": "*",
+				"tls_option": "NONE",
+				"user": "jdoe"
+			}`,
+			expectedID: "jdoe@%",
+			expected:   map[string]interface{}{"roles": []interface{}{"developers"}},
+		},
+		{
+			name:     "routine grant to a role with an unknown attribute",
+			resource: "mysql_grant",
+			attributes: `{
+				"database": "PROCEDURE app.refresh",
+				"grant": true,
+				"host": "localhost",
+				"id": "developers:PROCEDURE app.refresh",
+				"privileges": ["EXECUTE"],
+				"role": "developers",
+				"roles": null,
+				"some_other_fork_attribute": "x",
+				"table": "*",
+				"tls_option": "NONE",
+				"user": null
+			}`,
+			expectedID: "developers:`app`:`refresh`",
+			expected:   map[string]interface{}{"role": "developers", "database": "PROCEDURE app.refresh"},
+		},
+		{
+			name:     "role without host",
+			resource: "mysql_role",
+			attributes: `{
+				"id": "developers",
+				"name": "developers"
+			}`,
+			expectedID: "developers",
+			expected:   map[string]interface{}{"host": "%"},
+		},
+		{
+			name:     "user with a hashed plaintext_password",
+			resource: "mysql_user",
+			attributes: `{
+				"auth_plugin": null,
+				"host": "%",
+				"id": "jdoe@%",
+				"password": null,
+				"plaintext_password": "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+				"tls_option": "NONE",
+				"user": "jdoe"
+			}`,
+			expectedID: "jdoe@%",
+			expected:   map[string]interface{}{"plaintext_password": "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"},
+		},
+		{
+			name:     "user without host",
+			resource: "mysql_user",
+			attributes: `{
+				"auth_plugin": "caching_sha2_password",
+				"id": "app",
+				"user": "app"
+			}`,
+			expectedID: "app@localhost",
+			expected:   map[string]interface{}{"host": "localhost", "tls_option": "NONE"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var rawState map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.attributes), &rawState); err != nil {
+				t.Fatalf("parsing fixture failed: %v", err)
+			}
+
+			resource := Provider().ResourcesMap[tc.resource]
+			upgraded, err := resource.StateUpgraders[0].Upgrade(context.Background(), rawState, nil)
+			if err != nil {
+				t.Fatalf("upgrading state failed: %v", err)
+			}
+			if upgraded["id"] != tc.expectedID {
+				t.Errorf("expected ID %q, got %q", tc.expectedID, upgraded["id"])
+			}
+			for name, value := range tc.expected {
+				if !reflect.DeepEqual(upgraded[name], value) {
+					t.Errorf("expected %s %v, got %v", name, value, upgraded[name])
+				}
+			}
+		})
+	}
+}
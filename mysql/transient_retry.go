@@ -0,0 +1,287 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"regexp"
+	"syscall"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	lockDeadlockErrCode    = 1213
+	lockWaitTimeoutErrCode = 1205
+)
+
+// isTransientLockError tells whether the statement failed only because of
+// other transactions and can simply be run again.
+func isTransientLockError(err error) bool {
+	var me *mysql.MySQLError
+	if !errors.As(err, &me) {
+		return false
+	}
+	return me.Number == lockDeadlockErrCode || me.Number == lockWaitTimeoutErrCode
+}
+
+// isLostConnectionError tells whether the connection broke while running the
+// statement, so retrying needs a new one.
+func isLostConnectionError(err error) bool {
+	return errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
+
+// idempotentStatementRegex matches statements that can run twice without
+// changing the outcome.
+var idempotentStatementRegex = regexp.MustCompile(`(?is)^\s*(SELECT|SHOW|EXPLAIN|DESCRIBE|DESC|SET|GRANT)\b|^\s*(CREATE|DROP)(\s+\w+)+?\s+IF\s+(NOT\s+)?EXISTS\b`)
+
+// retryConn retries statements failing with deadlocks, lock wait timeouts or
+// lost connections according to the policy. Statements in transactions aren't
+// retried, as the server may have rolled back the whole transaction. Lost
+// connections are replaced with ones from connect.
+type retryConn struct {
+	conn    driver.Conn
+	connect func(ctx context.Context) (driver.Conn, error)
+	policy  RetryPolicy
+	metrics *queryMetrics
+	broken  bool
+	inTx    bool
+}
+
+// retry runs f until it succeeds or the policy gives up. Lost connections are
+// replaced before running f again, but only if the driver didn't send the
+// query yet, or running it again does no harm. Otherwise the server may have
+// run it already.
+func (c *retryConn) retry(ctx context.Context, query string, f func(conn driver.Conn) error) error {
+	if c.inTx {
+		return f(c.conn)
+	}
+
+	var lastErr error
+	attempts := 0
+	replayable := true
+	err := c.policy.Retry(ctx, func() (bool, error) {
+		if attempts++; attempts > 1 {
+			c.metrics.retry()
+		}
+		if c.broken {
+			conn, err := c.connect(ctx)
+			if err != nil {
+				lastErr = err
+				return mysqlErrorNumber(err) == 0 && ctx.Err() == nil, err
+			}
+			c.conn.Close()
+			c.conn = conn
+			c.broken = false
+		}
+
+		lastErr = f(c.conn)
+		if lastErr == nil || lastErr == driver.ErrSkip {
+			return false, lastErr
+		}
+		if isLostConnectionError(lastErr) {
+			c.broken = true
+			// The driver returns ErrBadConn only if nothing was sent.
+			replayable = errors.Is(lastErr, driver.ErrBadConn) || idempotentStatementRegex.MatchString(query)
+			return replayable && c.connect != nil && ctx.Err() == nil, lastErr
+		}
+		return isTransientLockError(lastErr), lastErr
+	})
+	if err != nil && err != lastErr {
+		log.Printf("[WARN] %v", err)
+	}
+	if c.broken && replayable && ctx.Err() == nil {
+		// Let database/sql drop the connection and try another one.
+		return driver.ErrBadConn
+	}
+	return lastErr
+}
+
+func (c *retryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, ok := c.conn.(driver.ExecerContext); !ok {
+		return nil, driver.ErrSkip
+	}
+
+	var result driver.Result
+	err := c.retry(ctx, query, func(conn driver.Conn) error {
+		var err error
+		result, err = conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *retryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if _, ok := c.conn.(driver.QueryerContext); !ok {
+		return nil, driver.ErrSkip
+	}
+
+	var rows driver.Rows
+	err := c.retry(ctx, query, func(conn driver.Conn) error {
+		var err error
+		rows, err = conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (c *retryConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *retryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &retryStmt{Stmt: stmt, conn: c}, nil
+}
+
+func (c *retryConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *retryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var tx driver.Tx
+	var err error
+	if beginner, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.inTx = true
+	return &retryTx{Tx: tx, conn: c}, nil
+}
+
+func (c *retryConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *retryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *retryConn) ResetSession(ctx context.Context) error {
+	if c.broken {
+		return driver.ErrBadConn
+	}
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *retryConn) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return !c.broken && validator.IsValid()
+	}
+	return !c.broken
+}
+
+func (c *retryConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// retryStmt retries prepared statements on deadlocks and lock wait timeouts.
+// Lost connections are left to database/sql, which prepares the statement
+// again on another connection.
+type retryStmt struct {
+	driver.Stmt
+	conn *retryConn
+}
+
+func (s *retryStmt) retry(ctx context.Context, f func() error) error {
+	if s.conn.inTx {
+		return f()
+	}
+
+	var lastErr error
+	attempts := 0
+	err := s.conn.policy.Retry(ctx, func() (bool, error) {
+		if attempts++; attempts > 1 {
+			s.conn.metrics.retry()
+		}
+		lastErr = f()
+		return isTransientLockError(lastErr), lastErr
+	})
+	if err != nil && err != lastErr {
+		log.Printf("[WARN] %v", err)
+	}
+	return lastErr
+}
+
+func (s *retryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, errors.New("statement doesn't support ExecContext")
+	}
+
+	var result driver.Result
+	err := s.retry(ctx, func() error {
+		var err error
+		result, err = execer.ExecContext(ctx, args)
+		return err
+	})
+	return result, err
+}
+
+func (s *retryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, errors.New("statement doesn't support QueryContext")
+	}
+
+	var rows driver.Rows
+	err := s.retry(ctx, func() error {
+		var err error
+		rows, err = queryer.QueryContext(ctx, args)
+		return err
+	})
+	return rows, err
+}
+
+func (s *retryStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return s.conn.CheckNamedValue(nv)
+}
+
+// retryTx ends the transaction of a retryConn.
+type retryTx struct {
+	driver.Tx
+	conn *retryConn
+}
+
+func (t *retryTx) Commit() error {
+	t.conn.inTx = false
+	return t.Tx.Commit()
+}
+
+func (t *retryTx) Rollback() error {
+	t.conn.inTx = false
+	return t.Tx.Rollback()
+}
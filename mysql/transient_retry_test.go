@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// fakeExecConn fails the first statements with the given errors.
+type fakeExecConn struct {
+	driver.Conn
+	errs  []error
+	execs int
+}
+
+func (c *fakeExecConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.execs++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeExecConn) Close() error {
+	return nil
+}
+
+func TestRetryConn(t *testing.T) {
+	deadlock := &mysql.MySQLError{Number: lockDeadlockErrCode, Message: "Deadlock found"}
+	lockWait := &mysql.MySQLError{Number: lockWaitTimeoutErrCode, Message: "Lock wait timeout exceeded"}
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	policy := RetryPolicy{MaxRetries: 2, Interval: time.Millisecond}
+
+	for _, tc := range []struct {
+		errs          []error
+		inTx          bool
+		expectedErr   error
+		expectedExecs int
+	}{
+		{nil, false, nil, 1},
+		{[]error{deadlock, lockWait}, false, nil, 3},
+		{[]error{deadlock, deadlock, deadlock}, false, deadlock, 3},
+		{[]error{duplicate}, false, duplicate, 1},
+		{[]error{deadlock}, true, deadlock, 1},
+		{[]error{mysql.ErrInvalidConn}, false, driver.ErrBadConn, 1},
+	} {
+		t.Run(fmt.Sprint(tc.errs, tc.inTx), func(t *testing.T) {
+			fake := &fakeExecConn{errs: tc.errs}
+			conn := &retryConn{conn: fake, policy: policy, inTx: tc.inTx}
+
+			_, err := conn.ExecContext(context.Background(), "GRANT SELECT ON *.* TO 'jdoe'@'%'", nil)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if fake.execs != tc.expectedExecs {
+				t.Errorf("expected %d executions, got %d", tc.expectedExecs, fake.execs)
+			}
+		})
+	}
+}
+
+func TestRetryConnLostConnection(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, Interval: time.Millisecond}
+
+	for _, tc := range []struct {
+		query         string
+		err           error
+		expectedErr   error
+		expectedExecs int
+	}{
+		// The statement may have run before the connection broke.
+		{"INSERT INTO app.t VALUES (1)", mysql.ErrInvalidConn, mysql.ErrInvalidConn, 1},
+		{"CREATE USER 'jdoe'@'%'", syscall.ECONNRESET, syscall.ECONNRESET, 1},
+		{"DROP USER 'jdoe'@'%'", syscall.EPIPE, syscall.EPIPE, 1},
+		// The driver didn't send it.
+		{"INSERT INTO app.t VALUES (1)", driver.ErrBadConn, nil, 2},
+		// Running it twice does no harm.
+		{"GRANT SELECT ON *.* TO 'jdoe'@'%'", mysql.ErrInvalidConn, nil, 2},
+		{"CREATE USER IF NOT EXISTS 'jdoe'@'%'", syscall.ECONNRESET, nil, 2},
+		{"SET GLOBAL max_connections = 100", syscall.EPIPE, nil, 2},
+	} {
+		t.Run(fmt.Sprint(tc.query, tc.err), func(t *testing.T) {
+			fake := &fakeExecConn{errs: []error{tc.err}}
+			reconnected := &fakeExecConn{}
+			conn := &retryConn{conn: fake, policy: policy, connect: func(ctx context.Context) (driver.Conn, error) {
+				return reconnected, nil
+			}}
+
+			_, err := conn.ExecContext(context.Background(), tc.query, nil)
+			if !errors.Is(err, tc.expectedErr) || (tc.expectedErr == nil && err != nil) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if errors.Is(tc.expectedErr, tc.err) && errors.Is(err, driver.ErrBadConn) {
+				t.Errorf("returned ErrBadConn, which makes database/sql run the statement again")
+			}
+			if execs := fake.execs + reconnected.execs; execs != tc.expectedExecs {
+				t.Errorf("expected %d executions, got %d", tc.expectedExecs, execs)
+			}
+			if !conn.IsValid() && tc.expectedErr == nil {
+				t.Errorf("the connection wasn't replaced")
+			}
+		})
+	}
+}
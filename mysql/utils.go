@@ -4,9 +4,17 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-version"
 )
@@ -45,6 +53,100 @@ func (km *KeyedMutex) Unlock(key string) {
 	lock.Unlock()
 }
 
+// privilegeChangeMutex serializes statements that change accounts and their
+// privileges with serialize_privilege_changes.
+var privilegeChangeMutex sync.Mutex
+
+// lockPrivilegeChanges waits for other privilege changes to finish if the
+// provider serializes them, and returns the function to release the lock.
+// Resources in dry run change nothing, so they don't wait.
+func lockPrivilegeChanges(ctx context.Context, meta interface{}) func() {
+	if !meta.(*MySQLConfiguration).SerializePrivilegeChanges || inDryRun(ctx) {
+		return func() {}
+	}
+	privilegeChangeMutex.Lock()
+	return privilegeChangeMutex.Unlock
+}
+
+// grantLockName returns the name of the advisory lock of the grantee. MySQL
+// limits lock names to 64 characters.
+func grantLockName(userOrRole UserOrRole) string {
+	name := "tf-mysql-" + userOrRole.IDString()
+	if len(name) > 64 {
+		name = "tf-mysql-" + hashSum(userOrRole.IDString())[:55]
+	}
+	return name
+}
+
+// lockGrantee takes the advisory lock of the grantee if the provider is
+// configured with grant_lock_timeout_sec, so that other Terraform runs changing
+// its grants wait for this one. It returns the function to release the lock.
+func lockGrantee(ctx context.Context, db *sql.DB, meta interface{}, userOrRole UserOrRole) (func(), error) {
+	timeout := meta.(*MySQLConfiguration).GrantLockTimeout
+	if timeout <= 0 {
+		return func() {}, nil
+	}
+
+	// Advisory locks belong to sessions, so the lock keeps its own connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := grantLockName(userOrRole)
+	stmtSQL := "SELECT GET_LOCK(?, ?)"
+	logStatement(ctx, stmtSQL, name, timeout.Seconds())
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, stmtSQL, name, timeout.Seconds()).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed getting lock %s: %w", name, err)
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		conn.Close()
+		return nil, fmt.Errorf("timed out after %s waiting for lock %s held by another session", timeout, name)
+	}
+
+	return func() {
+		// Release even if ctx is done, as the pool would keep the lock. The
+		// values of ctx are kept, so dry run stubs the release as well.
+		stmtSQL := "SELECT RELEASE_LOCK(?)"
+		logStatement(ctx, stmtSQL, name)
+		var released sql.NullInt64
+		if err := conn.QueryRowContext(context.WithoutCancel(ctx), stmtSQL, name).Scan(&released); err != nil {
+			log.Printf("[WARN] Failed releasing lock %s, closing its connection: %v", name, err)
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}, nil
+}
+
+// getGrantsCacheFromMeta returns the cache of SHOW GRANTS rows shared by the
+// resources of the provider.
+func getGrantsCacheFromMeta(meta interface{}) *grantsCache {
+	return meta.(*MySQLConfiguration).GrantsCache
+}
+
+// invalidateGrants drops the cached grants of the grantee after its grants
+// changed. They didn't in dry run, so they're kept.
+func invalidateGrants(ctx context.Context, meta interface{}, userOrRole UserOrRole) {
+	if inDryRun(ctx) {
+		return
+	}
+	getGrantsCacheFromMeta(meta).invalidate(userOrRole)
+}
+
+// flushPrivileges reloads the grant tables after accounts or privileges were
+// changed, if the provider is configured with flush_privileges.
+func flushPrivileges(ctx context.Context, db *sql.DB, meta interface{}) error {
+	if !meta.(*MySQLConfiguration).FlushPrivileges {
+		return nil
+	}
+	stmtSQL := "FLUSH PRIVILEGES"
+	logStatement(ctx, stmtSQL)
+	_, err := db.ExecContext(ctx, stmtSQL)
+	return err
+}
+
 func hashSum(contents interface{}) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(contents.(string))))
 }
@@ -69,3 +171,413 @@ func getVersionFromMeta(ctx context.Context, meta interface{}) *version.Version
 
 	return oneConnection.Version
 }
+
+func getFlavorFromMeta(ctx context.Context, meta interface{}) serverFlavor {
+	mysqlConf := meta.(*MySQLConfiguration)
+	oneConnection, err := connectToMySQLInternal(ctx, mysqlConf)
+	if err != nil {
+		log.Panicf("getting DB got us error: %v", err)
+	}
+
+	return oneConnection.Flavor
+}
+
+// checkFlavorUnsupported fails if the server is of the flavor, for features
+// that it doesn't have even though its version is new enough.
+func checkFlavorUnsupported(ctx context.Context, meta interface{}, flavor serverFlavor, feature string) error {
+	if getFlavorFromMeta(ctx, meta) == flavor {
+		return fmt.Errorf("%s are not supported on %s", feature, flavor)
+	}
+	return nil
+}
+
+func getAnsiQuotesFromMeta(ctx context.Context, meta interface{}) bool {
+	mysqlConf := meta.(*MySQLConfiguration)
+	oneConnection, err := connectToMySQLInternal(ctx, mysqlConf)
+	if err != nil {
+		log.Panicf("getting DB got us error: %v", err)
+	}
+
+	return oneConnection.AnsiQuotes
+}
+
+// backtickAnsiIdentifiers rewrites identifiers quoted with double quotes, as
+// servers with ANSI_QUOTES print them, to backtick quotes. String literals
+// and backtick-quoted identifiers are kept as they are.
+func backtickAnsiIdentifiers(stmt string) string {
+	var out strings.Builder
+	for i := 0; i < len(stmt); i++ {
+		c := stmt[i]
+		switch c {
+		case '\'', '`':
+			// Copy the quoted part, including doubled and escaped quotes.
+			out.WriteByte(c)
+			for i++; i < len(stmt); i++ {
+				out.WriteByte(stmt[i])
+				if c == '\'' && stmt[i] == '\\' && i+1 < len(stmt) {
+					i++
+					out.WriteByte(stmt[i])
+				} else if stmt[i] == c {
+					if i+1 < len(stmt) && stmt[i+1] == c {
+						i++
+						out.WriteByte(c)
+					} else {
+						break
+					}
+				}
+			}
+		case '"':
+			out.WriteByte('`')
+			for i++; i < len(stmt); i++ {
+				if stmt[i] == '"' {
+					if i+1 < len(stmt) && stmt[i+1] == '"' {
+						i++
+						out.WriteByte('"')
+						continue
+					}
+					break
+				}
+				if stmt[i] == '`' {
+					out.WriteByte('`')
+				}
+				out.WriteByte(stmt[i])
+			}
+			out.WriteByte('`')
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
+
+// readQuotedName reads the name at the start of s, as SHOW statements print
+// it: quoted with backticks, single or double quotes, or unquoted up to one
+// of the delimiters. It returns the name without its quotes and escapes, and
+// the rest of s.
+func readQuotedName(s string, delimiters string) (string, string, error) {
+	if s == "" {
+		return "", "", fmt.Errorf("expected a name")
+	}
+
+	q := s[0]
+	if q != '`' && q != '\'' && q != '"' {
+		end := strings.IndexAny(s, delimiters)
+		if end < 0 {
+			end = len(s)
+		}
+		return s[:end], s[end:], nil
+	}
+
+	var name strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '\'' && s[i] == '\\' && i+1 < len(s):
+			i++
+			name.WriteByte(s[i])
+		case s[i] == q && i+1 < len(s) && s[i+1] == q:
+			i++
+			name.WriteByte(q)
+		case s[i] == q:
+			return name.String(), s[i+1:], nil
+		default:
+			name.WriteByte(s[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated name: %s", s)
+}
+
+// splitQualifiedName splits configured names like db.proc, where names with
+// dots are quoted with backticks, e.g. `my.schema`.proc. The second name is
+// empty if there's none.
+func splitQualifiedName(s string) (string, string, error) {
+	first, rest, err := readBacktickName(s)
+	if err != nil || rest == "" {
+		return first, "", err
+	}
+	if !strings.HasPrefix(rest, ".") {
+		return "", "", fmt.Errorf("expected a dot after %s", first)
+	}
+	second, rest, err := readBacktickName(rest[1:])
+	if err != nil {
+		return "", "", err
+	}
+	if rest != "" {
+		return "", "", fmt.Errorf("unexpected %s after %s", rest, second)
+	}
+	return first, second, nil
+}
+
+// readBacktickName reads a name that's either quoted with backticks or
+// unquoted up to a dot.
+func readBacktickName(s string) (string, string, error) {
+	if strings.HasPrefix(s, "`") {
+		return readQuotedName(s, "")
+	}
+	if i := strings.Index(s, "."); i >= 0 {
+		return s[:i], s[i:], nil
+	}
+	return s, "", nil
+}
+
+// RetryPolicy says how often and how long to retry an operation that may
+// fail temporarily, e.g. connecting to a server that is still starting.
+type RetryPolicy struct {
+	// Timeout limits the total time spent, if positive.
+	Timeout time.Duration
+	// MaxRetries limits the number of retries, if positive.
+	MaxRetries int
+	// Interval is the wait before the first retry.
+	Interval time.Duration
+	// MaxInterval caps the wait between retries when backing off.
+	MaxInterval time.Duration
+	// Backoff doubles the wait after every retry.
+	Backoff bool
+}
+
+// Retry runs f until it succeeds, returns a non-retryable error or the policy
+// gives up, and returns the last error.
+func (p RetryPolicy) Retry(ctx context.Context, f func() (retryable bool, err error)) error {
+	var deadline time.Time
+	if p.Timeout > 0 {
+		deadline = time.Now().Add(p.Timeout)
+	}
+	interval := p.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	for attempt := 0; ; attempt++ {
+		retryable, err := f()
+		if err == nil || !retryable {
+			return err
+		}
+		if p.MaxRetries > 0 && attempt >= p.MaxRetries {
+			return fmt.Errorf("giving up after %d retries: %w", attempt, err)
+		}
+
+		wait := interval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return fmt.Errorf("timeout after %s: %w", p.Timeout, err)
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+		}
+		log.Printf("[DEBUG] Retrying in %s after error: %v", wait, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		if p.Backoff {
+			interval *= 2
+			if p.MaxInterval > 0 && interval > p.MaxInterval {
+				interval = p.MaxInterval
+			}
+		}
+	}
+}
+
+const (
+	maxUserNameLength   = 32
+	maxHostLength       = 255
+	maxIdentifierLength = 64
+)
+
+// validateUserName checks user and role names, which MySQL rejects with error
+// 1470 if they are too long.
+func validateUserName(val any, key string) (warns []string, errs []error) {
+	name := val.(string)
+	if n := utf8.RuneCountInString(name); n > maxUserNameLength {
+		errs = append(errs, fmt.Errorf("%q can be at most %d characters long, got %d: %s", key, maxUserNameLength, n, name))
+	}
+	return
+}
+
+// validateHostPattern checks host names and patterns like 10.0.0.% of
+// accounts.
+func validateHostPattern(val any, key string) (warns []string, errs []error) {
+	host := val.(string)
+	if n := utf8.RuneCountInString(host); n > maxHostLength {
+		errs = append(errs, fmt.Errorf("%q can be at most %d characters long, got %d: %s", key, maxHostLength, n, host))
+	}
+	if strings.IndexFunc(host, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		errs = append(errs, fmt.Errorf("%q can't contain spaces or control characters, got: %q", key, host))
+	}
+	if strings.Contains(host, "/") {
+		if err := checkNetmaskHost(host); err != nil {
+			errs = append(errs, fmt.Errorf("%q %v, got: %s", key, err, host))
+		}
+	}
+	if strings.HasPrefix(host, "[") {
+		errs = append(errs, fmt.Errorf("%q must have IPv6 addresses without brackets, got: %s", key, host))
+	}
+	// Hosts don't take zones; the % of fe80::1%eth0 matches any characters.
+	if address, zone, ok := strings.Cut(host, "%"); ok && zone != "" && net.ParseIP(address) != nil && strings.Contains(address, ":") {
+		warns = append(warns, fmt.Sprintf("%q has %% as a wildcard, not as the zone of an IPv6 address: %s", key, host))
+	}
+	return
+}
+
+// bracketIPv6Host puts IPv6 hosts in brackets, for IDs that separate their
+// parts with colons.
+func bracketIPv6Host(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// unbracketHost removes the brackets of IPv6 hosts of IDs.
+func unbracketHost(host string) string {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1]
+	}
+	return host
+}
+
+// checkNetmaskHost checks hosts like 192.168.0.0/255.255.255.0 or, since MySQL
+// 8.0.23, 192.168.0.0/24. The server matches client addresses that equal the
+// address once masked, so an address with bits outside the mask never
+// matches.
+func checkNetmaskHost(host string) error {
+	address, mask, _ := strings.Cut(host, "/")
+	ip := net.ParseIP(address).To4()
+	if ip == nil {
+		return fmt.Errorf("must have an IPv4 address before the netmask")
+	}
+
+	var ipMask net.IPMask
+	if bits, err := strconv.Atoi(mask); err == nil {
+		if bits < 0 || bits > 32 {
+			return fmt.Errorf("must have a prefix length from 0 to 32")
+		}
+		ipMask = net.CIDRMask(bits, 32)
+	} else if m := net.ParseIP(mask).To4(); m != nil {
+		ipMask = net.IPMask(m)
+		if _, size := ipMask.Size(); size == 0 {
+			return fmt.Errorf("must have a netmask with contiguous bits")
+		}
+	} else {
+		return fmt.Errorf("must have a netmask like 255.255.255.0 or a prefix length")
+	}
+
+	if !ip.Mask(ipMask).Equal(ip) {
+		return fmt.Errorf("must have an address without bits outside the netmask")
+	}
+	return nil
+}
+
+// validateIdentifier checks names of databases, tables and other schema
+// objects against the rules of
+// https://dev.mysql.com/doc/refman/8.0/en/identifiers.html.
+func validateIdentifier(val any, key string) (warns []string, errs []error) {
+	name := val.(string)
+	if name == "" {
+		errs = append(errs, fmt.Errorf("%q can't be empty", key))
+		return
+	}
+	if n := utf8.RuneCountInString(name); n > maxIdentifierLength {
+		errs = append(errs, fmt.Errorf("%q can be at most %d characters long, got %d: %s", key, maxIdentifierLength, n, name))
+	}
+	if strings.HasSuffix(name, " ") {
+		errs = append(errs, fmt.Errorf("%q can't end with a space, got: %q", key, name))
+	}
+	if strings.IndexFunc(name, func(r rune) bool { return r == 0 || r > 0xFFFF }) >= 0 {
+		errs = append(errs, fmt.Errorf("%q can only contain characters of the Unicode BMP other than U+0000, got: %q", key, name))
+	}
+	return
+}
+
+var (
+	// escapedLiteralRegex matches the inside of string literals, with quotes
+	// escaped by a backslash or doubled.
+	escapedLiteralRegex = regexp.MustCompile(`^(?:[^'\\]|\\.|'')*$`)
+
+	tlsLiteral     = `'(?:[^'\\]|\\.|'')*'`
+	tlsRequirement = `(?:SUBJECT|ISSUER|CIPHER)\s+` + tlsLiteral
+	tlsOptionRegex = regexp.MustCompile(`(?i)^\s*(?:|NONE|SSL|X509|` + tlsRequirement + `(?:\s+(?:AND\s+)?` + tlsRequirement + `)*)\s*$`)
+
+	// tlsRequireRegex finds the REQUIRE clause in SHOW CREATE USER and SHOW
+	// GRANTS output, but not PASSWORD REQUIRE CURRENT.
+	tlsRequireRegex         = regexp.MustCompile(`(?i)\bREQUIRE\s+(NONE|SSL|X509|` + tlsRequirement + `(?:\s+(?:AND\s+)?` + tlsRequirement + `)*)`)
+	tlsRequirementPartRegex = regexp.MustCompile(`(?i)(SUBJECT|ISSUER|CIPHER)\s+(` + tlsLiteral + `)`)
+)
+
+// normalizeTLSOption returns the canonical form of a tls_option: NONE, SSL,
+// X509 or the SUBJECT, ISSUER and CIPHER requirements in this order, joined by
+// AND. The server may list them in another order or without AND.
+func normalizeTLSOption(option string) string {
+	option = strings.TrimSpace(option)
+	switch upper := strings.ToUpper(option); upper {
+	case "", "NONE":
+		return "NONE"
+	case "SSL", "X509":
+		return upper
+	}
+
+	requirements := map[string]string{}
+	for _, m := range tlsRequirementPartRegex.FindAllStringSubmatch(option, -1) {
+		requirements[strings.ToUpper(m[1])] = m[2]
+	}
+	if len(requirements) == 0 {
+		return option
+	}
+	parts := make([]string, 0, len(requirements))
+	for _, key := range []string{"SUBJECT", "ISSUER", "CIPHER"} {
+		if value, ok := requirements[key]; ok {
+			parts = append(parts, key+" "+value)
+		}
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// parseTLSOption returns the normalized TLS requirement of a SHOW CREATE USER
+// or SHOW GRANTS row, or NONE if it has no REQUIRE clause.
+func parseTLSOption(stmt string) string {
+	if m := codeSubmatches(tlsRequireRegex, stmt, queryCode(stmt, true)); m != nil {
+		return normalizeTLSOption(m[1])
+	}
+	return "NONE"
+}
+
+// codeSubmatches matches the regex against the code of the statement, which
+// has the contents of quoted names and strings blanked, so that keywords in
+// them don't match, and returns the submatches of the statement itself.
+func codeSubmatches(regex *regexp.Regexp, stmt string, code string) []string {
+	m := regex.FindStringSubmatchIndex(code)
+	if m == nil {
+		return nil
+	}
+	matches := make([]string, 0, len(m)/2)
+	for i := 0; i+1 < len(m); i += 2 {
+		if m[i] < 0 {
+			matches = append(matches, "")
+			continue
+		}
+		matches = append(matches, stmt[m[i]:m[i+1]])
+	}
+	return matches
+}
+
+// validateEscapedLiteral checks values that are put in string literals as
+// they are, because they are escaped already.
+func validateEscapedLiteral(val any, key string) (warns []string, errs []error) {
+	if !escapedLiteralRegex.MatchString(val.(string)) {
+		errs = append(errs, fmt.Errorf("%q must escape quotes with a backslash or by doubling them", key))
+	}
+	return
+}
+
+// validateTLSOption checks the tls_option, which is used as the REQUIRE
+// clause, e.g. SSL or SUBJECT '/CN=jdoe' AND ISSUER '/CN=ca'.
+func validateTLSOption(val any, key string) (warns []string, errs []error) {
+	if !tlsOptionRegex.MatchString(val.(string)) {
+		errs = append(errs, fmt.Errorf("%q must be NONE, SSL, X509 or SUBJECT, ISSUER and CIPHER requirements, got: %s", key, val))
+	}
+	return
+}
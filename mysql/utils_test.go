@@ -0,0 +1,208 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	tempErr := errors.New("connection refused")
+
+	attempts := 0
+	err := RetryPolicy{MaxRetries: 3, Interval: time.Millisecond, Backoff: true}.Retry(context.Background(), func() (bool, error) {
+		attempts++
+		return true, tempErr
+	})
+	if !errors.Is(err, tempErr) || attempts != 4 {
+		t.Errorf("expected 4 attempts and the last error, got %d attempts and %v", attempts, err)
+	}
+
+	attempts = 0
+	err = RetryPolicy{Interval: time.Millisecond}.Retry(context.Background(), func() (bool, error) {
+		attempts++
+		if attempts < 3 {
+			return true, tempErr
+		}
+		return false, nil
+	})
+	if err != nil || attempts != 3 {
+		t.Errorf("expected success after 3 attempts, got %d attempts and %v", attempts, err)
+	}
+
+	attempts = 0
+	err = RetryPolicy{Interval: time.Millisecond}.Retry(context.Background(), func() (bool, error) {
+		attempts++
+		return false, tempErr
+	})
+	if err != tempErr || attempts != 1 {
+		t.Errorf("expected no retries of a permanent error, got %d attempts and %v", attempts, err)
+	}
+}
+
+func TestBacktickAnsiIdentifiers(t *testing.T) {
+	for stmt, expected := range map[string]string{
+		`GRANT SELECT ON "app".* TO "jdoe"@"%"`:                            "GRANT SELECT ON `app`.* TO `jdoe`@`%`",
+		`GRANT SELECT ("a b", "c") ON "app"."t" TO "x""y"@"%"`:             "GRANT SELECT (`a b`, `c`) ON `app`.`t` TO `x\"y`@`%`",
+		"GRANT USAGE ON *.* TO \"we`ird\"@\"%\"":                           "GRANT USAGE ON *.* TO `we``ird`@`%`",
+		`CREATE USER "u"@"%" IDENTIFIED WITH 'p' AS 'a"b\'c' REQUIRE NONE`: "CREATE USER `u`@`%` IDENTIFIED WITH 'p' AS 'a\"b\\'c' REQUIRE NONE",
+		"GRANT SELECT ON `app`.* TO 'it''s \"x\"'@'%'":                     "GRANT SELECT ON `app`.* TO 'it''s \"x\"'@'%'",
+		"GRANT SELECT ON `a\"b`.* TO `u`@`%`":                              "GRANT SELECT ON `a\"b`.* TO `u`@`%`",
+	} {
+		if got := backtickAnsiIdentifiers(stmt); got != expected {
+			t.Errorf("backtickAnsiIdentifiers(%s) = %s; expected %s", stmt, got, expected)
+		}
+	}
+}
+
+func TestReadQuotedName(t *testing.T) {
+	for _, tc := range []struct {
+		in, name, rest string
+	}{
+		{"`app-user`@`10.0.%`", "app-user", "@`10.0.%`"},
+		{"`we``ird` WITH GRANT OPTION", "we`ird", " WITH GRANT OPTION"},
+		{"'o''brien'@'%'", "o'brien", "@'%'"},
+		{`'back\\slash\'s'`, `back\slash's`, ""},
+		{`"x""y"@"%"`, `x"y`, `@"%"`},
+		{"jdoe@localhost", "jdoe", "@localhost"},
+		{"``@``", "", "@``"},
+	} {
+		name, rest, err := readQuotedName(tc.in, "@, ")
+		if err != nil || name != tc.name || rest != tc.rest {
+			t.Errorf("readQuotedName(%s) = %q, %q, %v; expected %q, %q", tc.in, name, rest, err, tc.name, tc.rest)
+		}
+	}
+
+	if _, _, err := readQuotedName("`unterminated", ""); err == nil {
+		t.Errorf("readQuotedName(`unterminated) expected an error")
+	}
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	for _, tc := range []struct {
+		validate func(any, string) ([]string, []error)
+		value    string
+		valid    bool
+	}{
+		{validateUserName, "jdoe", true},
+		{validateUserName, "", true},
+		{validateUserName, strings.Repeat("u", 32), true},
+		{validateUserName, strings.Repeat("u", 33), false},
+		{validateHostPattern, "10.0.0.%", true},
+		{validateHostPattern, "192.168.0.0/255.255.255.0", true},
+		{validateHostPattern, "192.168.0.0/24", true},
+		{validateHostPattern, "10.0.0.0/255.0.0.0", true},
+		{validateHostPattern, "192.168.0.1/255.255.255.0", false},
+		{validateHostPattern, "192.168.0.0/255.0.255.0", false},
+		{validateHostPattern, "192.168.0.0/33", false},
+		{validateHostPattern, "192.168.%/255.255.255.0", false},
+		{validateHostPattern, "example.com/255.255.255.0", false},
+		{validateHostPattern, "::1", true},
+		{validateHostPattern, "2001:db8::%", true},
+		{validateHostPattern, "[::1]", false},
+		{validateHostPattern, "2001:db8::/32", false},
+		{validateHostPattern, "example .com", false},
+		{validateHostPattern, strings.Repeat("h", 256), false},
+		{validateIdentifier, "tf-test", true},
+		{validateIdentifier, "my.db", true},
+		{validateIdentifier, strings.Repeat("ä", 64), true},
+		{validateIdentifier, strings.Repeat("d", 65), false},
+		{validateIdentifier, "", false},
+		{validateIdentifier, "trailing ", false},
+		{validateIdentifier, "nul\x00", false},
+		{validateIdentifier, "emoji😀", false},
+		{validateTLSOption, "SSL", true},
+		{validateTLSOption, "none", true},
+		{validateTLSOption, "SUBJECT '/CN=jdoe' AND ISSUER '/CN=it''s ca'", true},
+		{validateTLSOption, "CIPHER 'ECDHE-RSA-AES256-SHA384'", true},
+		{validateTLSOption, "SSL; DROP USER root", false},
+		{validateTLSOption, "SUBJECT '/CN=jdoe' OR 1", false},
+		{validateEscapedLiteral, `$A$005$a\'b''c`, true},
+		{validateEscapedLiteral, `abc' OR '1`, false},
+	} {
+		_, errs := tc.validate(tc.value, "name")
+		if (len(errs) == 0) != tc.valid {
+			t.Errorf("validating %q: expected valid %t, got %v", tc.value, tc.valid, errs)
+		}
+	}
+}
+
+func TestValidateHostPatternZone(t *testing.T) {
+	warns, errs := validateHostPattern("fe80::1%eth0", "host")
+	if len(errs) != 0 || len(warns) != 1 {
+		t.Errorf("expected a warning about the zone, got %v, %v", warns, errs)
+	}
+	if warns, _ := validateHostPattern("10.0.0.%", "host"); len(warns) != 0 {
+		t.Errorf("expected no warnings, got %v", warns)
+	}
+}
+
+func TestParseTLSOption(t *testing.T) {
+	for _, tc := range []struct {
+		stmt     string
+		expected string
+	}{
+		{"CREATE USER `jdoe`@`%` IDENTIFIED WITH 'caching_sha2_password' REQUIRE NONE PASSWORD EXPIRE DEFAULT ACCOUNT UNLOCK PASSWORD REQUIRE CURRENT DEFAULT", "NONE"},
+		{"CREATE USER `jdoe`@`%` IDENTIFIED WITH 'caching_sha2_password' REQUIRE SSL PASSWORD EXPIRE DEFAULT", "SSL"},
+		{"CREATE USER `jdoe`@`%` IDENTIFIED WITH 'caching_sha2_password' REQUIRE ISSUER '/CN=ca' SUBJECT '/CN=jdoe' PASSWORD EXPIRE DEFAULT", "SUBJECT '/CN=jdoe' AND ISSUER '/CN=ca'"},
+		{"CREATE USER `jdoe`@`%` IDENTIFIED VIA mysql_native_password USING '*94BDCEBE19083CE2A1F959FD02F964C7AF4CFC29'", "NONE"},
+		{"GRANT USAGE ON *.* TO 'jdoe'@'%' REQUIRE X509 WITH GRANT OPTION", "X509"},
+		{"GRANT SELECT ON `db`.* TO 'jdoe'@'%'", "NONE"},
+		{"CREATE USER `x REQUIRE SSL`@`%` IDENTIFIED WITH 'mysql_native_password' AS ' REQUIRE X509' REQUIRE NONE", "NONE"},
+		{"CREATE USER `jdoe`@`%` REQUIRE SUBJECT '/CN=x REQUIRE SSL' PASSWORD EXPIRE DEFAULT", "SUBJECT '/CN=x REQUIRE SSL'"},
+	} {
+		if actual := parseTLSOption(tc.stmt); actual != tc.expected {
+			t.Errorf("parseTLSOption(%q) = %q, expected %q", tc.stmt, actual, tc.expected)
+		}
+	}
+
+	for _, tc := range []struct {
+		old, new string
+		same     bool
+	}{
+		{"NONE", "", true},
+		{"SSL", "ssl", true},
+		{"SUBJECT '/CN=jdoe' AND ISSUER '/CN=ca'", "issuer '/CN=ca' subject '/CN=jdoe'", true},
+		{"SUBJECT '/CN=jdoe'", "SUBJECT '/CN=other'", false},
+		{"SSL", "NONE", false},
+	} {
+		if same := tlsOptionSuppressFunc("tls_option", tc.old, tc.new, nil); same != tc.same {
+			t.Errorf("tlsOptionSuppressFunc(%q, %q) = %t, expected %t", tc.old, tc.new, same, tc.same)
+		}
+	}
+}
+
+func TestLockPrivilegeChanges(t *testing.T) {
+	// Without serialize_privilege_changes, nothing is locked.
+	unlock := lockPrivilegeChanges(context.Background(), &MySQLConfiguration{})
+	lockPrivilegeChanges(context.Background(), &MySQLConfiguration{})()
+	unlock()
+
+	conf := &MySQLConfiguration{SerializePrivilegeChanges: true}
+	unlock = lockPrivilegeChanges(context.Background(), conf)
+	locked := make(chan struct{})
+	go func() {
+		lockPrivilegeChanges(context.Background(), conf)()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("expected the second change to wait for the first one")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	<-locked
+}
+
+func TestGrantLockName(t *testing.T) {
+	if name := grantLockName(UserOrRole{Name: "jdoe", Host: "%"}); name != "tf-mysql-jdoe@%" {
+		t.Errorf("unexpected lock name %s", name)
+	}
+	long := UserOrRole{Name: strings.Repeat("u", 32), Host: strings.Repeat("h", 60)}
+	if name := grantLockName(long); len(name) != 64 || name != grantLockName(long) {
+		t.Errorf("unexpected lock name %s for a long grantee", name)
+	}
+}
@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type VaultCredentials struct {
+	Address     string
+	Token       string
+	Namespace   string
+	Path        string
+	UsernameKey string
+	PasswordKey string
+}
+
+func vaultCredentialsFromMap(raw map[string]interface{}) VaultCredentials {
+	return VaultCredentials{
+		Address:     raw["address"].(string),
+		Token:       raw["token"].(string),
+		Namespace:   raw["namespace"].(string),
+		Path:        raw["path"].(string),
+		UsernameKey: raw["username_key"].(string),
+		PasswordKey: raw["password_key"].(string),
+	}
+}
+
+// fetchVaultCredentials reads the username and password from the secret at
+// the path. This works for the database secrets engine, which creates new
+// credentials on every read, as well as for KV secrets of version 1 and 2.
+func fetchVaultCredentials(ctx context.Context, creds VaultCredentials) (string, string, error) {
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(creds.Address, "/"), strings.TrimPrefix(creds.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("X-Vault-Token", creds.Token)
+	if creds.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", creds.Namespace)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("reading %s failed with %s: %s", creds.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", "", fmt.Errorf("failed to parse secret %s: %v", creds.Path, err)
+	}
+
+	data := secret.Data
+	// KV version 2 nests the secret, next to its metadata.
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+
+	username, ok := data[creds.UsernameKey].(string)
+	if !ok {
+		return "", "", fmt.Errorf("secret %s has no %q", creds.Path, creds.UsernameKey)
+	}
+	password, ok := data[creds.PasswordKey].(string)
+	if !ok {
+		return "", "", fmt.Errorf("secret %s has no %q", creds.Path, creds.PasswordKey)
+	}
+	return username, password, nil
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchVaultCredentials(t *testing.T) {
+	secrets := map[string]string{
+		"/v1/database/creds/terraform": `{"lease_id": "database/creds/terraform/abc", "data": {"username": "v-token-terraform", "password": "dynamic"}}`,
+		"/v1/secret/data/mysql":        `{"data": {"data": {"user": "root", "pass": "static"}, "metadata": {"version": 3}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `{"errors": ["permission denied"]}`)
+			return
+		}
+		secret, ok := secrets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, secret)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	username, password, err := fetchVaultCredentials(ctx, VaultCredentials{
+		Address: server.URL, Token: "s.token", Path: "database/creds/terraform", UsernameKey: "username", PasswordKey: "password",
+	})
+	if err != nil || username != "v-token-terraform" || password != "dynamic" {
+		t.Errorf("unexpected database credentials %q, %q, %v", username, password, err)
+	}
+
+	username, password, err = fetchVaultCredentials(ctx, VaultCredentials{
+		Address: server.URL + "/", Token: "s.token", Path: "/secret/data/mysql", UsernameKey: "user", PasswordKey: "pass",
+	})
+	if err != nil || username != "root" || password != "static" {
+		t.Errorf("unexpected KV credentials %q, %q, %v", username, password, err)
+	}
+
+	_, _, err = fetchVaultCredentials(ctx, VaultCredentials{
+		Address: server.URL, Token: "wrong", Path: "database/creds/terraform", UsernameKey: "username", PasswordKey: "password",
+	})
+	if err == nil {
+		t.Error("expected an error with a wrong token")
+	}
+}